@@ -2,30 +2,40 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/helm-unittest/helm-unittest/pkg/unittest"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/formatter"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/reporter"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
 	"github.com/spf13/cobra"
 )
 
 // testOptions stores options setup by user in command line
 type testOptions struct {
-	debugLogging   bool
-	useFailfast    bool
-	useStrict      bool
-	colored        bool
-	updateSnapshot bool
-	withSubChart   bool
-	testFiles      []string
-	valuesFiles    []string
-	outputFile     string
-	outputType     string
-	chartTestsPath string
+	debugLogging    bool
+	useFailfast     bool
+	useStrict       bool
+	colored         bool
+	updateSnapshot  string
+	snapshotReview  string
+	pruneSnapshots  bool
+	ci              bool
+	snapshotDir     string
+	snapshotPerTest bool
+	withSubChart    bool
+	testFiles       []string
+	valuesFiles     []string
+	outputFile      string
+	outputType      string
+	chartTestsPath  string
+	eventsFile      string
 }
 
 var defaultFilePattern = filepath.Join("tests", "*_test.yaml")
@@ -88,20 +98,62 @@ func RunPlugin(cmd *cobra.Command, chartPaths []string) {
 		testConfig.testFiles = []string{defaultFilePattern}
 	}
 
+	updateSnapshot, updateSnapshotPattern, err := parseUpdateSnapshot(testConfig.updateSnapshot)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	ci, err := resolveCI(cmd, updateSnapshot || testConfig.snapshotReview != "" || testConfig.pruneSnapshots)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	var snapshotReview *snapshot.Review
+	if testConfig.snapshotReview != "" {
+		if snapshotReview, err = snapshot.LoadReview(testConfig.snapshotReview); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	var output io.Writer = os.Stdout
+	var reporters []reporter.Reporter
+	closeEvents := func() {}
+	if testConfig.eventsFile != "" {
+		events, closeFile, err := openEvents(testConfig.eventsFile)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		closeEvents = closeFile
+		if testConfig.eventsFile == "-" {
+			// The events are written to stdout instead of the test output
+			output = io.Discard
+		}
+		reporters = append(reporters, reporter.NewEventStream(events))
+	}
+
 	formatter := formatter.NewFormatter(testConfig.outputFile, testConfig.outputType)
-	printer := printer.NewPrinter(os.Stdout, colored)
+	printer := printer.NewPrinter(output, colored)
 	testRunner = unittest.TestRunner{
-		Printer:        printer,
-		Formatter:      formatter,
-		UpdateSnapshot: testConfig.updateSnapshot,
-		WithSubChart:   testConfig.withSubChart,
-		Strict:         testConfig.useStrict,
-		Failfast:       testConfig.useFailfast,
-		TestFiles:      testConfig.testFiles,
-		ValuesFiles:    testConfig.valuesFiles,
-		OutputFile:     testConfig.outputFile,
-		ChartTestsPath: testConfig.chartTestsPath,
-		RenderPath:     renderPath,
+		Printer:               printer,
+		Formatter:             formatter,
+		UpdateSnapshot:        updateSnapshot,
+		UpdateSnapshotPattern: updateSnapshotPattern,
+		SnapshotReview:        snapshotReview,
+		PruneSnapshots:        testConfig.pruneSnapshots,
+		CI:                    ci,
+		SnapshotDir:           testConfig.snapshotDir,
+		SnapshotPerTest:       testConfig.snapshotPerTest,
+		WithSubChart:          testConfig.withSubChart,
+		Strict:                testConfig.useStrict,
+		Failfast:              testConfig.useFailfast,
+		TestFiles:             testConfig.testFiles,
+		ValuesFiles:           testConfig.valuesFiles,
+		OutputFile:            testConfig.outputFile,
+		ChartTestsPath:        testConfig.chartTestsPath,
+		RenderPath:            renderPath,
+		Reporters:             reporters,
 	}
 
 	log.SetFormatter(&log.TextFormatter{
@@ -110,12 +162,53 @@ func RunPlugin(cmd *cobra.Command, chartPaths []string) {
 	})
 
 	passed := testRunner.RunV3(chartPaths)
+	closeEvents()
 
 	if !passed {
 		os.Exit(1)
 	}
 }
 
+// parseUpdateSnapshot parses the value of the update-snapshot flag, which is a boolean
+// or the pattern of the suite or test names to update the snapshots of.
+func parseUpdateSnapshot(value string) (bool, *regexp.Regexp, error) {
+	switch value {
+	case "", "false":
+		return false, nil, nil
+	case "true":
+		return true, nil, nil
+	}
+	pattern, err := regexp.Compile(value)
+	if err != nil {
+		return false, nil, fmt.Errorf("invalid update-snapshot pattern: %w", err)
+	}
+	return true, pattern, nil
+}
+
+// resolveCI resolves the CI mode, which is enabled by the ci flag, or by the CI environment variable
+// unless the snapshots are written. The ci flag cannot be combined with writing the snapshots.
+func resolveCI(cmd *cobra.Command, writesSnapshots bool) (bool, error) {
+	if !cmd.PersistentFlags().Changed("ci") {
+		return os.Getenv("CI") == "true" && !writesSnapshots, nil
+	}
+	if testConfig.ci && writesSnapshots {
+		return false, fmt.Errorf("--ci cannot be combined with --update-snapshot, --snapshot-review or --prune-snapshots")
+	}
+	return testConfig.ci, nil
+}
+
+// openEvents opens the file to write the events to, where "-" is stdout.
+func openEvents(eventsFile string) (io.Writer, func(), error) {
+	if eventsFile == "-" {
+		return os.Stdout, func() {}, nil
+	}
+	file, err := os.Create(eventsFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	return file, func() { file.Close() }, nil
+}
+
 // main to execute execute unittest command
 func main() {
 	if err := cmd.Execute(); err != nil {
@@ -149,9 +242,35 @@ func InitPluginFlags(cmd *cobra.Command) {
 		"absolute or glob paths of values files location to override helmchart values",
 	)
 
-	cmd.PersistentFlags().BoolVarP(
-		&testConfig.updateSnapshot, "update-snapshot", "u", false,
-		"update the snapshot cached if needed, make sure you review the change before update",
+	cmd.PersistentFlags().StringVarP(
+		&testConfig.updateSnapshot, "update-snapshot", "u", "",
+		"update the snapshot cached if needed, make sure you review the change before update. Use --update-snapshot=PATTERN to only update the snapshots of suites or tests with a name matching the regular expression",
+	)
+	cmd.PersistentFlags().Lookup("update-snapshot").NoOptDefVal = "true"
+
+	cmd.PersistentFlags().StringVar(
+		&testConfig.snapshotReview, "snapshot-review", "",
+		"snapshot-review the file listing the changed snapshots to accept, only these snapshots are updated",
+	)
+
+	cmd.PersistentFlags().BoolVar(
+		&testConfig.pruneSnapshots, "prune-snapshots", false,
+		"remove the obsolete snapshots and the snapshot files of removed suites",
+	)
+
+	cmd.PersistentFlags().BoolVar(
+		&testConfig.ci, "ci", false,
+		"never write snapshots, fail when snapshots are missing or when obsolete snapshots or snapshot files of removed suites exist. Enabled when the CI environment variable is true",
+	)
+
+	cmd.PersistentFlags().StringVar(
+		&testConfig.snapshotDir, "snapshot-dir", "",
+		"snapshot-dir the directory where the snapshots are stored, keeping the layout of the suite files relative to the chart. Relative to the chart, defaults to the __snapshot__ directory next to the suite files",
+	)
+
+	cmd.PersistentFlags().BoolVar(
+		&testConfig.snapshotPerTest, "snapshot-per-test", false,
+		"store the snapshots of each test in a file of its own, instead of a file per suite",
 	)
 
 	cmd.PersistentFlags().BoolVarP(
@@ -174,6 +293,11 @@ func InitPluginFlags(cmd *cobra.Command) {
 		"chart-tests-path the folder location relative to the chart where a helm chart to render test suites is located",
 	)
 
+	cmd.PersistentFlags().StringVar(
+		&testConfig.eventsFile, "events", "",
+		"events the file where the test progress is streamed to as newline delimited JSON events, use - for stdout instead of the test output",
+	)
+
 	cmd.PersistentFlags().BoolVarP(
 		&testConfig.useFailfast, "failfast", "q", false,
 		"actually directly quit testing, when a test is failed",
@@ -166,6 +166,93 @@ func TestValidateUnittestUpdateSnapshotFlags(t *testing.T) {
 	}
 }
 
+func TestValidateUnittestUpdateSnapshotPatternFlags(t *testing.T) {
+	a := assert.New(t)
+
+	updateSnapshotPatterns := map[string]string{
+		"--update-snapshot=deployment": "deployment",
+		"-u=should render .*":          "should render .*",
+	}
+
+	for updateSnapshotFlag, updateSnapshotPattern := range updateSnapshotPatterns {
+		cmd := setupTestCmd()
+		cmd.SetArgs([]string{updateSnapshotFlag})
+
+		err := cmd.Execute()
+		runner := GetTestRunner()
+
+		a.Nil(err)
+		a.True(runner.UpdateSnapshot)
+		a.Equal(updateSnapshotPattern, runner.UpdateSnapshotPattern.String())
+	}
+}
+
+func TestValidateUnittestPruneSnapshotsAndCIFlags(t *testing.T) {
+	a := assert.New(t)
+	t.Setenv("CI", "")
+
+	snapshotFlags := map[string][2]bool{
+		"":                        {false, false},
+		"--prune-snapshots":       {true, false},
+		"--prune-snapshots=false": {false, false},
+		"--ci":                    {false, true},
+		"--ci=false":              {false, false},
+	}
+
+	for snapshotFlag, snapshotFlagValues := range snapshotFlags {
+		cmd := setupTestCmd()
+		if len(snapshotFlag) > 0 {
+			cmd.SetArgs([]string{snapshotFlag})
+		}
+
+		err := cmd.Execute()
+		runner := GetTestRunner()
+
+		a.Nil(err)
+		a.Equal(snapshotFlagValues[0], runner.PruneSnapshots)
+		a.Equal(snapshotFlagValues[1], runner.CI)
+	}
+}
+
+func TestValidateUnittestCIEnvironment(t *testing.T) {
+	a := assert.New(t)
+	t.Setenv("CI", "true")
+
+	ciFlags := map[string]bool{
+		"":                  true,
+		"--ci=false":        false,
+		"--update-snapshot": false,
+		"--prune-snapshots": false,
+	}
+
+	for ciFlag, ciValue := range ciFlags {
+		cmd := setupTestCmd()
+		if len(ciFlag) > 0 {
+			cmd.SetArgs([]string{ciFlag})
+		}
+
+		err := cmd.Execute()
+		runner := GetTestRunner()
+
+		a.Nil(err)
+		a.Equal(ciValue, runner.CI)
+	}
+}
+
+func TestValidateUnittestSnapshotLayoutFlags(t *testing.T) {
+	a := assert.New(t)
+
+	cmd := setupTestCmd()
+	cmd.SetArgs([]string{"--snapshot-dir", "snapshots", "--snapshot-per-test"})
+
+	err := cmd.Execute()
+	runner := GetTestRunner()
+
+	a.Nil(err)
+	a.Equal("snapshots", runner.SnapshotDir)
+	a.True(runner.SnapshotPerTest)
+}
+
 func TestValidateUnittestWithSnapshotFlags(t *testing.T) {
 	a := assert.New(t)
 
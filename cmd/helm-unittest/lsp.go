@@ -0,0 +1,39 @@
+package main
+
+import (
+	"os"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/lsp"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var lspCmd = &cobra.Command{
+	Use:   "lsp",
+	Short: "language server for test suite files",
+	Long: `Start a language server speaking the Language Server Protocol over stdio.
+
+The language server offers completion of assertion types and template paths,
+hover documentation, diagnostics, go-to-definition of templates and code lenses
+to run a single test from within your editor.
+`,
+	Args: cobra.NoArgs,
+	RunE: RunLanguageServer,
+}
+
+// RunLanguageServer serves the language server over stdin and stdout
+func RunLanguageServer(cmd *cobra.Command, args []string) error {
+	// stdout is reserved for the protocol messages
+	log.SetOutput(os.Stderr)
+	if testConfig.debugLogging {
+		log.SetLevel(log.DebugLevel)
+	}
+
+	server := lsp.NewServer(cmd.InOrStdin(), os.Stdout, testConfig.useStrict).
+		WithSnapshotLayout(testConfig.snapshotDir, testConfig.snapshotPerTest)
+	return server.Serve()
+}
+
+func init() {
+	cmd.AddCommand(lspCmd)
+}
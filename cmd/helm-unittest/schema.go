@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest"
+	"github.com/spf13/cobra"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema [FILE]",
+	Short: "json schema of the test suite files",
+	Long: `Generate the json schema of the test suite files.
+
+The schema is generated from the test suite types and the supported
+assertions, it is written to FILE or to stdout when no FILE is given.
+`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: RunSchema,
+}
+
+// RunSchema writes the generated json schema of the test suite files
+func RunSchema(cmd *cobra.Command, args []string) error {
+	schema, err := unittest.GenerateTestSuiteSchema()
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		_, err = cmd.OutOrStdout().Write(schema)
+		return err
+	}
+	return os.WriteFile(args[0], schema, 0644)
+}
+
+func init() {
+	cmd.AddCommand(schemaCmd)
+}
@@ -2,31 +2,40 @@ package unittest
 
 import (
 	"fmt"
+	"io"
 	"reflect"
 	"sort"
 	"strings"
+	"text/template"
+	"text/template/parse"
 
 	"github.com/helm-unittest/helm-unittest/internal/common"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/results"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
 
+	"github.com/Masterminds/sprig/v3"
 	"github.com/mitchellh/mapstructure"
 	log "github.com/sirupsen/logrus"
 )
 
 // Assertion defines target and metrics to validate rendered result
 type Assertion struct {
-	Template             string
-	DocumentSelector     *valueutils.DocumentSelector
-	DocumentIndex        int
-	Not                  bool
-	AssertType           string
+	Template             string                       `yaml:"template"`
+	DocumentSelector     *valueutils.DocumentSelector `yaml:"documentSelector"`
+	DocumentIndex        int                          `yaml:"documentIndex"`
+	Not                  bool                         `yaml:"not"`
+	Templated            bool                         `yaml:"templated"`
+	AssertType           string                       `yaml:"-"`
 	validator            validators.Validatable
 	requireRenderSuccess bool
 	antonym              bool
 	defaultTemplates     []string
 	config               AssertionConfig
+	// composite holds the nested assertions of the anyOf, allOf and noneOf assertions
+	composite []*Assertion
+	// params are the parameters of the validator as declared, rendered for templated assertions
+	params interface{}
 }
 
 func (a *Assertion) WithConfig(config AssertionConfig) {
@@ -47,6 +56,22 @@ func (a *Assertion) Assert(
 	result.AssertType = a.AssertType
 	result.Not = a.Not
 
+	if a.Templated && a.validator != nil {
+		if err := a.renderValidator(); err != nil {
+			result.Passed = false
+			result.FailInfo = []string{"Error:", err.Error()}
+			return result
+		}
+	}
+
+	if a.composite != nil {
+		return a.evaluateComposite(result)
+	}
+
+	if _, ok := a.validator.(validators.ReleaseValidatable); ok {
+		return a.evaluateRelease(result)
+	}
+
 	var templates = a.computeTemplatesWithPostRender()
 
 	// TODO: This could be optimised and computed once for the test suite
@@ -68,6 +93,10 @@ func (a *Assertion) Assert(
 		return a.evaluateEmptyTemplates(result)
 	}
 
+	if _, ok := a.validator.(validators.SingleTemplateValidatable); ok && len(selectedTemplates) > 1 {
+		return a.handleTemplatesError(result, selectedTemplates)
+	}
+
 	return a.evaluateTemplates(result, selectedTemplates, selectedDocsByTemplate)
 }
 
@@ -79,6 +108,18 @@ func (a *Assertion) handleIndexError(result *results.AssertionResult, indexError
 	return result
 }
 
+// handleTemplatesError handles the error when the assertion of a single template selects several templates
+// It sets the assertion result to failed and lists the selected templates
+func (a *Assertion) handleTemplatesError(result *results.AssertionResult, selectedTemplates []string) *results.AssertionResult {
+	result.Passed = false
+	result.FailInfo = []string{
+		"Error:",
+		fmt.Sprintf("%s asserts a single template, select one of %s with `template`",
+			a.AssertType, strings.Join(selectedTemplates, ", ")),
+	}
+	return result
+}
+
 // shouldSkipAssertion checks if the assertion should be skipped based on the configuration
 func (a *Assertion) shouldSkipAssertion(selectedTemplates []string) bool {
 	return a.configOrDefault().isSkipEmptyTemplate && len(selectedTemplates) == 0
@@ -107,7 +148,7 @@ func (a *Assertion) evaluateEmptyTemplates(
 		failInfo = append(failInfo, invalidRender)
 	} else {
 		var emptyTemplate []common.K8sManifest
-		_, validatePassed, failInfo = a.validateTemplate(emptyTemplate, emptyTemplate)
+		_, validatePassed, failInfo = a.validateTemplate("", emptyTemplate, emptyTemplate)
 	}
 
 	result.Passed = validatePassed
@@ -146,6 +187,87 @@ func (a *Assertion) evaluateTemplates(
 	return result
 }
 
+// evaluateRelease evaluates the assertion once for the documents of all rendered templates
+// It returns the assertion result with the validation status and failure information
+func (a *Assertion) evaluateRelease(result *results.AssertionResult) *results.AssertionResult {
+	templatesResult := a.configOrDefault().templatesResult
+
+	if a.requireRenderSuccess != a.configOrDefault().renderSucceed {
+		templates := a.getKeys(templatesResult)
+		sort.Strings(templates)
+
+		var rendered []common.K8sManifest
+		if len(templates) > 0 {
+			rendered = templatesResult[templates[0]]
+		}
+		result.Passed = false
+		result.FailInfo = a.handleRenderError(rendered)
+		return result
+	}
+
+	result.Passed, result.FailInfo = a.validator.Validate(&validators.ValidateContext{
+		Negative:                  a.Not != a.antonym,
+		SnapshotComparer:          a.configOrDefault().snapshotComparer,
+		RenderError:               a.configOrDefault().renderError,
+		FailFast:                  a.configOrDefault().failFast,
+		Release:                   templatesResult,
+		BaseDirectory:             a.configOrDefault().baseDirectory,
+		CustomResourceDefinitions: a.configOrDefault().customResourceDefinitions,
+		CustomResourceSchemas:     a.configOrDefault().customResourceSchemas,
+		RenderValues:              a.configOrDefault().renderValues,
+	})
+	return result
+}
+
+// evaluateComposite evaluates the nested assertions with the configuration of the assertion
+// and combines their results, the failure information lists which nested assertions passed
+func (a *Assertion) evaluateComposite(result *results.AssertionResult) *results.AssertionResult {
+	passed := 0
+	evaluated := 0
+	failInfo := make([]string, 0)
+
+	for idx, nested := range a.composite {
+		nested.WithConfig(a.configOrDefault())
+		nestedResult := nested.Assert(&results.AssertionResult{Index: idx})
+
+		var notAnnotation string
+		if nested.Not {
+			notAnnotation = " NOT"
+		}
+		status := "fail"
+		switch {
+		case nestedResult.Skipped:
+			status = "skipped"
+		case nestedResult.Passed:
+			status = "passed"
+		}
+		failInfo = append(failInfo, fmt.Sprintf("- %s[%d]%s `%s` %s", a.AssertType, idx, notAnnotation, nested.AssertType, status))
+		for _, infoLine := range nestedResult.FailInfo {
+			failInfo = append(failInfo, "\t"+infoLine)
+		}
+
+		if nestedResult.Skipped {
+			continue
+		}
+		evaluated++
+		if nestedResult.Passed {
+			passed++
+		}
+	}
+
+	if evaluated == 0 {
+		result.Skipped = true
+		result.SkipReason = fmt.Sprintf("skipped as all assertions of '%s' are skipped", a.AssertType)
+		result.Passed = true
+		log.WithField(common.LOG_TEST_ASSERTION, "assert").Debugln("skip assertion", result.SkipReason)
+		return result
+	}
+
+	result.Passed = compositeAssertTypes[a.AssertType](passed, evaluated) != a.Not
+	result.FailInfo = failInfo
+	return result
+}
+
 // processTemplate processes the template and validates it using the configured validator
 // It returns a boolean indicating if the template needs to be added in the failure information,
 // a boolean indicating if the validation passed, and a slice of failure information
@@ -159,7 +281,7 @@ func (a *Assertion) processTemplate(template string, selectedDocs []common.K8sMa
 		return true, false, a.handleRenderError(rendered)
 	}
 
-	return a.validateTemplate(rendered, selectedDocs)
+	return a.validateTemplate(template, rendered, selectedDocs)
 }
 
 // handleRenderError handles the error when the rendered manifest is empty
@@ -174,17 +296,23 @@ func (a *Assertion) handleRenderError(rendered []common.K8sManifest) []string {
 // validateTemplate validates the rendered template using the configured validator
 // It returns a boolean indicating if the template needs to be added in the failure information,
 // a boolean indicating if the template is valid and a slice of failure information
-func (a *Assertion) validateTemplate(rendered []common.K8sManifest, selectedDocs []common.K8sManifest) (bool, bool, []string) {
+func (a *Assertion) validateTemplate(template string, rendered []common.K8sManifest, selectedDocs []common.K8sManifest) (bool, bool, []string) {
 	var validatePassed bool
 	var singleFailInfo []string
 
 	validatePassed, singleFailInfo = a.validator.Validate(&validators.ValidateContext{
-		Docs:             rendered,
-		SelectedDocs:     &selectedDocs,
-		Negative:         a.Not != a.antonym,
-		SnapshotComparer: a.configOrDefault().snapshotComparer,
-		RenderError:      a.configOrDefault().renderError,
-		FailFast:         a.configOrDefault().failFast,
+		Docs:                      rendered,
+		SelectedDocs:              &selectedDocs,
+		Negative:                  a.Not != a.antonym,
+		SnapshotComparer:          a.configOrDefault().snapshotComparer,
+		RenderError:               a.configOrDefault().renderError,
+		FailFast:                  a.configOrDefault().failFast,
+		Template:                  template,
+		InlineSnapshotUpdater:     a.configOrDefault().inlineSnapshotUpdater,
+		BaseDirectory:             a.configOrDefault().baseDirectory,
+		CustomResourceDefinitions: a.configOrDefault().customResourceDefinitions,
+		CustomResourceSchemas:     a.configOrDefault().customResourceSchemas,
+		RenderValues:              a.configOrDefault().renderValues,
 	})
 
 	return true, validatePassed, singleFailInfo
@@ -247,6 +375,18 @@ func (a *Assertion) noFileErrMessage(template string) string {
 	return "\tassertion.template must be given if testsuite.templates is empty"
 }
 
+// newAssertion returns the assertion of the assert type with the validator, like it is declared in a test
+func newAssertion(assertType string, validator validators.Validatable) *Assertion {
+	return &Assertion{
+		DocumentIndex:        -1,
+		AssertType:           assertType,
+		validator:            validator,
+		requireRenderSuccess: assertTypeMapping[assertType].expectRenderSuccess,
+		antonym:              assertTypeMapping[assertType].antonym,
+		defaultTemplates:     []string{""},
+	}
+}
+
 // UnmarshalYAML implements yaml.Unmarshaler, constructing the validator according to the assert type.
 func (a *Assertion) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	assertDef := make(map[string]interface{})
@@ -254,6 +394,11 @@ func (a *Assertion) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		return err
 	}
 
+	return a.parse(assertDef)
+}
+
+// parse constructs the assertion from its definition, the nested assertions of a composite assertion recursively.
+func (a *Assertion) parse(assertDef map[string]interface{}) error {
 	a.parseBasicFields(assertDef)
 	if err := a.parseDocumentSelector(assertDef); err != nil {
 		return err
@@ -263,7 +408,11 @@ func (a *Assertion) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		return err
 	}
 
-	if a.validator == nil {
+	if err := a.constructComposite(assertDef); err != nil {
+		return err
+	}
+
+	if a.validator == nil && a.composite == nil {
 		return a.validateAssertionType(assertDef)
 	}
 
@@ -285,6 +434,10 @@ func (a *Assertion) parseBasicFields(assertDef map[string]interface{}) {
 	if template, ok := assertDef["template"].(string); ok {
 		a.Template = template
 	}
+
+	if templated, ok := assertDef["templated"].(bool); ok {
+		a.Templated = templated
+	}
 }
 
 // parseDocumentSelector parses the documentSelector field if present.
@@ -302,7 +455,7 @@ func (a *Assertion) parseDocumentSelector(assertDef map[string]interface{}) erro
 // validateAssertionType validates the assertion type and ensures at least one is defined.
 func (a *Assertion) validateAssertionType(assertDef map[string]interface{}) error {
 	for key := range assertDef {
-		if key != "template" && key != "documentIndex" && key != "not" {
+		if key != "template" && key != "documentIndex" && key != "not" && key != "templated" {
 			return fmt.Errorf("Assertion type `%s` is invalid", key)
 		}
 	}
@@ -320,13 +473,14 @@ func (a *Assertion) constructValidator(assertDef map[string]interface{}) error {
 				)
 			}
 
-			validator := reflect.New(correspondDef.validatorType).Interface()
-			if err := mapstructure.Decode(params, validator); err != nil {
+			validator, err := decodeValidator(correspondDef.validatorType, params)
+			if err != nil {
 				return err
 			}
 
 			a.AssertType = assertName
-			a.validator = validator.(validators.Validatable)
+			a.validator = validator
+			a.params = params
 			a.requireRenderSuccess = correspondDef.expectRenderSuccess
 			a.antonym = correspondDef.antonym
 			a.defaultTemplates = []string{a.Template}
@@ -335,6 +489,225 @@ func (a *Assertion) constructValidator(assertDef map[string]interface{}) error {
 	return nil
 }
 
+// constructComposite constructs the nested assertions of the anyOf, allOf and noneOf assertions,
+// which inherit the template and the document filters of the composite assertion unless they declare their own.
+func (a *Assertion) constructComposite(assertDef map[string]interface{}) error {
+	for assertName := range compositeAssertTypes {
+		params, ok := assertDef[assertName]
+		if !ok {
+			continue
+		}
+		if a.AssertType != "" {
+			return fmt.Errorf(
+				"assertion type `%s` and `%s` is declared duplicately",
+				a.AssertType,
+				assertName,
+			)
+		}
+
+		definitions, ok := params.([]interface{})
+		if !ok || len(definitions) == 0 {
+			return fmt.Errorf("assertion type `%s` must contain a list of assertions", assertName)
+		}
+
+		a.AssertType = assertName
+		a.requireRenderSuccess = true
+		a.defaultTemplates = []string{a.Template}
+		a.composite = make([]*Assertion, 0, len(definitions))
+		for idx, definition := range definitions {
+			nestedDef, ok := definition.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("assertion type `%s` must contain a list of assertions", assertName)
+			}
+
+			nested := &Assertion{}
+			if err := nested.parse(nestedDef); err != nil {
+				return fmt.Errorf("%s[%d]: %w", assertName, idx, err)
+			}
+			// The suite file holds the inline snapshots of the assertions of the tests only
+			if nested.AssertType == inlineSnapshotAssertType {
+				return fmt.Errorf("%s[%d]: assertion type `%s` is not supported in `%s`", assertName, idx, inlineSnapshotAssertType, assertName)
+			}
+			if nested.Template == "" {
+				nested.Template = a.Template
+				nested.defaultTemplates = []string{a.Template}
+			}
+			if nested.DocumentSelector == nil {
+				nested.DocumentSelector = a.DocumentSelector
+			}
+			if nested.DocumentIndex == -1 {
+				nested.DocumentIndex = a.DocumentIndex
+			}
+			nested.Templated = nested.Templated || a.Templated
+
+			// The composite assertion expects a successful rendering, unless a nested assertion asserts a failed rendering
+			a.requireRenderSuccess = a.requireRenderSuccess && nested.requireRenderSuccess
+			a.composite = append(a.composite, nested)
+		}
+	}
+	return nil
+}
+
+// renderValidator constructs the validator of a templated assertion from its parameters,
+// rendered with the values the chart is rendered with.
+func (a *Assertion) renderValidator() error {
+	// Without render values the chart failed before rendering, like values not matching the schema of the chart
+	if a.configOrDefault().renderValues == nil && a.configOrDefault().renderError != nil {
+		return fmt.Errorf("unable to render the templated assertion, the chart is not rendered: %s", a.configOrDefault().renderError)
+	}
+
+	params, err := renderExpectedValue(a.params, a.configOrDefault().renderValues)
+	if err != nil {
+		return fmt.Errorf("unable to render the templated assertion: %s", err)
+	}
+
+	validator, err := decodeValidator(assertTypeMapping[a.AssertType].validatorType, params)
+	if err != nil {
+		return err
+	}
+	a.validator = validator
+	return nil
+}
+
+// decodeValidator decodes the parameters of an assertion into a new validator of the type,
+// telling the validators which receive them the fields missing from the parameters.
+func decodeValidator(validatorType reflect.Type, params interface{}) (validators.Validatable, error) {
+	validator := reflect.New(validatorType).Interface()
+	var metadata mapstructure.Metadata
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{Metadata: &metadata, Result: validator})
+	if err != nil {
+		return nil, err
+	}
+	if err := decoder.Decode(params); err != nil {
+		return nil, err
+	}
+
+	if receiver, ok := validator.(validators.UnsetFieldsReceiver); ok {
+		receiver.WithUnsetFields(metadata.Unset)
+	}
+	return validator.(validators.Validatable), nil
+}
+
+// renderExpectedValue renders the strings of the value as Go templates with the sprig functions, like the templates of the chart.
+// A string of a single field, like {{ .Chart.AppVersion }}, is the value of the field, and a string of another single action,
+// like {{ .Values.labels | toJson }}, is read as yaml to expect numbers, booleans, lists and maps.
+func renderExpectedValue(value interface{}, renderValues map[string]interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		if !strings.Contains(v, "{{") {
+			return v, nil
+		}
+
+		tpl, err := template.New("expected").Funcs(expectedValueFuncs()).Option("missingkey=error").Parse(v)
+		if err != nil {
+			return nil, err
+		}
+		var rendered strings.Builder
+		if err := tpl.Execute(&rendered, renderValues); err != nil {
+			return nil, err
+		}
+
+		if chain, ok := fieldChain(tpl); ok {
+			return fieldValue(chain, renderValues)
+		}
+
+		trimmed := strings.TrimSpace(v)
+		if strings.HasPrefix(trimmed, "{{") && strings.HasSuffix(trimmed, "}}") && strings.Count(trimmed, "{{") == 1 && rendered.Len() > 0 {
+			var typed interface{}
+			if err := common.YmlUnmarshal(rendered.String(), &typed); err == nil {
+				return typed, nil
+			}
+		}
+		return rendered.String(), nil
+	case map[string]interface{}:
+		rendered := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			renderedItem, err := renderExpectedValue(item, renderValues)
+			if err != nil {
+				return nil, err
+			}
+			rendered[key] = renderedItem
+		}
+		return rendered, nil
+	case []interface{}:
+		rendered := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			renderedItem, err := renderExpectedValue(item, renderValues)
+			if err != nil {
+				return nil, err
+			}
+			rendered = append(rendered, renderedItem)
+		}
+		return rendered, nil
+	default:
+		return value, nil
+	}
+}
+
+// fieldChain returns the field chain of a template of a single action without pipeline, like .Chart.AppVersion,
+// and whether the template is one
+func fieldChain(tpl *template.Template) (string, bool) {
+	var action *parse.ActionNode
+	for _, node := range tpl.Tree.Root.Nodes {
+		switch n := node.(type) {
+		case *parse.TextNode:
+			if strings.TrimSpace(string(n.Text)) != "" {
+				return "", false
+			}
+		case *parse.ActionNode:
+			if action != nil {
+				return "", false
+			}
+			action = n
+		default:
+			return "", false
+		}
+	}
+
+	if action == nil || len(action.Pipe.Decl) > 0 || len(action.Pipe.Cmds) != 1 || len(action.Pipe.Cmds[0].Args) != 1 {
+		return "", false
+	}
+	field, ok := action.Pipe.Cmds[0].Args[0].(*parse.FieldNode)
+	if !ok {
+		return "", false
+	}
+	return field.String(), true
+}
+
+// fieldValue returns the value of the field chain in the render values, strings are kept as is, like a version "1.10",
+// other values are read as yaml like the rendered documents, as the numbers of the values are float64
+func fieldValue(chain string, renderValues map[string]interface{}) (interface{}, error) {
+	var value interface{}
+	funcs := template.FuncMap{"expectedValue": func(v interface{}) string {
+		value = v
+		return ""
+	}}
+	tpl, err := template.New("expected").Funcs(funcs).Option("missingkey=error").Parse("{{ expectedValue " + chain + " }}")
+	if err != nil {
+		return nil, err
+	}
+	if err := tpl.Execute(io.Discard, renderValues); err != nil {
+		return nil, err
+	}
+
+	if _, ok := value.(string); ok || value == nil {
+		return value, nil
+	}
+	var typed interface{}
+	if err := common.YmlUnmarshal(common.TrustedMarshalYAML(value), &typed); err != nil {
+		return nil, err
+	}
+	return typed, nil
+}
+
+// expectedValueFuncs returns the sprig functions, without the functions reading the environment like helm does
+func expectedValueFuncs() template.FuncMap {
+	funcs := sprig.TxtFuncMap()
+	delete(funcs, "env")
+	delete(funcs, "expandenv")
+	return funcs
+}
+
 func (a *Assertion) computeTemplatesWithPostRender() map[string][]common.K8sManifest {
 	// If we PostRendered, there's no guarantee the post-renderer will preserve our file mapping.  If it doesn't, the
 	// parser just puts the whole manifest in one "manifest.yaml" so handle that case:
@@ -356,6 +729,19 @@ func (a *Assertion) computeTemplatesWithPostRender() map[string][]common.K8sMani
 	return templates
 }
 
+// AssertTypes returns the sorted names of all supported assertion types.
+func AssertTypes() []string {
+	assertTypes := make([]string, 0, len(assertTypeMapping)+len(compositeAssertTypes))
+	for assertType := range assertTypeMapping {
+		assertTypes = append(assertTypes, assertType)
+	}
+	for assertType := range compositeAssertTypes {
+		assertTypes = append(assertTypes, assertType)
+	}
+	sort.Strings(assertTypes)
+	return assertTypes
+}
+
 type assertTypeDef struct {
 	validatorType       reflect.Type
 	antonym             bool
@@ -363,41 +749,64 @@ type assertTypeDef struct {
 }
 
 var assertTypeMapping = map[string]assertTypeDef{
-	"matchSnapshot":     {reflect.TypeOf(validators.MatchSnapshotValidator{}), false, true},
-	"matchSnapshotRaw":  {reflect.TypeOf(validators.MatchSnapshotRawValidator{}), false, true},
-	"equal":             {reflect.TypeOf(validators.EqualValidator{}), false, true},
-	"notEqual":          {reflect.TypeOf(validators.EqualValidator{}), true, true},
-	"greaterOrEqual":    {reflect.TypeOf(validators.EqualOrGreaterValidator{}), false, true},
-	"notGreaterOrEqual": {reflect.TypeOf(validators.EqualOrGreaterValidator{}), true, true},
-	"lessOrEqual":       {reflect.TypeOf(validators.EqualOrLessValidator{}), false, true},
-	"notLessOrEqual":    {reflect.TypeOf(validators.EqualOrLessValidator{}), true, true},
-	"equalRaw":          {reflect.TypeOf(validators.EqualRawValidator{}), false, true},
-	"notEqualRaw":       {reflect.TypeOf(validators.EqualRawValidator{}), true, true},
-	"exists":            {reflect.TypeOf(validators.ExistsValidator{}), false, true},
-	"notExists":         {reflect.TypeOf(validators.ExistsValidator{}), true, true},
-	"matchRegex":        {reflect.TypeOf(validators.MatchRegexValidator{}), false, true},
-	"notMatchRegex":     {reflect.TypeOf(validators.MatchRegexValidator{}), true, true},
-	"matchRegexRaw":     {reflect.TypeOf(validators.MatchRegexRawValidator{}), false, true},
-	"notMatchRegexRaw":  {reflect.TypeOf(validators.MatchRegexRawValidator{}), true, true},
-	"contains":          {reflect.TypeOf(validators.ContainsValidator{}), false, true},
-	"stringContains":    {reflect.TypeOf(validators.StringContainsValidator{}), false, true},
-	"notContains":       {reflect.TypeOf(validators.ContainsValidator{}), true, true},
-	"isKind":            {reflect.TypeOf(validators.IsKindValidator{}), false, true},
-	"isAPIVersion":      {reflect.TypeOf(validators.IsAPIVersionValidator{}), false, true},
-	"hasDocuments":      {reflect.TypeOf(validators.HasDocumentsValidator{}), false, true},
-	"isSubset":          {reflect.TypeOf(validators.IsSubsetValidator{}), false, true},
-	"isNotSubset":       {reflect.TypeOf(validators.IsSubsetValidator{}), true, true},
-	"isNullOrEmpty":     {reflect.TypeOf(validators.IsNullOrEmptyValidator{}), false, true},
-	"isNotNullOrEmpty":  {reflect.TypeOf(validators.IsNullOrEmptyValidator{}), true, true},
-	"failedTemplate":    {reflect.TypeOf(validators.FailedTemplateValidator{}), false, false},
-	"notFailedTemplate": {reflect.TypeOf(validators.FailedTemplateValidator{}), true, true},
-	"containsDocument":  {reflect.TypeOf(validators.ContainsDocumentValidator{}), false, true},
-	"lengthEqual":       {reflect.TypeOf(validators.LengthEqualDocumentsValidator{}), false, true},
-	"notLengthEqual":    {reflect.TypeOf(validators.LengthEqualDocumentsValidator{}), true, true},
-	"isNull":            {reflect.TypeOf(validators.ExistsValidator{}), true, true},
-	"isNotNull":         {reflect.TypeOf(validators.ExistsValidator{}), false, true},
-	"isEmpty":           {reflect.TypeOf(validators.IsNullOrEmptyValidator{}), false, true},
-	"isNotEmpty":        {reflect.TypeOf(validators.IsNullOrEmptyValidator{}), true, true},
-	"isType":            {reflect.TypeOf(validators.IsTypeValidator{}), false, true},
-	"isNotType":         {reflect.TypeOf(validators.IsTypeValidator{}), true, true},
+	"matchSnapshot":           {reflect.TypeOf(validators.MatchSnapshotValidator{}), false, true},
+	"matchSnapshotRaw":        {reflect.TypeOf(validators.MatchSnapshotRawValidator{}), false, true},
+	"matchInlineSnapshot":     {reflect.TypeOf(validators.MatchInlineSnapshotValidator{}), false, true},
+	"matchReleaseSnapshot":    {reflect.TypeOf(validators.MatchReleaseSnapshotValidator{}), false, true},
+	"equal":                   {reflect.TypeOf(validators.EqualValidator{}), false, true},
+	"notEqual":                {reflect.TypeOf(validators.EqualValidator{}), true, true},
+	"greaterOrEqual":          {reflect.TypeOf(validators.EqualOrGreaterValidator{}), false, true},
+	"notGreaterOrEqual":       {reflect.TypeOf(validators.EqualOrGreaterValidator{}), true, true},
+	"lessOrEqual":             {reflect.TypeOf(validators.EqualOrLessValidator{}), false, true},
+	"notLessOrEqual":          {reflect.TypeOf(validators.EqualOrLessValidator{}), true, true},
+	"greaterThan":             {reflect.TypeOf(validators.GreaterThanValidator{}), false, true},
+	"notGreaterThan":          {reflect.TypeOf(validators.GreaterThanValidator{}), true, true},
+	"lessThan":                {reflect.TypeOf(validators.LessThanValidator{}), false, true},
+	"notLessThan":             {reflect.TypeOf(validators.LessThanValidator{}), true, true},
+	"equalRaw":                {reflect.TypeOf(validators.EqualRawValidator{}), false, true},
+	"notEqualRaw":             {reflect.TypeOf(validators.EqualRawValidator{}), true, true},
+	"exists":                  {reflect.TypeOf(validators.ExistsValidator{}), false, true},
+	"notExists":               {reflect.TypeOf(validators.ExistsValidator{}), true, true},
+	"matchRegex":              {reflect.TypeOf(validators.MatchRegexValidator{}), false, true},
+	"notMatchRegex":           {reflect.TypeOf(validators.MatchRegexValidator{}), true, true},
+	"matchRegexRaw":           {reflect.TypeOf(validators.MatchRegexRawValidator{}), false, true},
+	"notMatchRegexRaw":        {reflect.TypeOf(validators.MatchRegexRawValidator{}), true, true},
+	"matchJsonSchema":         {reflect.TypeOf(validators.MatchJsonSchemaValidator{}), false, true},
+	"contains":                {reflect.TypeOf(validators.ContainsValidator{}), false, true},
+	"stringContains":          {reflect.TypeOf(validators.StringContainsValidator{}), false, true},
+	"notContains":             {reflect.TypeOf(validators.ContainsValidator{}), true, true},
+	"isKind":                  {reflect.TypeOf(validators.IsKindValidator{}), false, true},
+	"isAPIVersion":            {reflect.TypeOf(validators.IsAPIVersionValidator{}), false, true},
+	"isValidKubernetesObject": {reflect.TypeOf(validators.IsValidKubernetesObjectValidator{}), false, true},
+	"isValidCustomResource":   {reflect.TypeOf(validators.IsValidCustomResourceValidator{}), false, true},
+	"satisfies":               {reflect.TypeOf(validators.SatisfiesValidator{}), false, true},
+	"jq":                      {reflect.TypeOf(validators.JqValidator{}), false, true},
+	"isSemver":                {reflect.TypeOf(validators.IsSemverValidator{}), false, true},
+	"semverSatisfies":         {reflect.TypeOf(validators.SemverSatisfiesValidator{}), false, true},
+	"hasValidReferences":      {reflect.TypeOf(validators.HasValidReferencesValidator{}), false, true},
+	"image":                   {reflect.TypeOf(validators.ImageValidator{}), false, true},
+	"hasDocuments":            {reflect.TypeOf(validators.HasDocumentsValidator{}), false, true},
+	"isSubset":                {reflect.TypeOf(validators.IsSubsetValidator{}), false, true},
+	"isNotSubset":             {reflect.TypeOf(validators.IsSubsetValidator{}), true, true},
+	"isNullOrEmpty":           {reflect.TypeOf(validators.IsNullOrEmptyValidator{}), false, true},
+	"isNotNullOrEmpty":        {reflect.TypeOf(validators.IsNullOrEmptyValidator{}), true, true},
+	"failedTemplate":          {reflect.TypeOf(validators.FailedTemplateValidator{}), false, false},
+	"notFailedTemplate":       {reflect.TypeOf(validators.FailedTemplateValidator{}), true, true},
+	"containsDocument":        {reflect.TypeOf(validators.ContainsDocumentValidator{}), false, true},
+	"lengthEqual":             {reflect.TypeOf(validators.LengthEqualDocumentsValidator{}), false, true},
+	"notLengthEqual":          {reflect.TypeOf(validators.LengthEqualDocumentsValidator{}), true, true},
+	"isNull":                  {reflect.TypeOf(validators.ExistsValidator{}), true, true},
+	"isNotNull":               {reflect.TypeOf(validators.ExistsValidator{}), false, true},
+	"isEmpty":                 {reflect.TypeOf(validators.IsNullOrEmptyValidator{}), false, true},
+	"isNotEmpty":              {reflect.TypeOf(validators.IsNullOrEmptyValidator{}), true, true},
+	"isType":                  {reflect.TypeOf(validators.IsTypeValidator{}), false, true},
+	"isNotType":               {reflect.TypeOf(validators.IsTypeValidator{}), true, true},
+}
+
+// compositeAssertTypes are the assertion types combining the results of their nested assertions,
+// deciding whether the assertion passes from the number of passed and evaluated nested assertions.
+var compositeAssertTypes = map[string]func(passed, evaluated int) bool{
+	"anyOf":  func(passed, _ int) bool { return passed > 0 },
+	"allOf":  func(passed, evaluated int) bool { return passed == evaluated },
+	"noneOf": func(passed, _ int) bool { return passed == 0 },
 }
@@ -1,6 +1,7 @@
 package unittest_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/helm-unittest/helm-unittest/internal/common"
@@ -614,3 +615,353 @@ x:
 		assert.True(t, result.Skipped)
 	}
 }
+
+func TestCompositeAssertionAssert(t *testing.T) {
+	manifest := common.TrustedUnmarshalYAML(`
+kind: Service
+apiVersion: v1
+spec:
+  type: ClusterIP
+`)
+	renderedMap := map[string][]common.K8sManifest{
+		"service.yaml": {manifest},
+		"ingress.yaml": {},
+	}
+
+	tests := []struct {
+		name           string
+		assertionYAML  string
+		expectedPassed bool
+		expectedInfo   []string
+	}{
+		{
+			name: "anyOf passes when one nested assertion passes",
+			assertionYAML: `
+anyOf:
+  - template: ingress.yaml
+    hasDocuments:
+      count: 1
+  - template: service.yaml
+    isKind:
+      of: Service
+`,
+			expectedPassed: true,
+			expectedInfo: []string{
+				"- anyOf[0] `hasDocuments` fail",
+				"\tTemplate:\tingress.yaml",
+				"\tExpected documents count to be:",
+				"\t\t1",
+				"\tActual:",
+				"\t\t0",
+				"- anyOf[1] `isKind` passed",
+			},
+		},
+		{
+			name: "allOf fails when one nested assertion fails",
+			assertionYAML: `
+template: service.yaml
+allOf:
+  - isKind:
+      of: Service
+  - equal:
+      path: spec.type
+      value: NodePort
+`,
+			expectedPassed: false,
+			expectedInfo: []string{
+				"- allOf[0] `isKind` passed",
+				"- allOf[1] `equal` fail",
+				"\tTemplate:\tservice.yaml",
+				"\tDocumentIndex:\t0",
+				"\tValuesIndex:\t0",
+				"\tPath:\tspec.type",
+				"\tExpected to equal:",
+				"\t\tNodePort",
+				"\tActual:",
+				"\t\tClusterIP",
+				"\tDiff:",
+				"\t\t--- Expected",
+				"\t\t+++ Actual",
+				"\t\t@@ -1,2 +1,2 @@",
+				"\t\t-NodePort",
+				"\t\t+ClusterIP",
+			},
+		},
+		{
+			name: "noneOf fails when one nested assertion passes",
+			assertionYAML: `
+template: service.yaml
+noneOf:
+  - exists:
+      path: spec.type
+  - not: true
+    isKind:
+      of: Deployment
+`,
+			expectedPassed: false,
+			expectedInfo: []string{
+				"- noneOf[0] `exists` passed",
+				"- noneOf[1] NOT `isKind` passed",
+			},
+		},
+		{
+			name: "not inverts the combined result",
+			assertionYAML: `
+template: service.yaml
+not: true
+anyOf:
+  - exists:
+      path: spec.ports
+`,
+			expectedPassed: true,
+			expectedInfo: []string{
+				"- anyOf[0] `exists` fail",
+				"\tTemplate:\tservice.yaml",
+				"\tDocumentIndex:\t0",
+				"\tPath:\tspec.ports expected to exists",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			assertion := new(Assertion)
+			common.YmlUnmarshalTestHelper(tt.assertionYAML, &assertion, t)
+
+			cfg := AssertionConfigBuilder{
+				TemplatesResult:  renderedMap,
+				SnapshotComparer: fakeSnapshotComparer(true),
+				RenderSucceed:    true,
+			}
+			assertion.WithConfig(cfg.Build())
+			result := assertion.Assert(&results.AssertionResult{Index: 0})
+
+			a.Equal(tt.expectedPassed, result.Passed)
+			a.Equal(tt.expectedInfo, result.FailInfo)
+		})
+	}
+}
+
+func TestCompositeAssertionUnmarshalErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		assertionYAML string
+		expectedError string
+	}{
+		{
+			name:          "no list of assertions",
+			assertionYAML: "anyOf:\n  exists:\n    path: a\n",
+			expectedError: "assertion type `anyOf` must contain a list of assertions",
+		},
+		{
+			name:          "empty list of assertions",
+			assertionYAML: "allOf: []\n",
+			expectedError: "assertion type `allOf` must contain a list of assertions",
+		},
+		{
+			name:          "invalid nested assertion",
+			assertionYAML: "noneOf:\n  - exists:\n      path: a\n  - notAnAssertion: {}\n",
+			expectedError: "noneOf[1]: Assertion type `notAnAssertion` is invalid",
+		},
+		{
+			name:          "declared with another assertion type",
+			assertionYAML: "exists:\n  path: a\nanyOf:\n  - exists:\n      path: b\n",
+			expectedError: "assertion type `exists` and `anyOf` is declared duplicately",
+		},
+		{
+			name:          "nested inline snapshot",
+			assertionYAML: "allOf:\n  - anyOf:\n      - matchInlineSnapshot:\n          path: spec.type\n",
+			expectedError: "allOf[0]: anyOf[0]: assertion type `matchInlineSnapshot` is not supported in `anyOf`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertion := new(Assertion)
+			err := common.YmlUnmarshal(tt.assertionYAML, assertion)
+			assert.EqualError(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestTemplatedAssertionAssert(t *testing.T) {
+	manifest := common.TrustedUnmarshalYAML(`
+kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: my-release-web
+  labels:
+    app.kubernetes.io/version: "1.10"
+spec:
+  replicas: 3
+`)
+	renderedMap := map[string][]common.K8sManifest{
+		"deployment.yaml": {manifest},
+	}
+	renderValues := map[string]interface{}{
+		"Release": map[string]interface{}{"Name": "my-release"},
+		"Chart":   map[string]interface{}{"AppVersion": "1.10"},
+		// The numbers of the values of a chart are float64
+		"Values": map[string]interface{}{"replicaCount": float64(3), "labels": map[string]interface{}{"app.kubernetes.io/version": "1.10"}},
+	}
+
+	tests := []struct {
+		name           string
+		assertionYAML  string
+		expectedPassed bool
+		expectedInfo   []string
+	}{
+		{
+			name: "renders the expected value",
+			assertionYAML: `
+template: deployment.yaml
+templated: true
+equal:
+  path: metadata.name
+  value: "{{ .Release.Name }}-web"
+`,
+			expectedPassed: true,
+		},
+		{
+			name: "keeps the number of a single field a number",
+			assertionYAML: `
+template: deployment.yaml
+templated: true
+equal:
+  path: spec.replicas
+  value: "{{ .Values.replicaCount }}"
+`,
+			expectedPassed: true,
+		},
+		{
+			name: "keeps the string of a single field a string",
+			assertionYAML: `
+template: deployment.yaml
+templated: true
+equal:
+  path: metadata.labels["app.kubernetes.io/version"]
+  value: "{{ .Chart.AppVersion }}"
+`,
+			expectedPassed: true,
+		},
+		{
+			name: "reads a single action with a pipeline as yaml",
+			assertionYAML: `
+template: deployment.yaml
+templated: true
+equal:
+  path: metadata.labels
+  value: "{{ .Values.labels | toJson }}"
+`,
+			expectedPassed: true,
+		},
+		{
+			name: "keeps a quoted single action a string",
+			assertionYAML: `
+template: deployment.yaml
+templated: true
+equal:
+  path: metadata.labels["app.kubernetes.io/version"]
+  value: "{{ .Chart.AppVersion | quote }}"
+`,
+			expectedPassed: true,
+		},
+		{
+			name: "renders the nested assertions of a composite assertion",
+			assertionYAML: `
+template: deployment.yaml
+templated: true
+allOf:
+  - matchRegex:
+      path: metadata.name
+      pattern: "^{{ .Release.Name }}-"
+  - equal:
+      path: spec.replicas
+      value: "{{ add .Values.replicaCount 1 }}"
+    not: true
+`,
+			expectedPassed: true,
+			expectedInfo: []string{
+				"- allOf[0] `matchRegex` passed",
+				"- allOf[1] NOT `equal` passed",
+			},
+		},
+		{
+			name: "does not render the values of an assertion which is not templated",
+			assertionYAML: `
+template: deployment.yaml
+equal:
+  path: metadata.name
+  value: "{{ .Release.Name }}-web"
+`,
+			expectedPassed: false,
+		},
+		{
+			name: "fails when the values can not be rendered",
+			assertionYAML: `
+template: deployment.yaml
+templated: true
+equal:
+  path: metadata.name
+  value: "{{ .Values.name }}"
+`,
+			expectedPassed: false,
+			expectedInfo: []string{
+				"Error:",
+				"unable to render the templated assertion: template: expected:1:10: executing \"expected\" at <.Values.name>: map has no entry for key \"name\"",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			assertion := new(Assertion)
+			common.YmlUnmarshalTestHelper(tt.assertionYAML, &assertion, t)
+
+			cfg := AssertionConfigBuilder{
+				TemplatesResult:  renderedMap,
+				SnapshotComparer: fakeSnapshotComparer(true),
+				RenderSucceed:    true,
+				RenderValues:     renderValues,
+			}
+			assertion.WithConfig(cfg.Build())
+			result := assertion.Assert(&results.AssertionResult{Index: 0})
+
+			a.Equal(tt.expectedPassed, result.Passed)
+			if tt.expectedInfo != nil {
+				a.Equal(tt.expectedInfo, result.FailInfo)
+			}
+		})
+	}
+}
+
+func TestTemplatedAssertionAssertWhenChartNotRendered(t *testing.T) {
+	a := assert.New(t)
+	assertion := new(Assertion)
+	common.YmlUnmarshalTestHelper(`
+template: deployment.yaml
+templated: true
+equal:
+  path: metadata.name
+  value: "{{ .Release.Name }}-web"
+`, &assertion, t)
+
+	cfg := AssertionConfigBuilder{
+		TemplatesResult:  map[string][]common.K8sManifest{},
+		SnapshotComparer: fakeSnapshotComparer(true),
+		RenderSucceed:    false,
+		RenderError:      errors.New("values don't meet the specifications of the schema(s) in the following chart(s)"),
+	}
+	assertion.WithConfig(cfg.Build())
+	result := assertion.Assert(&results.AssertionResult{Index: 0})
+
+	a.False(result.Passed)
+	a.Equal([]string{
+		"Error:",
+		"unable to render the templated assertion, the chart is not rendered: values don't meet the specifications of the schema(s) in the following chart(s)",
+	}, result.FailInfo)
+}
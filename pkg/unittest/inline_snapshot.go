@@ -0,0 +1,384 @@
+package unittest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+const inlineSnapshotAssertType = "matchInlineSnapshot"
+const inlineSnapshotKey = "snapshot"
+
+// InlineSnapshots collects the inline snapshots written by the `matchInlineSnapshot` assertions of a suite,
+// until they are stored to the suite file. Missing inline snapshots are written, changed inline snapshots only
+// when IsUpdating, and nothing is written when ReadOnly.
+type InlineSnapshots struct {
+	IsUpdating bool
+	ReadOnly   bool
+	// UpdateFilter limits the inline snapshots updated when IsUpdating, by the name of the test
+	UpdateFilter snapshot.UpdateFilter
+	updates      map[inlineSnapshotPosition]interface{}
+}
+
+// inlineSnapshotPosition is the index of the test in the suite and of the assertion in the test
+type inlineSnapshotPosition struct {
+	test      int
+	assertion int
+}
+
+// inlineSnapshotUpdater writes the inline snapshot of an assertion
+type inlineSnapshotUpdater struct {
+	snapshots *InlineSnapshots
+	name      string
+	position  inlineSnapshotPosition
+}
+
+// UpdateInlineSnapshot implement validators.InlineSnapshotUpdater
+func (u inlineSnapshotUpdater) UpdateInlineSnapshot(content interface{}, missing bool) bool {
+	s := u.snapshots
+	if s.ReadOnly {
+		return false
+	}
+	if !missing && !(s.IsUpdating && (s.UpdateFilter == nil || s.UpdateFilter(u.name, ""))) {
+		return false
+	}
+
+	if s.updates == nil {
+		s.updates = make(map[inlineSnapshotPosition]interface{})
+	}
+	s.updates[u.position] = content
+	return true
+}
+
+// IsReadOnly implement validators.InlineSnapshotUpdater
+func (u inlineSnapshotUpdater) IsReadOnly() bool {
+	return u.snapshots.ReadOnly
+}
+
+// updater returns the updater of the inline snapshot of the assertion of the test, which is nil without InlineSnapshots
+func (s *InlineSnapshots) updater(name string, test, assertion int) validators.InlineSnapshotUpdater {
+	if s == nil {
+		return nil
+	}
+	return inlineSnapshotUpdater{
+		snapshots: s,
+		name:      name,
+		position:  inlineSnapshotPosition{test: test, assertion: assertion},
+	}
+}
+
+// UpdatedCount returns the number of inline snapshots to store
+func (s *InlineSnapshots) UpdatedCount() uint {
+	return uint(len(s.updates))
+}
+
+// StoreToSuiteFile writes the inline snapshots to the suite in the document of the suite file and returns whether
+// the file is written. Only the bytes of the changed assertions are replaced, the rest of the file is kept as is.
+func (s *InlineSnapshots) StoreToSuiteFile(path string, document int) (bool, error) {
+	if s.ReadOnly || len(s.updates) == 0 {
+		return false, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	documents, err := decodeDocuments(content)
+	if err != nil {
+		return false, err
+	}
+	if document >= len(documents) {
+		return false, fmt.Errorf("suite %d not found in %s", document, path)
+	}
+
+	source := newSuiteSource(content, documents[document])
+	edits := make([]suiteEdit, 0, len(s.updates))
+	for position, snapshot := range s.updates {
+		edit, err := source.inlineSnapshotEdit(position, snapshot)
+		if err != nil {
+			return false, fmt.Errorf("failed to write inline snapshot to %s: %s", path, err)
+		}
+		edits = append(edits, edit)
+	}
+
+	// The edits are applied from the end of the file, so the offsets of the others stay valid
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].start > edits[j].start
+	})
+	for _, edit := range edits {
+		content = slices.Concat(content[:edit.start], []byte(edit.text), content[edit.end:])
+	}
+
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return false, err
+	}
+	s.updates = nil
+	return true, nil
+}
+
+// decodeDocuments returns the yaml documents of the content which are not empty, like the suites of a suite file
+func decodeDocuments(content []byte) ([]*yamlv3.Node, error) {
+	decoder := common.YamlNewDecoder(bytes.NewReader(content))
+	var documents []*yamlv3.Node
+	for {
+		var node yamlv3.Node
+		if err := decoder.Decode(&node); err != nil {
+			if errors.Is(err, io.EOF) {
+				return documents, nil
+			}
+			return nil, err
+		}
+		if len(node.Content) > 0 && node.Content[0].Tag != "!!null" {
+			documents = append(documents, &node)
+		}
+	}
+}
+
+// suiteEdit replaces the bytes from start to end of the suite file by the text
+type suiteEdit struct {
+	start int
+	end   int
+	text  string
+}
+
+// suiteSource is the content of a suite file with the parsed suite document, to locate the bytes of its nodes
+type suiteSource struct {
+	content    []byte
+	document   *yamlv3.Node
+	lineStarts []int
+}
+
+func newSuiteSource(content []byte, document *yamlv3.Node) suiteSource {
+	lineStarts := []int{0}
+	for offset, b := range content {
+		if b == '\n' {
+			lineStarts = append(lineStarts, offset+1)
+		}
+	}
+	return suiteSource{content: content, document: document, lineStarts: lineStarts}
+}
+
+// offset returns the offset in the content of the line and column of a node, which start at 1
+func (s suiteSource) offset(line, column int) int {
+	offset := s.lineStarts[line-1]
+	for i := 1; i < column && offset < len(s.content) && s.content[offset] != '\n'; i++ {
+		_, size := utf8.DecodeRune(s.content[offset:])
+		offset += size
+	}
+	return offset
+}
+
+// lineEnd returns the offset of the end of the line, without the line break
+func (s suiteSource) lineEnd(line int) int {
+	if line < len(s.lineStarts) {
+		return s.lineStarts[line] - 1
+	}
+	return len(s.content)
+}
+
+// blockEnd returns the offset of the end of the value of the key at the line with the indent, which ends before
+// the first line which is not blank and not indented deeper than the key
+func (s suiteSource) blockEnd(line, indent int) int {
+	end := s.lineEnd(line)
+	for next := line + 1; next <= len(s.lineStarts); next++ {
+		text := s.content[s.lineStarts[next-1]:s.lineEnd(next)]
+		trimmed := bytes.TrimLeft(text, " ")
+		if len(bytes.TrimSpace(trimmed)) == 0 {
+			continue
+		}
+		if len(text)-len(trimmed) <= indent {
+			break
+		}
+		end = s.lineEnd(next)
+	}
+	return end
+}
+
+// flowEnd returns the offset after the flow collection which starts at the offset
+func (s suiteSource) flowEnd(start int) int {
+	depth := 0
+	var quote byte
+	for offset := start; offset < len(s.content); offset++ {
+		switch b := s.content[offset]; {
+		case quote != 0:
+			if b == '\\' && quote == '"' {
+				offset++
+			} else if b == quote {
+				quote = 0
+			}
+		case b == '"' || b == '\'':
+			quote = b
+		case b == '{' || b == '[':
+			depth++
+		case b == '}' || b == ']':
+			depth--
+			if depth == 0 {
+				return offset + 1
+			}
+		}
+	}
+	return len(s.content)
+}
+
+// indentStep returns the indentation used by the suite for a nested mapping, preferring the one of the params
+func (s suiteSource) indentStep(key, params *yamlv3.Node) int {
+	if params.Kind == yamlv3.MappingNode && params.Style&yamlv3.FlowStyle == 0 && len(params.Content) > 0 {
+		if step := params.Content[0].Column - key.Column; step > 0 {
+			return step
+		}
+	}
+	if step := nestedIndent(s.document.Content[0]); step > 0 {
+		return step
+	}
+	return 2
+}
+
+// nestedIndent returns the indentation of the first block mapping nested in a block mapping, 0 when there is none
+func nestedIndent(node *yamlv3.Node) int {
+	if node.Style&yamlv3.FlowStyle != 0 {
+		return 0
+	}
+	for i, child := range node.Content {
+		if node.Kind == yamlv3.MappingNode && i%2 == 1 && child.Kind == yamlv3.MappingNode &&
+			child.Style&yamlv3.FlowStyle == 0 && len(child.Content) > 0 && child.Content[0].Column > node.Content[i-1].Column {
+			return child.Content[0].Column - node.Content[i-1].Column
+		}
+		if step := nestedIndent(child); step > 0 {
+			return step
+		}
+	}
+	return 0
+}
+
+// inlineSnapshotEdit returns the edit which sets the snapshot of the `matchInlineSnapshot` assertion at the position
+func (s suiteSource) inlineSnapshotEdit(position inlineSnapshotPosition, content interface{}) (suiteEdit, error) {
+	tests := sequenceItem(mappingValue(s.document.Content[0], "tests"), position.test)
+	assertion := sequenceItem(mappingValue(tests, "asserts"), position.assertion)
+	key := mappingKey(assertion, inlineSnapshotAssertType)
+	if key == nil {
+		return suiteEdit{}, fmt.Errorf("assertion %d of test %d is no %s", position.assertion, position.test, inlineSnapshotAssertType)
+	}
+	params := mappingValue(assertion, inlineSnapshotAssertType)
+	step := s.indentStep(key, params)
+
+	var value yamlv3.Node
+	if err := value.Encode(content); err != nil {
+		return suiteEdit{}, err
+	}
+
+	// A snapshot of block params is replaced, or added after their last key
+	if params.Kind == yamlv3.MappingNode && params.Style&yamlv3.FlowStyle == 0 && len(params.Content) > 0 {
+		if snapshotKey := mappingKey(params, inlineSnapshotKey); snapshotKey != nil {
+			value.LineComment = mappingValue(params, inlineSnapshotKey).LineComment
+			text, err := encodeBlock([]*yamlv3.Node{snapshotKey, &value}, snapshotKey.Column-1, step)
+			if err != nil {
+				return suiteEdit{}, err
+			}
+			start := s.offset(snapshotKey.Line, snapshotKey.Column)
+			end := s.blockEnd(snapshotKey.Line, snapshotKey.Column-1)
+			return suiteEdit{start: start, end: end, text: strings.TrimLeft(text, " ")}, nil
+		}
+
+		lastKey := params.Content[len(params.Content)-2]
+		text, err := encodeBlock([]*yamlv3.Node{{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: inlineSnapshotKey}, &value}, lastKey.Column-1, step)
+		if err != nil {
+			return suiteEdit{}, err
+		}
+		end := s.blockEnd(lastKey.Line, lastKey.Column-1)
+		return suiteEdit{start: end, end: end, text: "\n" + text}, nil
+	}
+
+	// Flow and empty params become block params with the snapshot
+	var fields []*yamlv3.Node
+	start := s.offset(params.Line, params.Column)
+	end := start
+	switch {
+	case params.Kind == yamlv3.MappingNode:
+		fields = params.Content
+		end = s.flowEnd(start)
+	case params.Tag == "!!null":
+		end = start + len(params.Value)
+	default:
+		return suiteEdit{}, fmt.Errorf("assertion %d of test %d has no mapping for %s", position.assertion, position.test, inlineSnapshotAssertType)
+	}
+	for start > 0 && (s.content[start-1] == ' ' || s.content[start-1] == '\t') {
+		start--
+	}
+	fields = slices.Clone(fields)
+	if i := slices.Index(fields, mappingKey(params, inlineSnapshotKey)); i >= 0 {
+		fields[i+1] = &value
+	} else {
+		fields = append(fields, &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: inlineSnapshotKey}, &value)
+	}
+	text, err := encodeBlock(fields, key.Column-1+step, step)
+	if err != nil {
+		return suiteEdit{}, err
+	}
+	return suiteEdit{start: start, end: end, text: "\n" + text}, nil
+}
+
+// encodeBlock returns the keys and values encoded as block mapping, with every line indented by indent
+func encodeBlock(fields []*yamlv3.Node, indent, step int) (string, error) {
+	var buffer bytes.Buffer
+	encoder := common.YamlNewEncoder(&buffer)
+	encoder.SetIndent(step)
+	if err := encoder.Encode(&yamlv3.Node{Kind: yamlv3.MappingNode, Tag: "!!map", Content: fields}); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = strings.Repeat(" ", indent) + line
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// mappingKey returns the key node of the key in the mapping node, nil when not found
+func mappingKey(node *yamlv3.Node, key string) *yamlv3.Node {
+	if node == nil || node.Kind != yamlv3.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i]
+		}
+	}
+	return nil
+}
+
+// mappingValue returns the value of the key in the mapping node, nil when not found
+func mappingValue(node *yamlv3.Node, key string) *yamlv3.Node {
+	if node == nil || node.Kind != yamlv3.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// sequenceItem returns the item at the index of the sequence node, nil when not found
+func sequenceItem(node *yamlv3.Node, idx int) *yamlv3.Node {
+	if node == nil || node.Kind != yamlv3.SequenceNode || idx >= len(node.Content) {
+		return nil
+	}
+	return node.Content[idx]
+}
@@ -0,0 +1,16 @@
+package lsp
+
+import "github.com/helm-unittest/helm-unittest/pkg/unittest"
+
+// assertionDocs and keywordDocs contain the hover documentation of the assertion types and of the test suite keywords,
+// built from the documentation of the json schema of the test suite files.
+var assertionDocs, keywordDocs = schemaDocs()
+
+func schemaDocs() (map[string]string, map[string]string) {
+	assertionDocs, keywordDocs, err := unittest.SchemaDocs()
+	if err != nil {
+		// Every assertion type is documented, which is asserted by the tests of the schema
+		return map[string]string{}, map[string]string{}
+	}
+	return assertionDocs, keywordDocs
+}
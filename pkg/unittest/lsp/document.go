@@ -0,0 +1,235 @@
+package lsp
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	documentSeparatorPattern = regexp.MustCompile(`^---\s*$`)
+	testNamePattern          = regexp.MustCompile(`^(\s*)-\s+it:\s*(.*)$`)
+	templateValuePattern     = regexp.MustCompile(`^\s*(?:-\s+)?template:\s*["']?([^"'#\s]+)`)
+	listValuePattern         = regexp.MustCompile(`^\s*-\s+["']?([^"'#\s]+)`)
+)
+
+// document is an opened test suite file
+type document struct {
+	uri   string
+	path  string
+	text  string
+	lines []string
+}
+
+func newDocument(uri, text string) *document {
+	return &document{
+		uri:   uri,
+		path:  uriToPath(uri),
+		text:  text,
+		lines: strings.Split(text, "\n"),
+	}
+}
+
+// suitePart is a single yaml document of the suite file, starting at line.
+type suitePart struct {
+	line    int
+	content string
+}
+
+// parts splits the document into the yaml documents separated by `---`,
+// skipping empty documents the same way the suite parser does.
+func (d *document) parts() []suitePart {
+	parts := make([]suitePart, 0)
+	start := 0
+	appendPart := func(end int) {
+		content := strings.Join(d.lines[start:end], "\n")
+		if len(strings.TrimSpace(content)) > 0 {
+			parts = append(parts, suitePart{line: start, content: content})
+		}
+	}
+	for idx, line := range d.lines {
+		if documentSeparatorPattern.MatchString(line) {
+			appendPart(idx)
+			start = idx + 1
+		}
+	}
+	appendPart(len(d.lines))
+	return parts
+}
+
+// testLocation is the position of a test job within the suite file.
+type testLocation struct {
+	suiteIndex int
+	testIndex  int
+	line       int
+	name       string
+}
+
+// tests returns the location of all test jobs in the document.
+func (d *document) tests() []testLocation {
+	locations := make([]testLocation, 0)
+	for suiteIndex, part := range d.parts() {
+		testIndex := 0
+		testIndent := -1
+		for offset, line := range strings.Split(part.content, "\n") {
+			match := testNamePattern.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			// Only count the items of the tests list, nested `it` keys are not expected.
+			if testIndent >= 0 && len(match[1]) != testIndent {
+				continue
+			}
+			testIndent = len(match[1])
+			locations = append(locations, testLocation{
+				suiteIndex: suiteIndex,
+				testIndex:  testIndex,
+				line:       part.line + offset,
+				name:       strings.Trim(strings.TrimSpace(match[2]), `"'`),
+			})
+			testIndex++
+		}
+	}
+	return locations
+}
+
+func (d *document) line(idx int) string {
+	if idx < 0 || idx >= len(d.lines) {
+		return ""
+	}
+	return strings.TrimRight(d.lines[idx], "\r")
+}
+
+// wordAt returns the word under the position and its range.
+func (d *document) wordAt(pos Position) (string, Range) {
+	line := d.line(pos.Line)
+	if pos.Character > len(line) {
+		return "", Range{}
+	}
+	isWordChar := func(c byte) bool {
+		return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+	}
+	start, end := pos.Character, pos.Character
+	for start > 0 && isWordChar(line[start-1]) {
+		start--
+	}
+	for end < len(line) && isWordChar(line[end]) {
+		end++
+	}
+	return line[start:end], Range{
+		Start: Position{Line: pos.Line, Character: start},
+		End:   Position{Line: pos.Line, Character: end},
+	}
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func isBlankOrComment(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "" || strings.HasPrefix(trimmed, "#")
+}
+
+// parentKey returns the key of the mapping or sequence containing the given line,
+// and whether the line is (part of) a sequence item.
+func (d *document) parentKey(lineIdx int, column int) (string, bool) {
+	current := d.line(lineIdx)
+	indent := column
+	if !isBlankOrComment(current) {
+		indent = indentOf(current)
+	}
+
+	isItem := strings.HasPrefix(strings.TrimSpace(current), "-")
+	itemIndent := indent
+	// Find the sequence item the line belongs to, when the line is a key of an item.
+	for idx := lineIdx - 1; idx >= 0 && !isItem; idx-- {
+		line := d.line(idx)
+		if isBlankOrComment(line) {
+			continue
+		}
+		lineIndent := indentOf(line)
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "- ") && lineIndent+2 == indent {
+			isItem = true
+			itemIndent = lineIndent
+			lineIdx = idx
+			break
+		}
+		if lineIndent < indent {
+			break
+		}
+	}
+
+	for idx := lineIdx - 1; idx >= 0; idx-- {
+		line := d.line(idx)
+		if isBlankOrComment(line) {
+			continue
+		}
+		lineIndent := indentOf(line)
+		trimmed := strings.TrimPrefix(strings.TrimSpace(line), "- ")
+		if documentSeparatorPattern.MatchString(line) {
+			return "", isItem
+		}
+		if lineIndent < itemIndent || (isItem && lineIndent == itemIndent && !strings.HasPrefix(strings.TrimSpace(line), "-")) {
+			key, _, _ := strings.Cut(trimmed, ":")
+			return strings.TrimSpace(key), isItem
+		}
+	}
+	return "", isItem
+}
+
+// templateAt returns the template path referenced at the given line, if any.
+func (d *document) templateAt(lineIdx int) string {
+	line := d.line(lineIdx)
+	if match := templateValuePattern.FindStringSubmatch(line); match != nil {
+		return match[1]
+	}
+	if key, isItem := d.parentKey(lineIdx, 0); isItem && isTemplatesKey(key) {
+		if match := listValuePattern.FindStringSubmatch(line); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+func isTemplatesKey(key string) bool {
+	return key == "templates" || key == "excludeTemplates"
+}
+
+// findChartPath walks up from the suite file to the closest directory containing a Chart.yaml.
+func findChartPath(suiteFilePath string) (string, error) {
+	dir := filepath.Dir(suiteFilePath)
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "Chart.yaml")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("no Chart.yaml found for " + suiteFilePath)
+		}
+		dir = parent
+	}
+}
+
+// chartTemplates returns the template files of the chart, relative to the templates directory.
+func chartTemplates(chartPath string) []string {
+	templatesDir := filepath.Join(chartPath, "templates")
+	templates := make([]string, 0)
+	_ = filepath.WalkDir(templatesDir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return nil
+		}
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml", ".tpl", ".txt":
+			relPath, relErr := filepath.Rel(templatesDir, path)
+			if relErr == nil {
+				templates = append(templates, filepath.ToSlash(relPath))
+			}
+		}
+		return nil
+	})
+	return templates
+}
@@ -0,0 +1,556 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/results"
+	log "github.com/sirupsen/logrus"
+
+	v3loader "helm.sh/helm/v3/pkg/chart/loader"
+)
+
+const LOG_LSP = "lsp"
+
+// RunTestCommand the command executed by the code lens to run a single test
+const RunTestCommand = "helm-unittest.runTest"
+
+const diagnosticSource = "helm-unittest"
+
+var errorLinePattern = regexp.MustCompile(`line (\d+)`)
+
+// testOutcome is the result of the last run of a test
+type testOutcome struct {
+	passed bool
+	output string
+}
+
+// Server a language server for test suite files, speaking LSP over the given streams
+type Server struct {
+	reader    *bufio.Reader
+	writer    io.Writer
+	strict    bool
+	documents map[string]*document
+	// outcomes of the tests executed via the code lens, by uri and test key
+	outcomes  map[string]map[string]testOutcome
+	requestID int
+	// snapshotDir and snapshotPerTest locate the snapshot files like the runner does
+	snapshotDir     string
+	snapshotPerTest bool
+}
+
+// NewServer create a Server reading requests from in and writing responses to out
+func NewServer(in io.Reader, out io.Writer, strict bool) *Server {
+	return &Server{
+		reader:    bufio.NewReader(in),
+		writer:    out,
+		strict:    strict,
+		documents: make(map[string]*document),
+		outcomes:  make(map[string]map[string]testOutcome),
+	}
+}
+
+// WithSnapshotLayout sets the directory of the snapshot files relative to the chart and whether they are stored per test,
+// like the `--snapshot-dir` and `--snapshot-per-test` flags of the runner
+func (s *Server) WithSnapshotLayout(snapshotDir string, perTest bool) *Server {
+	s.snapshotDir = snapshotDir
+	s.snapshotPerTest = perTest
+	return s
+}
+
+// Serve handles the messages until the client sends exit or closes the input stream
+func (s *Server) Serve() error {
+	for {
+		request, err := readMessage(s.reader)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				if writeErr := s.respondError(nil, codeParseError, err.Error()); writeErr != nil {
+					return writeErr
+				}
+				continue
+			}
+			return err
+		}
+
+		if request.Method == "exit" {
+			return nil
+		}
+		if request.Method == "" {
+			// responses of the client on requests of the server are ignored
+			continue
+		}
+
+		if err := s.handle(request); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(request *message) error {
+	log.WithField(LOG_LSP, "handle").Debugln("method:", request.Method)
+
+	var result interface{}
+	var err error
+	switch request.Method {
+	case "initialize":
+		result = s.initialize()
+	case "initialized", "$/cancelRequest", "$/setTrace", "workspace/didChangeConfiguration":
+		return nil
+	case "shutdown":
+		// nothing to release, the exit notification stops serving
+	case "textDocument/didOpen":
+		params := DidOpenTextDocumentParams{}
+		if err = json.Unmarshal(request.Params, &params); err == nil {
+			err = s.open(params.TextDocument.URI, params.TextDocument.Text)
+		}
+	case "textDocument/didChange":
+		params := DidChangeTextDocumentParams{}
+		if err = json.Unmarshal(request.Params, &params); err == nil && len(params.ContentChanges) > 0 {
+			err = s.open(params.TextDocument.URI, params.ContentChanges[len(params.ContentChanges)-1].Text)
+		}
+	case "textDocument/didSave":
+		params := DidCloseTextDocumentParams{}
+		if err = json.Unmarshal(request.Params, &params); err == nil {
+			if doc, ok := s.documents[params.TextDocument.URI]; ok {
+				err = s.publishDiagnostics(doc)
+			}
+		}
+	case "textDocument/didClose":
+		params := DidCloseTextDocumentParams{}
+		if err = json.Unmarshal(request.Params, &params); err == nil {
+			err = s.close(params.TextDocument.URI)
+		}
+	case "textDocument/completion":
+		params := TextDocumentPositionParams{}
+		if err = json.Unmarshal(request.Params, &params); err == nil {
+			result = s.completion(params)
+		}
+	case "textDocument/hover":
+		params := TextDocumentPositionParams{}
+		if err = json.Unmarshal(request.Params, &params); err == nil {
+			result = s.hover(params)
+		}
+	case "textDocument/definition":
+		params := TextDocumentPositionParams{}
+		if err = json.Unmarshal(request.Params, &params); err == nil {
+			result = s.definition(params)
+		}
+	case "textDocument/codeLens":
+		params := CodeLensParams{}
+		if err = json.Unmarshal(request.Params, &params); err == nil {
+			result = s.codeLens(params)
+		}
+	case "workspace/executeCommand":
+		params := ExecuteCommandParams{}
+		if err = json.Unmarshal(request.Params, &params); err == nil {
+			result, err = s.executeCommand(params)
+		}
+	default:
+		if request.ID == nil {
+			return nil
+		}
+		return s.respondError(request.ID, codeMethodNotFound, fmt.Sprintf("method '%s' not supported", request.Method))
+	}
+
+	if request.ID == nil {
+		if err != nil {
+			log.WithField(LOG_LSP, "handle").Debugln("notification error:", err)
+		}
+		return nil
+	}
+	if err != nil {
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+			return s.respondError(request.ID, codeInvalidParams, err.Error())
+		}
+		return s.respondError(request.ID, codeInternalError, err.Error())
+	}
+	if result == nil {
+		result = json.RawMessage("null")
+	}
+	return writeMessage(s.writer, &message{ID: request.ID, Result: result})
+}
+
+func (s *Server) respondError(id *json.RawMessage, code int, msg string) error {
+	if id == nil {
+		null := json.RawMessage("null")
+		id = &null
+	}
+	return writeMessage(s.writer, &message{ID: id, Error: &responseError{Code: code, Message: msg}})
+}
+
+func (s *Server) notify(method string, params interface{}) error {
+	content, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return writeMessage(s.writer, &message{Method: method, Params: content})
+}
+
+func (s *Server) request(method string) error {
+	s.requestID++
+	id := json.RawMessage(strconv.Itoa(s.requestID))
+	return writeMessage(s.writer, &message{ID: &id, Method: method})
+}
+
+func (s *Server) initialize() interface{} {
+	return map[string]interface{}{
+		"capabilities": map[string]interface{}{
+			// full document sync
+			"textDocumentSync": 1,
+			"completionProvider": map[string]interface{}{
+				"triggerCharacters": []string{" ", ":", "/"},
+			},
+			"hoverProvider":      true,
+			"definitionProvider": true,
+			"codeLensProvider": map[string]interface{}{
+				"resolveProvider": false,
+			},
+			"executeCommandProvider": map[string]interface{}{
+				"commands": []string{RunTestCommand},
+			},
+		},
+		"serverInfo": map[string]interface{}{
+			"name": "helm-unittest",
+		},
+	}
+}
+
+func (s *Server) open(uri, text string) error {
+	doc := newDocument(uri, text)
+	s.documents[uri] = doc
+	return s.publishDiagnostics(doc)
+}
+
+func (s *Server) close(uri string) error {
+	delete(s.documents, uri)
+	delete(s.outcomes, uri)
+	return s.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{URI: uri, Diagnostics: []Diagnostic{}})
+}
+
+func (s *Server) document(uri string) (*document, error) {
+	if doc, ok := s.documents[uri]; ok {
+		return doc, nil
+	}
+	content, err := os.ReadFile(uriToPath(uri))
+	if err != nil {
+		return nil, err
+	}
+	return newDocument(uri, string(content)), nil
+}
+
+// chartRouteOf returns the name of the chart of the suite file,
+// falling back to the directory name when the chart can't be loaded.
+func chartRouteOf(chartPath string) string {
+	chart, err := v3loader.Load(chartPath)
+	if err != nil {
+		return filepath.Base(chartPath)
+	}
+	return chart.Name()
+}
+
+// diagnose parses each suite of the document, parse errors are reported as error,
+// errors only reported with strict parsing are reported as warning unless the server runs strict.
+func (s *Server) diagnose(doc *document) []Diagnostic {
+	chartRoute := filepath.Base(filepath.Dir(doc.path))
+	if chartPath, err := findChartPath(doc.path); err == nil {
+		chartRoute = chartRouteOf(chartPath)
+	}
+
+	diagnostics := make([]Diagnostic, 0)
+	for _, part := range doc.parts() {
+		severity := severityError
+		_, err := unittest.ParseTestSuiteContent(doc.path, chartRoute, part.content, s.strict, nil)
+		if err == nil && !s.strict {
+			severity = severityWarning
+			_, err = unittest.ParseTestSuiteContent(doc.path, chartRoute, part.content, true, nil)
+		}
+		if err != nil {
+			diagnostics = append(diagnostics, diagnosticsOfError(doc, err, part.line, severity)...)
+		}
+	}
+
+	for _, test := range doc.tests() {
+		outcome, ok := s.outcomes[doc.uri][testKey(test)]
+		if !ok || outcome.passed {
+			continue
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    lineRange(doc, test.line),
+			Severity: severityError,
+			Source:   diagnosticSource,
+			Message:  fmt.Sprintf("test '%s' failed:\n%s", test.name, outcome.output),
+		})
+	}
+	return diagnostics
+}
+
+// diagnosticsOfError translates the error lines containing a line number to diagnostics.
+func diagnosticsOfError(doc *document, err error, partLine, severity int) []Diagnostic {
+	diagnostics := make([]Diagnostic, 0)
+	for _, errLine := range strings.Split(err.Error(), "\n") {
+		match := errorLinePattern.FindStringSubmatch(errLine)
+		if match == nil {
+			continue
+		}
+		line, _ := strconv.Atoi(match[1])
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    lineRange(doc, partLine+line-1),
+			Severity: severity,
+			Source:   diagnosticSource,
+			Message:  strings.TrimSpace(errLine),
+		})
+	}
+
+	if len(diagnostics) == 0 {
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    lineRange(doc, partLine),
+			Severity: severity,
+			Source:   diagnosticSource,
+			Message:  err.Error(),
+		})
+	}
+	return diagnostics
+}
+
+func lineRange(doc *document, line int) Range {
+	return Range{
+		Start: Position{Line: line, Character: indentOf(doc.line(line))},
+		End:   Position{Line: line, Character: len(doc.line(line))},
+	}
+}
+
+func (s *Server) publishDiagnostics(doc *document) error {
+	return s.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+		URI:         doc.uri,
+		Diagnostics: s.diagnose(doc),
+	})
+}
+
+func (s *Server) completion(params TextDocumentPositionParams) []CompletionItem {
+	items := make([]CompletionItem, 0)
+	doc, err := s.document(params.TextDocument.URI)
+	if err != nil {
+		return items
+	}
+
+	line := doc.line(params.Position.Line)
+	prefix := line[:min(params.Position.Character, len(line))]
+	key, isItem := doc.parentKey(params.Position.Line, params.Position.Character)
+
+	if strings.HasSuffix(strings.TrimSpace(prefix), "template:") || templateValuePattern.MatchString(prefix) ||
+		(isItem && isTemplatesKey(key)) {
+		chartPath, err := findChartPath(doc.path)
+		if err != nil {
+			return items
+		}
+		for _, template := range chartTemplates(chartPath) {
+			items = append(items, CompletionItem{
+				Label:  template,
+				Kind:   completionKindFile,
+				Detail: "template",
+			})
+		}
+		return items
+	}
+
+	if isItem && key == "asserts" && !strings.Contains(prefix, ":") {
+		for _, assertType := range unittest.AssertTypes() {
+			item := CompletionItem{
+				Label:  assertType,
+				Kind:   completionKindProperty,
+				Detail: "assertion",
+			}
+			if docs, ok := assertionDocs[assertType]; ok {
+				item.Documentation = &MarkupContent{Kind: "markdown", Value: docs}
+			}
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (s *Server) hover(params TextDocumentPositionParams) *Hover {
+	doc, err := s.document(params.TextDocument.URI)
+	if err != nil {
+		return nil
+	}
+
+	word, wordRange := doc.wordAt(params.Position)
+	if word == "" {
+		return nil
+	}
+	docs, ok := assertionDocs[word]
+	if ok {
+		docs = fmt.Sprintf("**%s** *assertion*\n\n%s", word, docs)
+	} else if docs, ok = keywordDocs[word]; !ok {
+		return nil
+	}
+	return &Hover{
+		Contents: MarkupContent{Kind: "markdown", Value: docs},
+		Range:    &wordRange,
+	}
+}
+
+func (s *Server) definition(params TextDocumentPositionParams) []Location {
+	locations := make([]Location, 0)
+	doc, err := s.document(params.TextDocument.URI)
+	if err != nil {
+		return locations
+	}
+
+	template := doc.templateAt(params.Position.Line)
+	if template == "" {
+		return locations
+	}
+	chartPath, err := findChartPath(doc.path)
+	if err != nil {
+		return locations
+	}
+	// templates can be given relative to the chart or to the templates directory
+	if !strings.HasPrefix(template, "templates/") && !strings.HasPrefix(template, "charts/") {
+		template = "templates/" + template
+	}
+	templatePath := filepath.Join(chartPath, filepath.FromSlash(template))
+	if _, err := os.Stat(templatePath); err != nil {
+		return locations
+	}
+	return append(locations, Location{URI: pathToURI(templatePath)})
+}
+
+func testKey(test testLocation) string {
+	return fmt.Sprintf("%d/%s", test.suiteIndex, test.name)
+}
+
+func (s *Server) codeLens(params CodeLensParams) []CodeLens {
+	lenses := make([]CodeLens, 0)
+	doc, err := s.document(params.TextDocument.URI)
+	if err != nil {
+		return lenses
+	}
+
+	for _, test := range doc.tests() {
+		title := "▶ run test"
+		if outcome, ok := s.outcomes[doc.uri][testKey(test)]; ok {
+			if outcome.passed {
+				title = "✔ passed (run again)"
+			} else {
+				title = "✘ failed (run again)"
+			}
+		}
+		lenses = append(lenses, CodeLens{
+			Range: lineRange(doc, test.line),
+			Command: &Command{
+				Title:     title,
+				Command:   RunTestCommand,
+				Arguments: []interface{}{doc.uri, test.suiteIndex, test.testIndex},
+			},
+		})
+	}
+	return lenses
+}
+
+func (s *Server) executeCommand(params ExecuteCommandParams) (interface{}, error) {
+	if params.Command != RunTestCommand {
+		return nil, fmt.Errorf("command '%s' not supported", params.Command)
+	}
+	if len(params.Arguments) != 3 {
+		return nil, fmt.Errorf("command '%s' expects the arguments uri, suite index and test index", params.Command)
+	}
+
+	var uri string
+	var suiteIndex, testIndex int
+	if err := json.Unmarshal(params.Arguments[0], &uri); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(params.Arguments[1], &suiteIndex); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(params.Arguments[2], &testIndex); err != nil {
+		return nil, err
+	}
+
+	doc, err := s.document(uri)
+	if err != nil {
+		return nil, err
+	}
+	var location *testLocation
+	for _, test := range doc.tests() {
+		if test.suiteIndex == suiteIndex && test.testIndex == testIndex {
+			location = &test
+			break
+		}
+	}
+	if location == nil {
+		return nil, fmt.Errorf("test %d of suite %d not found", testIndex, suiteIndex)
+	}
+
+	result, err := s.runTest(doc, suiteIndex, testIndex)
+	outcome := testOutcome{}
+	if err != nil {
+		outcome.output = err.Error()
+	} else {
+		outcome.passed = result.Passed || result.Skipped
+		outcome.output = result.Stringify()
+	}
+	if s.outcomes[uri] == nil {
+		s.outcomes[uri] = make(map[string]testOutcome)
+	}
+	s.outcomes[uri][testKey(*location)] = outcome
+
+	if err := s.publishDiagnostics(doc); err != nil {
+		return nil, err
+	}
+	if err := s.request("workspace/codeLens/refresh"); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"passed": outcome.passed,
+		"output": outcome.output,
+	}, nil
+}
+
+// runTest runs a single test of a suite of the document, snapshots are compared read-only and a missing one fails.
+func (s *Server) runTest(doc *document, suiteIndex, testIndex int) (*results.TestJobResult, error) {
+	chartPath, err := findChartPath(doc.path)
+	if err != nil {
+		return nil, err
+	}
+
+	suites, err := unittest.ParseTestSuiteContent(doc.path, chartRouteOf(chartPath), doc.text, s.strict, nil)
+	if err != nil {
+		return nil, err
+	}
+	if suiteIndex >= len(suites) || testIndex >= len(suites[suiteIndex].Tests) {
+		return nil, fmt.Errorf("test %d of suite %d not found", testIndex, suiteIndex)
+	}
+
+	suite := suites[suiteIndex]
+	suite.Tests = []*unittest.TestJob{suite.Tests[testIndex]}
+	layout := suite.SnapshotLayout(chartPath, s.snapshotDir, s.snapshotPerTest)
+	cache, err := layout.CreateSnapshotOfSuite(suite.SnapshotFileUrl(), false, true)
+	if err != nil {
+		return nil, err
+	}
+
+	result := suite.RunV3(chartPath, cache, false, "", &results.TestSuiteResult{})
+	if len(result.TestsResult) == 0 || result.TestsResult[0] == nil {
+		return nil, fmt.Errorf("test %d of suite %d did not run", testIndex, suiteIndex)
+	}
+	return result.TestsResult[0], nil
+}
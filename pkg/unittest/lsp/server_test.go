@@ -0,0 +1,308 @@
+package lsp_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/lsp"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSuiteFile = "../../../test/data/v3/basic/tests/deployment_test.yaml"
+
+type testMessage struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func fileURI(t *testing.T, path string) string {
+	t.Helper()
+	absPath, err := filepath.Abs(path)
+	assert.NoError(t, err)
+	return "file://" + filepath.ToSlash(absPath)
+}
+
+func frame(t *testing.T, messages ...map[string]interface{}) io.Reader {
+	t.Helper()
+	buffer := new(bytes.Buffer)
+	for _, msg := range messages {
+		msg["jsonrpc"] = "2.0"
+		content, err := json.Marshal(msg)
+		assert.NoError(t, err)
+		fmt.Fprintf(buffer, "Content-Length: %d\r\n\r\n%s", len(content), content)
+	}
+	return buffer
+}
+
+func serve(t *testing.T, messages ...map[string]interface{}) []testMessage {
+	t.Helper()
+	return serveWithSnapshotLayout(t, "", false, messages...)
+}
+
+func serveWithSnapshotLayout(t *testing.T, snapshotDir string, perTest bool, messages ...map[string]interface{}) []testMessage {
+	t.Helper()
+	output := new(bytes.Buffer)
+	server := NewServer(frame(t, messages...), output, false).WithSnapshotLayout(snapshotDir, perTest)
+	assert.NoError(t, server.Serve())
+
+	responses := make([]testMessage, 0)
+	reader := bufio.NewReader(output)
+	for {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			break
+		}
+		length, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(header, "Content-Length:")))
+		assert.NoError(t, err)
+		_, _ = reader.ReadString('\n')
+		content := make([]byte, length)
+		_, err = io.ReadFull(reader, content)
+		assert.NoError(t, err)
+
+		msg := testMessage{}
+		assert.NoError(t, json.Unmarshal(content, &msg))
+		responses = append(responses, msg)
+	}
+	return responses
+}
+
+func responseOf(responses []testMessage, id int) *testMessage {
+	for _, response := range responses {
+		if response.ID != nil && *response.ID == id && response.Method == "" {
+			return &response
+		}
+	}
+	return nil
+}
+
+func openMessage(uri, text string) map[string]interface{} {
+	return map[string]interface{}{
+		"method": "textDocument/didOpen",
+		"params": map[string]interface{}{
+			"textDocument": map[string]interface{}{"uri": uri, "languageId": "yaml", "version": 1, "text": text},
+		},
+	}
+}
+
+func positionMessage(id int, method, uri string, line, character int) map[string]interface{} {
+	return map[string]interface{}{
+		"id":     id,
+		"method": method,
+		"params": map[string]interface{}{
+			"textDocument": map[string]interface{}{"uri": uri},
+			"position":     map[string]interface{}{"line": line, "character": character},
+		},
+	}
+}
+
+func TestServerInitialize(t *testing.T) {
+	responses := serve(t,
+		map[string]interface{}{"id": 1, "method": "initialize", "params": map[string]interface{}{}},
+		map[string]interface{}{"method": "exit"},
+	)
+
+	response := responseOf(responses, 1)
+	assert.NotNil(t, response)
+	assert.Contains(t, string(response.Result), `"hoverProvider":true`)
+	assert.Contains(t, string(response.Result), RunTestCommand)
+}
+
+func TestServerUnknownMethod(t *testing.T) {
+	responses := serve(t, map[string]interface{}{"id": 1, "method": "textDocument/unknown"})
+
+	response := responseOf(responses, 1)
+	assert.NotNil(t, response)
+	assert.Equal(t, -32601, response.Error.Code)
+}
+
+func TestServerCompletionOfAssertTypes(t *testing.T) {
+	uri := fileURI(t, testSuiteFile)
+	text := "suite: test\ntemplates:\n  - deployment.yaml\ntests:\n  - it: should work\n    asserts:\n      - \n"
+	responses := serve(t, openMessage(uri, text), positionMessage(1, "textDocument/completion", uri, 6, 8))
+
+	items := []CompletionItem{}
+	assert.NoError(t, json.Unmarshal(responseOf(responses, 1).Result, &items))
+	labels := make([]string, 0, len(items))
+	for _, item := range items {
+		labels = append(labels, item.Label)
+	}
+	assert.Contains(t, labels, "equal")
+	assert.Contains(t, labels, "matchSnapshot")
+}
+
+func TestServerCompletionOfTemplates(t *testing.T) {
+	uri := fileURI(t, testSuiteFile)
+	text := "suite: test\ntemplates:\n  - \ntests: []\n"
+	responses := serve(t, openMessage(uri, text), positionMessage(1, "textDocument/completion", uri, 2, 4))
+
+	items := []CompletionItem{}
+	assert.NoError(t, json.Unmarshal(responseOf(responses, 1).Result, &items))
+	labels := make([]string, 0, len(items))
+	for _, item := range items {
+		labels = append(labels, item.Label)
+	}
+	assert.Contains(t, labels, "deployment.yaml")
+	assert.Contains(t, labels, "NOTES.txt")
+	assert.NotContains(t, labels, "equal")
+}
+
+func TestServerHoverOfAssertType(t *testing.T) {
+	uri := fileURI(t, testSuiteFile)
+	text := "tests:\n  - it: should work\n    asserts:\n      - isKind:\n          of: Deployment\n"
+	responses := serve(t, openMessage(uri, text), positionMessage(1, "textDocument/hover", uri, 3, 10))
+
+	hover := Hover{}
+	assert.NoError(t, json.Unmarshal(responseOf(responses, 1).Result, &hover))
+	assert.Equal(t, "markdown", hover.Contents.Kind)
+	assert.Contains(t, hover.Contents.Value, "**isKind**")
+}
+
+func TestServerDefinitionOfTemplate(t *testing.T) {
+	uri := fileURI(t, testSuiteFile)
+	text := "templates:\n  - templates/deployment.yaml\ntests:\n  - it: should work\n    template: service.yaml\n"
+	responses := serve(t,
+		openMessage(uri, text),
+		positionMessage(1, "textDocument/definition", uri, 1, 8),
+		positionMessage(2, "textDocument/definition", uri, 4, 16),
+	)
+
+	locations := []Location{}
+	assert.NoError(t, json.Unmarshal(responseOf(responses, 1).Result, &locations))
+	assert.Len(t, locations, 1)
+	assert.True(t, strings.HasSuffix(locations[0].URI, "/basic/templates/deployment.yaml"))
+
+	assert.NoError(t, json.Unmarshal(responseOf(responses, 2).Result, &locations))
+	assert.Len(t, locations, 1)
+	assert.True(t, strings.HasSuffix(locations[0].URI, "/basic/templates/service.yaml"))
+}
+
+func TestServerDiagnostics(t *testing.T) {
+	uri := fileURI(t, testSuiteFile)
+	text := "suite: test\nunknownField: true\ntests:\n  - it: should work\n    asserts:\n      - isKind:\n          of: Deployment\n---\nsuite: broken\ntests:\n  - it: has an invalid assertion\n    asserts:\n      - isNotAnAssertion: {}\n"
+	responses := serve(t, openMessage(uri, text))
+
+	assert.Len(t, responses, 1)
+	assert.Equal(t, "textDocument/publishDiagnostics", responses[0].Method)
+	params := PublishDiagnosticsParams{}
+	assert.NoError(t, json.Unmarshal(responses[0].Params, &params))
+	assert.Len(t, params.Diagnostics, 2)
+
+	// strict only errors are warnings
+	assert.Equal(t, 2, params.Diagnostics[0].Severity)
+	assert.Equal(t, 1, params.Diagnostics[0].Range.Start.Line)
+	assert.Contains(t, params.Diagnostics[0].Message, "unknownField")
+
+	assert.Equal(t, 1, params.Diagnostics[1].Severity)
+	assert.Contains(t, params.Diagnostics[1].Message, "isNotAnAssertion")
+}
+
+func TestServerCodeLensAndRunTest(t *testing.T) {
+	uri := fileURI(t, testSuiteFile)
+	content, err := os.ReadFile(testSuiteFile)
+	assert.NoError(t, err)
+
+	responses := serve(t,
+		openMessage(uri, string(content)),
+		map[string]interface{}{
+			"id":     1,
+			"method": "textDocument/codeLens",
+			"params": map[string]interface{}{"textDocument": map[string]interface{}{"uri": uri}},
+		},
+		map[string]interface{}{
+			"id":     2,
+			"method": "workspace/executeCommand",
+			"params": map[string]interface{}{"command": RunTestCommand, "arguments": []interface{}{uri, 0, 0}},
+		},
+		map[string]interface{}{
+			"id":     3,
+			"method": "textDocument/codeLens",
+			"params": map[string]interface{}{"textDocument": map[string]interface{}{"uri": uri}},
+		},
+	)
+
+	lenses := []CodeLens{}
+	assert.NoError(t, json.Unmarshal(responseOf(responses, 1).Result, &lenses))
+	assert.NotEmpty(t, lenses)
+	assert.Equal(t, "▶ run test", lenses[0].Command.Title)
+	assert.Equal(t, RunTestCommand, lenses[0].Command.Command)
+
+	result := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(responseOf(responses, 2).Result, &result))
+	assert.Equal(t, true, result["passed"], result["output"])
+
+	assert.NoError(t, json.Unmarshal(responseOf(responses, 3).Result, &lenses))
+	assert.Equal(t, "✔ passed (run again)", lenses[0].Command.Title)
+}
+
+const snapshotSuiteFile = "../../../test/data/v3/basic/tests/lsp_snapshot_test.yaml"
+
+func runTestMessage(id int, uri string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":     id,
+		"method": "workspace/executeCommand",
+		"params": map[string]interface{}{"command": RunTestCommand, "arguments": []interface{}{uri, 0, 0}},
+	}
+}
+
+func TestServerRunTestWithSuiteSnapshotDir(t *testing.T) {
+	snapshotDir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(snapshotDir, "lsp_snapshot_test.yaml.snap"),
+		[]byte("should match the snapshot:\n  1: |\n    changed: true\n"), 0644))
+	uri := fileURI(t, snapshotSuiteFile)
+	suite := fmt.Sprintf(`suite: snapshot dir suite
+snapshotDir: %s
+templates:
+  - templates/configmap.yaml
+tests:
+  - it: should match the snapshot
+    asserts:
+      - matchSnapshot:
+          path: metadata.name
+`, snapshotDir)
+
+	responses := serve(t, openMessage(uri, suite), runTestMessage(1, uri))
+
+	result := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(responseOf(responses, 1).Result, &result))
+	assert.Equal(t, false, result["passed"])
+	assert.Contains(t, result["output"], "changed: true")
+}
+
+func TestServerRunTestWithSnapshotLayoutIsReadOnly(t *testing.T) {
+	snapshotDir := t.TempDir()
+	uri := fileURI(t, snapshotSuiteFile)
+	suite := `suite: snapshot per test suite
+templates:
+  - templates/configmap.yaml
+tests:
+  - it: should match the snapshot
+    asserts:
+      - matchSnapshot:
+          path: metadata.name
+`
+
+	responses := serveWithSnapshotLayout(t, snapshotDir, true, openMessage(uri, suite), runTestMessage(1, uri))
+
+	result := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(responseOf(responses, 1).Result, &result))
+	assert.Equal(t, false, result["passed"])
+	assert.Contains(t, result["output"], "snapshot 1 to exist")
+	entries, err := os.ReadDir(snapshotDir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.NoDirExists(t, filepath.Join(filepath.Dir(snapshotSuiteFile), "__snapshot__", "lsp_snapshot_test.yaml"))
+}
@@ -15,6 +15,11 @@ type TestConfig struct {
 	failFast            bool
 	isSkipEmptyTemplate bool
 	postRenderer        PostRendererConfig
+	snapshotKeys        string
+	inlineSnapshots     *InlineSnapshots
+	testIndex           int
+	// customResourceSchemas caches the schemas of the CustomResourceDefinitions for the tests of the suite
+	customResourceSchemas *validators.CustomResourceSchemas
 }
 
 func NewTestConfig(chart *v3chart.Chart, cache *snapshot.Cache, options ...func(*TestConfig)) *TestConfig {
@@ -68,6 +73,27 @@ func WithSkipEmptyTemplate(config bool) LoadTestOptionsFunc {
 	}
 }
 
+func WithSnapshotKeys(snapshotKeys string) LoadTestOptionsFunc {
+	return func(c *TestConfig) {
+		c.snapshotKeys = snapshotKeys
+	}
+}
+
+// WithInlineSnapshots sets the inline snapshots of the suite and the index of the test in the suite
+func WithInlineSnapshots(snapshots *InlineSnapshots, testIndex int) LoadTestOptionsFunc {
+	return func(c *TestConfig) {
+		c.inlineSnapshots = snapshots
+		c.testIndex = testIndex
+	}
+}
+
+// WithCustomResourceSchemas sets the schemas of the CustomResourceDefinitions cached for the tests of the suite
+func WithCustomResourceSchemas(schemas *validators.CustomResourceSchemas) LoadTestOptionsFunc {
+	return func(c *TestConfig) {
+		c.customResourceSchemas = schemas
+	}
+}
+
 type AssertionConfig struct {
 	templatesResult     map[string][]common.K8sManifest
 	snapshotComparer    validators.SnapshotComparer
@@ -76,6 +102,16 @@ type AssertionConfig struct {
 	isSkipEmptyTemplate bool
 	didPostRender       bool
 	renderError         error
+	// inlineSnapshotUpdater writes the inline snapshot of the assertion
+	inlineSnapshotUpdater validators.InlineSnapshotUpdater
+	// baseDirectory is the directory of the test suite
+	baseDirectory string
+	// customResourceDefinitions are the CRD files of the chart
+	customResourceDefinitions [][]byte
+	// customResourceSchemas caches the schemas of the CustomResourceDefinitions
+	customResourceSchemas *validators.CustomResourceSchemas
+	// renderValues are the values the chart is rendered with
+	renderValues map[string]interface{}
 }
 
 // AssertionConfigBuilder Required to simplify tests
@@ -87,6 +123,7 @@ type AssertionConfigBuilder struct {
 	DidPostRender       bool
 	RenderError         error
 	IsSkipEmptyTemplate bool
+	RenderValues        map[string]interface{}
 }
 
 func (b AssertionConfigBuilder) Build() AssertionConfig {
@@ -98,5 +135,6 @@ func (b AssertionConfigBuilder) Build() AssertionConfig {
 		didPostRender:       b.DidPostRender,
 		renderError:         b.RenderError,
 		isSkipEmptyTemplate: b.IsSkipEmptyTemplate,
+		renderValues:        b.RenderValues,
 	}
 }
@@ -0,0 +1,218 @@
+package reporter
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/results"
+)
+
+// Names of the events written by the EventStream.
+const (
+	EventChartStarted      = "chartStarted"
+	EventSuiteStarted      = "suiteStarted"
+	EventTestStarted       = "testStarted"
+	EventAssertionFinished = "assertionFinished"
+	EventTestFinished      = "testFinished"
+	EventSuiteFinished     = "suiteFinished"
+	EventChartFinished     = "chartFinished"
+	EventRunFinished       = "runFinished"
+)
+
+// Event is a line of the newline delimited JSON written by the EventStream.
+// The chart, suite and test of an event are the ones being run when the event occurred.
+type Event struct {
+	Event string `json:"event"`
+	Chart string `json:"chart,omitempty"`
+	Path  string `json:"path,omitempty"`
+	Suite string `json:"suite,omitempty"`
+	File  string `json:"file,omitempty"`
+	Test  string `json:"test,omitempty"`
+	// Index is the index of the test in its suite, or of the assertion in its test.
+	Index      *int     `json:"index,omitempty"`
+	AssertType string   `json:"assertType,omitempty"`
+	Not        bool     `json:"not,omitempty"`
+	Passed     *bool    `json:"passed,omitempty"`
+	Skipped    bool     `json:"skipped,omitempty"`
+	SkipReason string   `json:"skipReason,omitempty"`
+	FailInfo   []string `json:"failInfo,omitempty"`
+	CustomInfo string   `json:"customInfo,omitempty"`
+	Error      string   `json:"error,omitempty"`
+	// Elapsed is the duration in seconds, of tests and of the run.
+	Elapsed *float64         `json:"elapsed,omitempty"`
+	Summary *EventRunSummary `json:"summary,omitempty"`
+}
+
+// EventRunSummary are the countings of a finished run.
+type EventRunSummary struct {
+	Charts     EventCounting `json:"charts"`
+	Suites     EventCounting `json:"suites"`
+	Tests      EventCounting `json:"tests"`
+	Assertions EventCounting `json:"assertions"`
+	Snapshots  EventCounting `json:"snapshots"`
+}
+
+// EventCounting is the JSON representation of a results.UnitCounting.
+type EventCounting struct {
+	Passed  uint `json:"passed"`
+	Failed  uint `json:"failed"`
+	Errored uint `json:"errored"`
+	Skipped uint `json:"skipped"`
+}
+
+// EventStream writes the progress of a test run as newline delimited JSON events.
+type EventStream struct {
+	encoder *json.Encoder
+	chart   *results.ChartResult
+	suite   *results.TestSuiteResult
+	test    *results.TestJobResult
+	err     error
+}
+
+// NewEventStream create an EventStream writing the events to w.
+func NewEventStream(w io.Writer) *EventStream {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return &EventStream{encoder: encoder}
+}
+
+// ChartStarted write a chartStarted event.
+func (s *EventStream) ChartStarted(chart *results.ChartResult) {
+	s.chart = chart
+	s.write(Event{Event: EventChartStarted, Chart: chart.Name, Path: chart.Path})
+}
+
+// SuiteStarted write a suiteStarted event.
+func (s *EventStream) SuiteStarted(suite *results.TestSuiteResult) {
+	s.suite = suite
+	s.write(s.suiteEvent(EventSuiteStarted, suite))
+}
+
+// TestStarted write a testStarted event.
+func (s *EventStream) TestStarted(test *results.TestJobResult) {
+	s.test = test
+	event := s.testEvent(EventTestStarted, test)
+	event.Index = &test.Index
+	s.write(event)
+}
+
+// AssertionFinished write an assertionFinished event.
+func (s *EventStream) AssertionFinished(assertion *results.AssertionResult) {
+	event := s.testEvent(EventAssertionFinished, s.test)
+	event.Index = &assertion.Index
+	event.AssertType = assertion.AssertType
+	event.Not = assertion.Not
+	event.Passed = &assertion.Passed
+	event.Skipped = assertion.Skipped
+	event.SkipReason = assertion.SkipReason
+	event.FailInfo = assertion.FailInfo
+	event.CustomInfo = assertion.CustomInfo
+	s.write(event)
+}
+
+// TestFinished write a testFinished event.
+func (s *EventStream) TestFinished(test *results.TestJobResult) {
+	event := s.testEvent(EventTestFinished, test)
+	event.Index = &test.Index
+	event.Passed = &test.Passed
+	event.Skipped = test.Skipped
+	event.Error = errorString(test.ExecError)
+	event.Elapsed = seconds(test.Duration)
+	s.write(event)
+	s.test = nil
+}
+
+// SuiteFinished write a suiteFinished event.
+func (s *EventStream) SuiteFinished(suite *results.TestSuiteResult) {
+	event := s.suiteEvent(EventSuiteFinished, suite)
+	event.Passed = &suite.Passed
+	event.Skipped = suite.Skipped
+	event.Error = errorString(suite.ExecError)
+	s.write(event)
+	s.suite = nil
+}
+
+// ChartFinished write a chartFinished event.
+func (s *EventStream) ChartFinished(chart *results.ChartResult) {
+	s.write(Event{
+		Event:  EventChartFinished,
+		Chart:  chart.Name,
+		Path:   chart.Path,
+		Passed: &chart.Passed,
+		Error:  errorString(chart.ExecError),
+	})
+	s.chart = nil
+}
+
+// RunFinished write a runFinished event with the summary of the run.
+func (s *EventStream) RunFinished(result *results.RunResult) {
+	s.write(Event{
+		Event:   EventRunFinished,
+		Passed:  &result.Passed,
+		Elapsed: seconds(result.Duration),
+		Summary: &EventRunSummary{
+			Charts:     eventCounting(result.ChartCounting),
+			Suites:     eventCounting(result.SuiteCounting.UnitCounting),
+			Tests:      eventCounting(result.TestCounting),
+			Assertions: eventCounting(result.AssertionCounting),
+			Snapshots:  eventCounting(result.SnapshotCounting.UnitCounting),
+		},
+	})
+}
+
+// Err returns the first error of writing the events.
+func (s *EventStream) Err() error {
+	return s.err
+}
+
+func (s *EventStream) suiteEvent(name string, suite *results.TestSuiteResult) Event {
+	event := Event{Event: name, Suite: suite.DisplayName, File: suite.FilePath}
+	if s.chart != nil {
+		event.Chart = s.chart.Name
+	}
+	return event
+}
+
+func (s *EventStream) testEvent(name string, test *results.TestJobResult) Event {
+	event := Event{Event: name}
+	if s.chart != nil {
+		event.Chart = s.chart.Name
+	}
+	if s.suite != nil {
+		event.Suite = s.suite.DisplayName
+		event.File = s.suite.FilePath
+	}
+	if test != nil {
+		event.Test = test.DisplayName
+	}
+	return event
+}
+
+func (s *EventStream) write(event Event) {
+	if s.err != nil {
+		return
+	}
+	s.err = s.encoder.Encode(event)
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func seconds(duration time.Duration) *float64 {
+	elapsed := duration.Seconds()
+	return &elapsed
+}
+
+func eventCounting(counting results.UnitCounting) EventCounting {
+	return EventCounting{
+		Passed:  counting.Passed,
+		Failed:  counting.Failed,
+		Errored: counting.Errored,
+		Skipped: counting.Skipped,
+	}
+}
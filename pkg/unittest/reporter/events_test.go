@@ -0,0 +1,108 @@
+package reporter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/reporter"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/results"
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeEvents(t *testing.T, output string) []Event {
+	var events []Event
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		var event Event
+		assert.NoError(t, json.Unmarshal([]byte(line), &event))
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestEventStreamWritesEventsWithContext(t *testing.T) {
+	a := assert.New(t)
+	output := new(bytes.Buffer)
+	stream := NewEventStream(output)
+
+	chart := &results.ChartResult{Name: "basic", Path: "charts/basic", Passed: true}
+	suite := &results.TestSuiteResult{DisplayName: "deployment", FilePath: "tests/deployment_test.yaml", Passed: true}
+	assertion := &results.AssertionResult{Index: 1, AssertType: "equal", Passed: false, FailInfo: []string{"Path: metadata.name"}}
+	test := &results.TestJobResult{DisplayName: "should be named", Index: 2, ExecError: errors.New("failed"), Duration: time.Second}
+
+	stream.ChartStarted(chart)
+	stream.SuiteStarted(suite)
+	stream.TestStarted(test)
+	stream.AssertionFinished(assertion)
+	stream.TestFinished(test)
+	stream.SuiteFinished(suite)
+	stream.ChartFinished(chart)
+	stream.RunFinished(&results.RunResult{
+		Passed:       false,
+		Duration:     2 * time.Second,
+		TestCounting: results.UnitCounting{Failed: 1, Errored: 1},
+	})
+	a.NoError(stream.Err())
+
+	events := decodeEvents(t, output.String())
+	a.Len(events, 8)
+
+	names := make([]string, 0, len(events))
+	for _, event := range events {
+		names = append(names, event.Event)
+	}
+	a.Equal([]string{
+		EventChartStarted, EventSuiteStarted, EventTestStarted, EventAssertionFinished,
+		EventTestFinished, EventSuiteFinished, EventChartFinished, EventRunFinished,
+	}, names)
+
+	assertionEvent := events[3]
+	a.Equal("basic", assertionEvent.Chart)
+	a.Equal("deployment", assertionEvent.Suite)
+	a.Equal("tests/deployment_test.yaml", assertionEvent.File)
+	a.Equal("should be named", assertionEvent.Test)
+	a.Equal(1, *assertionEvent.Index)
+	a.Equal("equal", assertionEvent.AssertType)
+	a.False(*assertionEvent.Passed)
+	a.Equal([]string{"Path: metadata.name"}, assertionEvent.FailInfo)
+
+	testEvent := events[4]
+	a.Equal(2, *testEvent.Index)
+	a.Equal("failed", testEvent.Error)
+	a.Equal(1.0, *testEvent.Elapsed)
+
+	runEvent := events[7]
+	a.False(*runEvent.Passed)
+	a.Equal(2.0, *runEvent.Elapsed)
+	a.Equal(EventCounting{Failed: 1, Errored: 1}, runEvent.Summary.Tests)
+}
+
+func TestEventStreamWritesCamelCaseJson(t *testing.T) {
+	a := assert.New(t)
+	output := new(bytes.Buffer)
+	stream := NewEventStream(output)
+
+	stream.ChartFinished(&results.ChartResult{Path: "charts/<missing>", ExecError: errors.New("no chart")})
+
+	a.Equal(`{"event":"chartFinished","path":"charts/<missing>","passed":false,"error":"no chart"}`+"\n", output.String())
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestEventStreamKeepsWriteError(t *testing.T) {
+	a := assert.New(t)
+	stream := NewEventStream(failingWriter{})
+
+	stream.ChartStarted(&results.ChartResult{Name: "basic"})
+	stream.RunFinished(&results.RunResult{})
+
+	a.EqualError(stream.Err(), "write failed")
+	a.EqualError(Err(stream), "write failed")
+}
@@ -0,0 +1,59 @@
+package reporter
+
+import (
+	"io"
+	"os"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/formatter"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/results"
+)
+
+// FormatterReporter collects the results of the run test suites and writes them with a Formatter when the run is finished.
+type FormatterReporter struct {
+	NopReporter
+	formatter  formatter.Formatter
+	output     io.Writer
+	outputFile string
+	suites     []*results.TestSuiteResult
+	err        error
+}
+
+// NewFormatterReporter create a FormatterReporter writing to the output.
+func NewFormatterReporter(formatter formatter.Formatter, output io.Writer) *FormatterReporter {
+	return &FormatterReporter{formatter: formatter, output: output}
+}
+
+// NewFormatterFileReporter create a FormatterReporter writing to the outputFile, which is created when the run is finished.
+func NewFormatterFileReporter(formatter formatter.Formatter, outputFile string) *FormatterReporter {
+	return &FormatterReporter{formatter: formatter, outputFile: outputFile}
+}
+
+// SuiteStarted collect the suite, suites failing before they are started are not reported.
+func (r *FormatterReporter) SuiteStarted(suite *results.TestSuiteResult) {
+	r.suites = append(r.suites, suite)
+}
+
+// RunFinished write the collected suites with the formatter.
+func (r *FormatterReporter) RunFinished(*results.RunResult) {
+	r.err = r.write()
+}
+
+// Err returns the error of writing the output.
+func (r *FormatterReporter) Err() error {
+	return r.err
+}
+
+func (r *FormatterReporter) write() error {
+	writer := r.output
+	if writer == nil {
+		// Create outputfile for testsuite
+		file, err := os.Create(r.outputFile)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		writer = file
+	}
+
+	return r.formatter.WriteTestOutput(r.suites, true, writer)
+}
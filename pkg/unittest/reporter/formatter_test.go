@@ -0,0 +1,50 @@
+package reporter_test
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"testing"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/reporter"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/results"
+	"github.com/stretchr/testify/assert"
+)
+
+// suitesFormatter writes the names of the formatted suites.
+type suitesFormatter struct{}
+
+func (suitesFormatter) WriteTestOutput(testSuiteResults []*results.TestSuiteResult, _ bool, w io.Writer) error {
+	for _, suite := range testSuiteResults {
+		if _, err := io.WriteString(w, suite.DisplayName+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestFormatterReporterWritesStartedSuites(t *testing.T) {
+	a := assert.New(t)
+	output := new(bytes.Buffer)
+	formatterReporter := NewFormatterReporter(suitesFormatter{}, output)
+
+	formatterReporter.SuiteStarted(&results.TestSuiteResult{DisplayName: "first"})
+	formatterReporter.SuiteFinished(&results.TestSuiteResult{DisplayName: "not started"})
+	formatterReporter.SuiteStarted(&results.TestSuiteResult{DisplayName: "second"})
+	a.Empty(output.String())
+
+	formatterReporter.RunFinished(&results.RunResult{})
+
+	a.NoError(formatterReporter.Err())
+	a.Equal("first\nsecond\n", output.String())
+}
+
+func TestFormatterFileReporterFailsToCreateFile(t *testing.T) {
+	a := assert.New(t)
+	outputFile := filepath.Join(t.TempDir(), "missing", "output.xml")
+	formatterReporter := NewFormatterFileReporter(suitesFormatter{}, outputFile)
+
+	formatterReporter.RunFinished(&results.RunResult{})
+
+	a.Error(formatterReporter.Err())
+}
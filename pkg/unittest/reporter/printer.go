@@ -0,0 +1,120 @@
+package reporter
+
+import (
+	"fmt"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/results"
+)
+
+// PrinterReporter prints the human readable test output with a Printer.
+type PrinterReporter struct {
+	NopReporter
+	printer *printer.Printer
+}
+
+// NewPrinterReporter create a PrinterReporter printing with the printer.
+func NewPrinterReporter(printer *printer.Printer) *PrinterReporter {
+	return &PrinterReporter{printer: printer}
+}
+
+// ChartStarted print header before suite result of a chart
+func (r *PrinterReporter) ChartStarted(chart *results.ChartResult) {
+	headerFormat := `
+### Chart [ %s ] %s
+`
+	header := fmt.Sprintf(
+		headerFormat,
+		r.printer.Highlight("%s", chart.Name),
+		r.printer.Faint("%s", chart.Path),
+	)
+	r.printer.Println(header, 0)
+}
+
+// SuiteFinished print suite result
+func (r *PrinterReporter) SuiteFinished(suite *results.TestSuiteResult) {
+	suite.Print(r.printer, 0)
+}
+
+// ChartFinished print header with the error, if the chart has an execution error,
+// and the orphaned and pruned snapshot files of the chart
+func (r *PrinterReporter) ChartFinished(chart *results.ChartResult) {
+	if chart.ExecError != nil {
+		r.PrintError(chart.ExecError)
+	}
+	r.printSnapshotFiles(chart.OrphanedSnapshots, r.printer.WarningLabel(" ORPHANED "))
+	r.printSnapshotFiles(chart.PrunedSnapshots, r.printer.SuccessLabel(" PRUNED "))
+}
+
+// printSnapshotFiles print the snapshot files with the label
+func (r *PrinterReporter) printSnapshotFiles(files []string, label string) {
+	for _, file := range files {
+		r.printer.Println(label+" "+r.printer.Faint("%s", file), 0)
+	}
+}
+
+// RunFinished print snapshot summary and summary footer
+func (r *PrinterReporter) RunFinished(result *results.RunResult) {
+	r.printSnapshotSummary(result)
+	r.printSummary(result)
+}
+
+// PrintError print header with the error
+func (r *PrinterReporter) PrintError(err error) {
+	headerFormat := `
+### ` + r.printer.Danger("%s", "Error: ") + ` %s
+`
+	header := fmt.Sprintf(headerFormat, err)
+	r.printer.Println(header, 0)
+}
+
+// printSnapshotSummary print snapshot summary in footer
+func (r *PrinterReporter) printSnapshotSummary(result *results.RunResult) {
+	if result.SnapshotCounting.Failed > 0 {
+		snapshotFormat := `
+Snapshot Summary: %s`
+
+		summary := r.printer.Danger("%d snapshot failed", result.SnapshotCounting.Failed) +
+			fmt.Sprintf(" in %d test suite.", result.SuiteCounting.SnapshotFailed) +
+			r.printer.Faint("%s", " Check changes and use `-u` to update snapshot.")
+
+		r.printer.Println(fmt.Sprintf(snapshotFormat, summary), 0)
+	}
+
+	if result.SnapshotCounting.Orphaned > 0 {
+		snapshotFormat := `
+Snapshot Summary: %s`
+
+		summary := r.printer.Warning("%d orphaned snapshot file", result.SnapshotCounting.Orphaned) +
+			r.printer.Faint("%s", " Check and use `--prune-snapshots` to remove them.")
+
+		r.printer.Println(fmt.Sprintf(snapshotFormat, summary), 0)
+	}
+
+	if result.SnapshotCounting.Pruned > 0 {
+		r.printer.Println(fmt.Sprintf(`
+Snapshot Summary: %d obsolete snapshot pruned.`, result.SnapshotCounting.Pruned), 0)
+	}
+}
+
+// printSummary print summary footer
+func (r *PrinterReporter) printSummary(result *results.RunResult) {
+	summaryFormat := `
+Charts:      %s
+Test Suites: %s
+Tests:       %s
+Snapshot:    %s
+Time:        %s
+`
+	r.printer.Println(
+		fmt.Sprintf(
+			summaryFormat,
+			result.ChartCounting.Sprint(r.printer),
+			result.SuiteCounting.Sprint(r.printer),
+			result.TestCounting.Sprint(r.printer),
+			result.SnapshotCounting.Sprint(r.printer),
+			result.Duration.String(),
+		),
+		0,
+	)
+}
@@ -0,0 +1,36 @@
+package reporter_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/reporter"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/results"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrinterReporterPrintsChartAndSummary(t *testing.T) {
+	a := assert.New(t)
+	colored := false
+	output := new(bytes.Buffer)
+	printerReporter := NewPrinterReporter(printer.NewPrinter(output, &colored))
+
+	printerReporter.ChartStarted(&results.ChartResult{Name: "basic", Path: "charts/basic"})
+	printerReporter.SuiteFinished(&results.TestSuiteResult{DisplayName: "deployment", FilePath: "tests/deployment_test.yaml", Passed: true})
+	printerReporter.ChartFinished(&results.ChartResult{ExecError: errors.New("no chart")})
+	printerReporter.RunFinished(&results.RunResult{
+		Duration:      time.Second,
+		ChartCounting: results.UnitCounting{Passed: 1, Failed: 1, Errored: 1},
+		TestCounting:  results.UnitCounting{Passed: 2, Skipped: 1},
+	})
+
+	a.Contains(output.String(), "### Chart [ basic ] charts/basic")
+	a.Contains(output.String(), " PASS  deployment\ttests/deployment_test.yaml")
+	a.Contains(output.String(), "### Error:  no chart")
+	a.Contains(output.String(), "Charts:      1 failed, 1 errored, 1 passed, 2 total")
+	a.Contains(output.String(), "Tests:       2 passed, 1 skipped, 3 total")
+	a.Contains(output.String(), "Time:        1s")
+}
@@ -0,0 +1,123 @@
+package reporter
+
+import (
+	"errors"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/results"
+)
+
+// Reporter receives the progress of a test run while the tests are running.
+// The results passed to the Started callbacks are completed by the time the Finished callbacks are invoked.
+type Reporter interface {
+	// ChartStarted is invoked before the test suites of a chart are run.
+	ChartStarted(chart *results.ChartResult)
+	// SuiteStarted is invoked before the tests of a suite are run.
+	SuiteStarted(suite *results.TestSuiteResult)
+	// TestStarted is invoked before a test is run or skipped.
+	TestStarted(test *results.TestJobResult)
+	// AssertionFinished is invoked for every assertion of a test, before the test is finished.
+	AssertionFinished(assertion *results.AssertionResult)
+	// TestFinished is invoked when a test is finished or skipped.
+	TestFinished(test *results.TestJobResult)
+	// SuiteFinished is invoked when a suite is finished, or failed to be parsed or run.
+	SuiteFinished(suite *results.TestSuiteResult)
+	// ChartFinished is invoked when the test suites of a chart are finished, or the chart failed to load.
+	ChartFinished(chart *results.ChartResult)
+	// RunFinished is invoked when all charts are finished.
+	RunFinished(result *results.RunResult)
+}
+
+// NopReporter ignores all events, embed it to implement only the callbacks of interest.
+type NopReporter struct{}
+
+func (NopReporter) ChartStarted(*results.ChartResult)          {}
+func (NopReporter) SuiteStarted(*results.TestSuiteResult)      {}
+func (NopReporter) TestStarted(*results.TestJobResult)         {}
+func (NopReporter) AssertionFinished(*results.AssertionResult) {}
+func (NopReporter) TestFinished(*results.TestJobResult)        {}
+func (NopReporter) SuiteFinished(*results.TestSuiteResult)     {}
+func (NopReporter) ChartFinished(*results.ChartResult)         {}
+func (NopReporter) RunFinished(*results.RunResult)             {}
+
+// errorReporter is a Reporter which can fail, like writing its output.
+type errorReporter interface {
+	Err() error
+}
+
+// multiReporter invokes the reporters in order.
+type multiReporter []Reporter
+
+// Multi combines the reporters into a single Reporter, which invokes them in order.
+func Multi(reporters ...Reporter) Reporter {
+	combined := make(multiReporter, 0, len(reporters))
+	for _, reporter := range reporters {
+		if reporter != nil {
+			combined = append(combined, reporter)
+		}
+	}
+	return combined
+}
+
+func (m multiReporter) ChartStarted(chart *results.ChartResult) {
+	for _, reporter := range m {
+		reporter.ChartStarted(chart)
+	}
+}
+
+func (m multiReporter) SuiteStarted(suite *results.TestSuiteResult) {
+	for _, reporter := range m {
+		reporter.SuiteStarted(suite)
+	}
+}
+
+func (m multiReporter) TestStarted(test *results.TestJobResult) {
+	for _, reporter := range m {
+		reporter.TestStarted(test)
+	}
+}
+
+func (m multiReporter) AssertionFinished(assertion *results.AssertionResult) {
+	for _, reporter := range m {
+		reporter.AssertionFinished(assertion)
+	}
+}
+
+func (m multiReporter) TestFinished(test *results.TestJobResult) {
+	for _, reporter := range m {
+		reporter.TestFinished(test)
+	}
+}
+
+func (m multiReporter) SuiteFinished(suite *results.TestSuiteResult) {
+	for _, reporter := range m {
+		reporter.SuiteFinished(suite)
+	}
+}
+
+func (m multiReporter) ChartFinished(chart *results.ChartResult) {
+	for _, reporter := range m {
+		reporter.ChartFinished(chart)
+	}
+}
+
+func (m multiReporter) RunFinished(result *results.RunResult) {
+	for _, reporter := range m {
+		reporter.RunFinished(result)
+	}
+}
+
+// Err returns the errors of the reporters which failed.
+func (m multiReporter) Err() error {
+	return Err(m...)
+}
+
+// Err returns the errors of the reporters which failed to report, like failing to write their output.
+func Err(reporters ...Reporter) error {
+	var errs []error
+	for _, reporter := range reporters {
+		if failing, ok := reporter.(errorReporter); ok {
+			errs = append(errs, failing.Err())
+		}
+	}
+	return errors.Join(errs...)
+}
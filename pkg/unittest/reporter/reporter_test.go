@@ -0,0 +1,65 @@
+package reporter_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/reporter"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/results"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingReporter records the names of the received events.
+type recordingReporter struct {
+	NopReporter
+	events []string
+	err    error
+}
+
+func (r *recordingReporter) ChartStarted(chart *results.ChartResult) {
+	r.events = append(r.events, "chartStarted "+chart.Name)
+}
+
+func (r *recordingReporter) TestFinished(test *results.TestJobResult) {
+	r.events = append(r.events, "testFinished "+test.DisplayName)
+}
+
+func (r *recordingReporter) RunFinished(*results.RunResult) {
+	r.events = append(r.events, "runFinished")
+}
+
+func (r *recordingReporter) Err() error {
+	return r.err
+}
+
+func TestMultiInvokesReportersInOrder(t *testing.T) {
+	a := assert.New(t)
+	first := &recordingReporter{}
+	second := &recordingReporter{}
+
+	multi := Multi(first, nil, second)
+	multi.ChartStarted(&results.ChartResult{Name: "basic"})
+	multi.SuiteStarted(&results.TestSuiteResult{})
+	multi.TestFinished(&results.TestJobResult{DisplayName: "should pass"})
+	multi.RunFinished(&results.RunResult{})
+
+	expected := []string{"chartStarted basic", "testFinished should pass", "runFinished"}
+	a.Equal(expected, first.events)
+	a.Equal(expected, second.events)
+}
+
+func TestErrJoinsErrorsOfReporters(t *testing.T) {
+	a := assert.New(t)
+	firstErr := errors.New("first failed")
+	secondErr := errors.New("second failed")
+
+	a.NoError(Err(&recordingReporter{}, NopReporter{}))
+	a.NoError(Err())
+
+	err := Err(&recordingReporter{err: firstErr}, NopReporter{}, &recordingReporter{err: secondErr})
+	a.ErrorIs(err, firstErr)
+	a.ErrorIs(err, secondErr)
+
+	err = Err(Multi(&recordingReporter{err: firstErr}))
+	a.ErrorIs(err, firstErr)
+}
@@ -0,0 +1,86 @@
+package results
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
+)
+
+// UnitCounting stores counting numbers of test unit status
+type UnitCounting struct {
+	Passed  uint
+	Failed  uint
+	Errored uint
+	Skipped uint
+}
+
+// Sprint returns string of counting result
+func (counting UnitCounting) Sprint(printer *printer.Printer) string {
+	var failedLabel string
+	if counting.Failed > 0 {
+		failedLabel = printer.Danger("%d failed, ", counting.Failed)
+	}
+	var erroredLabel string
+	if counting.Errored > 0 {
+		erroredLabel = fmt.Sprintf("%d errored, ", counting.Errored)
+	}
+	result := failedLabel + erroredLabel
+	if counting.Skipped > 0 {
+		result += fmt.Sprintf(
+			"%d passed, %d skipped, %d total",
+			counting.Passed,
+			counting.Skipped,
+			counting.Passed+counting.Failed+counting.Skipped,
+		)
+	} else {
+		result += fmt.Sprintf(
+			"%d passed, %d total",
+			counting.Passed,
+			counting.Passed+counting.Failed,
+		)
+	}
+	return result
+}
+
+// SuiteCounting stores UnitCounting of suites with the suites having failed snapshots
+type SuiteCounting struct {
+	UnitCounting
+	SnapshotFailed uint
+}
+
+// SnapshotCounting stores UnitCounting of snapshots with the created, vanished, orphaned and pruned snapshots
+type SnapshotCounting struct {
+	UnitCounting
+	Created  uint
+	Vanished uint
+	// Orphaned counts the snapshot files belonging to no suite, which are not pruned
+	Orphaned uint
+	// Pruned counts the removed obsolete snapshots and orphaned snapshot files
+	Pruned uint
+}
+
+// ChartResult result of running the test suites of a chart
+type ChartResult struct {
+	Name         string
+	Path         string
+	Passed       bool
+	ExecError    error
+	SuitesResult []*TestSuiteResult
+	// OrphanedSnapshots are the snapshot files of the chart belonging to no suite
+	OrphanedSnapshots []string
+	// PrunedSnapshots are the orphaned snapshot files of the chart which are removed
+	PrunedSnapshots []string
+}
+
+// RunResult result of running the tests of charts
+type RunResult struct {
+	Passed            bool
+	Duration          time.Duration
+	Charts            []*ChartResult
+	ChartCounting     UnitCounting
+	SuiteCounting     SuiteCounting
+	TestCounting      UnitCounting
+	AssertionCounting UnitCounting
+	SnapshotCounting  SnapshotCounting
+}
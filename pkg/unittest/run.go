@@ -0,0 +1,117 @@
+package unittest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/formatter"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/reporter"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/results"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+)
+
+// RunOptions are the options to run the tests of charts with Run.
+type RunOptions struct {
+	// ChartPaths are the paths of the charts to test.
+	ChartPaths []string
+	// TestFiles are the glob patterns of the test suite files, relative to the chart.
+	TestFiles []string
+	// Suites are in-memory test suites, which run next to the TestFiles of every chart.
+	Suites []SuiteDefinition
+	// ValuesFiles are the values files applied to all test suites.
+	ValuesFiles []string
+	// ChartTestsPath is the path of the helm rendered test suites, relative to the chart.
+	ChartTestsPath string
+	WithSubChart   bool
+	Strict         bool
+	FailFast       bool
+	UpdateSnapshot bool
+	// UpdateSnapshotPattern limits the snapshots updated by UpdateSnapshot to the suites or tests with a matching name.
+	UpdateSnapshotPattern *regexp.Regexp
+	// SnapshotReview lists the changed snapshots to update, next to the snapshots updated by UpdateSnapshot.
+	SnapshotReview *snapshot.Review
+	// PruneSnapshots removes the obsolete snapshots and the orphaned snapshot files.
+	PruneSnapshots bool
+	// CI never writes snapshots, and fails on missing snapshots, obsolete snapshots and orphaned snapshot files.
+	CI bool
+	// SnapshotDir stores the snapshot files, keeping the paths of the suite files relative to the chart.
+	// A relative SnapshotDir is relative to the chart, the snapshots are stored next to the suite files when empty.
+	SnapshotDir string
+	// SnapshotPerTest stores the snapshots of each test in a file of its own, instead of a file per suite.
+	SnapshotPerTest bool
+	// RenderPath is the directory to write the rendered templates to, for debugging.
+	RenderPath string
+	// Output receives the human readable test output, nothing is printed when nil.
+	Output io.Writer
+	// Colored forces the colors of the Output on or off, when set.
+	Colored *bool
+	// Formatter writes the test results to the FormatterOutput, when both are set.
+	Formatter       formatter.Formatter
+	FormatterOutput io.Writer
+	// Reporters receive the results while the tests are running.
+	Reporters []reporter.Reporter
+}
+
+// SuiteDefinition is a test suite file defined in memory.
+type SuiteDefinition struct {
+	// Path is where the suite file is considered to be located, relative paths are relative to the chart.
+	// Values files and snapshots are resolved relative to it, it defaults to `tests/suite-<index>_test.yaml`.
+	Path string
+	// Content of the suite file, which may contain multiple suites separated by `---`.
+	Content string
+}
+
+// filePath returns the path of the suite definition within the chart.
+func (d SuiteDefinition) filePath(chartPath string, idx int) string {
+	if d.Path == "" {
+		return filepath.Join(chartPath, "tests", fmt.Sprintf("suite-%d_test.yaml", idx))
+	}
+	if filepath.IsAbs(d.Path) {
+		return d.Path
+	}
+	return filepath.Join(chartPath, d.Path)
+}
+
+// Run runs the tests of the charts with the given options and returns the result.
+// The test output is only written to the writers of the options, the returned error
+// indicates invalid options or a failure to write the formatted output.
+func Run(options RunOptions) (*results.RunResult, error) {
+	if len(options.ChartPaths) == 0 {
+		return nil, errors.New("no chart paths to test")
+	}
+	if options.Formatter != nil && options.FormatterOutput == nil {
+		return nil, errors.New("formatter requires a formatter output")
+	}
+
+	output := options.Output
+	if output == nil {
+		output = io.Discard
+	}
+
+	runner := TestRunner{
+		Printer:               printer.NewPrinter(output, options.Colored),
+		Formatter:             options.Formatter,
+		UpdateSnapshot:        options.UpdateSnapshot,
+		UpdateSnapshotPattern: options.UpdateSnapshotPattern,
+		SnapshotReview:        options.SnapshotReview,
+		PruneSnapshots:        options.PruneSnapshots,
+		CI:                    options.CI,
+		SnapshotDir:           options.SnapshotDir,
+		SnapshotPerTest:       options.SnapshotPerTest,
+		WithSubChart:          options.WithSubChart,
+		Strict:                options.Strict,
+		Failfast:              options.FailFast,
+		TestFiles:             options.TestFiles,
+		ChartTestsPath:        options.ChartTestsPath,
+		ValuesFiles:           options.ValuesFiles,
+		RenderPath:            options.RenderPath,
+		Suites:                options.Suites,
+		Reporters:             options.Reporters,
+		formatterOutput:       options.FormatterOutput,
+	}
+	return runner.run(options.ChartPaths)
+}
@@ -0,0 +1,505 @@
+package unittest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/formatter"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/reporter"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/results"
+	"github.com/stretchr/testify/assert"
+)
+
+const inMemoryPassingSuite = `
+suite: in memory suite
+templates:
+  - templates/configmap.yaml
+  - templates/deployment.yaml
+tests:
+  - it: should be a deployment
+    template: templates/deployment.yaml
+    asserts:
+      - isKind:
+          of: Deployment
+      - exists:
+          path: metadata.name
+`
+
+const inMemoryFailingSuite = `
+suite: in memory failing suite
+templates:
+  - templates/configmap.yaml
+  - templates/deployment.yaml
+tests:
+  - it: should fail
+    template: templates/deployment.yaml
+    asserts:
+      - isKind:
+          of: Service
+  - it: should be skipped
+    skip:
+      reason: not relevant
+    asserts:
+      - isKind:
+          of: Deployment
+`
+
+func TestRunWithInMemorySuite(t *testing.T) {
+	a := assert.New(t)
+	result, err := Run(RunOptions{
+		ChartPaths: []string{testV3BasicChart},
+		Suites:     []SuiteDefinition{{Content: inMemoryPassingSuite}},
+	})
+
+	a.NoError(err)
+	a.True(result.Passed)
+	a.Len(result.Charts, 1)
+	a.Equal("basic", result.Charts[0].Name)
+	a.Equal(testV3BasicChart, result.Charts[0].Path)
+	a.True(result.Charts[0].Passed)
+	a.Len(result.Charts[0].SuitesResult, 1)
+	a.Equal("in memory suite", result.Charts[0].SuitesResult[0].DisplayName)
+	a.Equal(results.UnitCounting{Passed: 1}, result.ChartCounting)
+	a.Equal(results.UnitCounting{Passed: 1}, result.SuiteCounting.UnitCounting)
+	a.Equal(results.UnitCounting{Passed: 1}, result.TestCounting)
+	a.Equal(results.UnitCounting{Passed: 2}, result.AssertionCounting)
+}
+
+func TestRunWithFailingInMemorySuite(t *testing.T) {
+	a := assert.New(t)
+	result, err := Run(RunOptions{
+		ChartPaths: []string{testV3BasicChart},
+		Suites:     []SuiteDefinition{{Path: "tests/in_memory_test.yaml", Content: inMemoryFailingSuite}},
+	})
+
+	a.NoError(err)
+	a.False(result.Passed)
+	a.False(result.Charts[0].Passed)
+	a.Equal(results.UnitCounting{Failed: 1}, result.ChartCounting)
+	a.Equal(results.UnitCounting{Failed: 1}, result.SuiteCounting.UnitCounting)
+	a.Equal(results.UnitCounting{Failed: 1, Skipped: 1}, result.TestCounting)
+	a.Equal(results.UnitCounting{Failed: 1}, result.AssertionCounting)
+	a.Contains(result.Charts[0].SuitesResult[0].FilePath, "in_memory_test.yaml")
+}
+
+func TestRunWritesOutput(t *testing.T) {
+	a := assert.New(t)
+	colored := false
+	output := new(bytes.Buffer)
+	result, err := Run(RunOptions{
+		ChartPaths: []string{testV3BasicChart},
+		Suites:     []SuiteDefinition{{Content: inMemoryPassingSuite}},
+		Output:     output,
+		Colored:    &colored,
+	})
+
+	a.NoError(err)
+	a.True(result.Passed)
+	a.Contains(output.String(), "### Chart [ basic ]")
+	a.Contains(output.String(), "in memory suite")
+	a.Contains(output.String(), "Tests:       1 passed, 1 total")
+}
+
+func TestRunWritesFormatterOutput(t *testing.T) {
+	a := assert.New(t)
+	output := new(bytes.Buffer)
+	result, err := Run(RunOptions{
+		ChartPaths:      []string{testV3BasicChart},
+		Suites:          []SuiteDefinition{{Content: inMemoryPassingSuite}},
+		Formatter:       formatter.NewJUnitReportXML(),
+		FormatterOutput: output,
+	})
+
+	a.NoError(err)
+	a.True(result.Passed)
+	a.Contains(output.String(), "in memory suite")
+}
+
+func TestRunWithInvalidChart(t *testing.T) {
+	a := assert.New(t)
+	result, err := Run(RunOptions{
+		ChartPaths: []string{"../../test/data/v3/not-existing"},
+		Suites:     []SuiteDefinition{{Content: inMemoryPassingSuite}},
+	})
+
+	a.NoError(err)
+	a.False(result.Passed)
+	a.Error(result.Charts[0].ExecError)
+	a.Equal(results.UnitCounting{Failed: 1, Errored: 1}, result.ChartCounting)
+}
+
+func TestRunWithInvalidOptions(t *testing.T) {
+	a := assert.New(t)
+
+	_, err := Run(RunOptions{})
+	a.EqualError(err, "no chart paths to test")
+
+	_, err = Run(RunOptions{ChartPaths: []string{testV3BasicChart}, Formatter: formatter.NewJUnitReportXML()})
+	a.EqualError(err, "formatter requires a formatter output")
+}
+
+func TestRunStreamsEventsToReporters(t *testing.T) {
+	a := assert.New(t)
+	output := new(bytes.Buffer)
+	result, err := Run(RunOptions{
+		ChartPaths: []string{testV3BasicChart},
+		Suites:     []SuiteDefinition{{Content: inMemoryFailingSuite}},
+		Reporters:  []reporter.Reporter{reporter.NewEventStream(output)},
+	})
+
+	a.NoError(err)
+	a.False(result.Passed)
+
+	var names []string
+	for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+		var event reporter.Event
+		a.NoError(json.Unmarshal([]byte(line), &event))
+		names = append(names, event.Event)
+	}
+	a.Equal([]string{
+		reporter.EventChartStarted,
+		reporter.EventSuiteStarted,
+		reporter.EventTestStarted,
+		reporter.EventAssertionFinished,
+		reporter.EventTestFinished,
+		reporter.EventTestStarted,
+		reporter.EventTestFinished,
+		reporter.EventSuiteFinished,
+		reporter.EventChartFinished,
+		reporter.EventRunFinished,
+	}, names)
+}
+
+const inMemorySnapshotSuite = `
+suite: in memory snapshot suite
+templates:
+  - templates/configmap.yaml
+tests:
+  - it: should match snapshot
+    asserts:
+      - matchSnapshot: {}
+`
+
+func absPaths(paths []string) []string {
+	absolutes := make([]string, 0, len(paths))
+	for _, path := range paths {
+		absolute, _ := filepath.Abs(path)
+		absolutes = append(absolutes, absolute)
+	}
+	return absolutes
+}
+
+func TestRunWithObsoleteSnapshots(t *testing.T) {
+	a := assert.New(t)
+	dir := t.TempDir()
+	snapshotDir := filepath.Join(dir, "__snapshot__")
+	snapshotFile := filepath.Join(snapshotDir, "snapshot_test.yaml.snap")
+	orphanedFile := filepath.Join(snapshotDir, "removed_test.yaml.snap")
+	a.NoError(os.MkdirAll(snapshotDir, 0755))
+	a.NoError(os.WriteFile(snapshotFile, []byte("removed test:\n  1: |\n    a: b\n"), 0644))
+	a.NoError(os.WriteFile(orphanedFile, []byte{}, 0644))
+
+	options := RunOptions{
+		ChartPaths: []string{testV3BasicChart},
+		Suites:     []SuiteDefinition{{Path: filepath.Join(dir, "snapshot_test.yaml"), Content: inMemorySnapshotSuite}},
+		CI:         true,
+	}
+	result, err := Run(options)
+
+	a.NoError(err)
+	a.False(result.Passed)
+	a.Equal([]string{orphanedFile}, absPaths(result.Charts[0].OrphanedSnapshots))
+	a.ErrorContains(result.Charts[0].SuitesResult[0].ExecError, "1 obsolete snapshot(s) in ")
+	a.ErrorContains(result.Charts[0].SuitesResult[0].ExecError, "snapshot_test.yaml.snap, use `--prune-snapshots` to remove them")
+	a.Equal(uint(1), result.SnapshotCounting.Orphaned)
+	a.FileExists(orphanedFile)
+
+	options.CI = false
+	options.PruneSnapshots = true
+	result, err = Run(options)
+
+	a.NoError(err)
+	a.True(result.Passed)
+	a.Equal([]string{orphanedFile}, absPaths(result.Charts[0].PrunedSnapshots))
+	a.Equal(uint(2), result.SnapshotCounting.Pruned)
+	a.NoFileExists(orphanedFile)
+	content, _ := os.ReadFile(snapshotFile)
+	a.NotContains(string(content), "removed test")
+	a.Contains(string(content), "should match snapshot")
+}
+
+func TestRunWithMissingSnapshotsInCI(t *testing.T) {
+	a := assert.New(t)
+	dir := t.TempDir()
+
+	options := RunOptions{
+		ChartPaths: []string{testV3BasicChart},
+		Suites:     []SuiteDefinition{{Path: filepath.Join(dir, "snapshot_test.yaml"), Content: inMemorySnapshotSuite}},
+		CI:         true,
+	}
+	result, err := Run(options)
+
+	a.NoError(err)
+	a.False(result.Passed)
+	a.Equal(uint(1), result.SnapshotCounting.Failed)
+	a.Equal(uint(0), result.SnapshotCounting.Created)
+	a.Contains(result.Charts[0].SuitesResult[0].TestsResult[0].AssertsResult[0].FailInfo,
+		"Expected snapshot 1 to exist, snapshots are not created in CI mode:")
+	a.NoDirExists(filepath.Join(dir, "__snapshot__"))
+}
+
+func TestRunWithSnapshotDir(t *testing.T) {
+	a := assert.New(t)
+	dir := t.TempDir()
+
+	options := RunOptions{
+		ChartPaths:      []string{testV3BasicChart},
+		Suites:          []SuiteDefinition{{Content: inMemorySnapshotSuite}},
+		SnapshotDir:     dir,
+		SnapshotPerTest: true,
+	}
+	result, err := Run(options)
+
+	a.NoError(err)
+	a.True(result.Passed)
+	a.FileExists(filepath.Join(dir, "tests", "suite-0_test.yaml", "should_match_snapshot.snap"))
+	a.NoDirExists(filepath.Join(testV3BasicChart, "tests", "__snapshot__", "suite-0_test.yaml"))
+}
+
+func TestRunWithSuiteSnapshotDir(t *testing.T) {
+	a := assert.New(t)
+	dir := t.TempDir()
+
+	options := RunOptions{
+		ChartPaths: []string{testV3BasicChart},
+		Suites: []SuiteDefinition{{
+			Path:    filepath.Join(dir, "tests", "snapshot_test.yaml"),
+			Content: "snapshotDir: ../snapshots\n" + inMemorySnapshotSuite,
+		}},
+		SnapshotDir: filepath.Join(dir, "ignored"),
+	}
+	result, err := Run(options)
+
+	a.NoError(err)
+	a.True(result.Passed)
+	a.FileExists(filepath.Join(dir, "snapshots", "snapshot_test.yaml.snap"))
+	a.NoDirExists(filepath.Join(dir, "ignored"))
+}
+
+const inlineSnapshotSuite = `# keeps the comments
+suite: inline snapshot suite
+templates:
+  - templates/service.yaml
+tests:
+  - it: should match inline snapshot
+    asserts:
+      # the selector of the service
+      - matchInlineSnapshot:
+          path: spec.selector
+      - matchInlineSnapshot: {path: spec.type}
+`
+
+func writeInlineSnapshotSuite(t *testing.T, content string) string {
+	suiteFile := filepath.Join(t.TempDir(), "inline_test.yaml")
+	assert.NoError(t, os.WriteFile(suiteFile, []byte(content), 0644))
+	return suiteFile
+}
+
+func TestRunWithInlineSnapshots(t *testing.T) {
+	a := assert.New(t)
+	suiteFile := writeInlineSnapshotSuite(t, inlineSnapshotSuite)
+
+	result, err := Run(RunOptions{ChartPaths: []string{testV3BasicChart}, TestFiles: []string{suiteFile}})
+
+	a.NoError(err)
+	a.True(result.Passed)
+	content, _ := os.ReadFile(suiteFile)
+	a.Equal(`# keeps the comments
+suite: inline snapshot suite
+templates:
+  - templates/service.yaml
+tests:
+  - it: should match inline snapshot
+    asserts:
+      # the selector of the service
+      - matchInlineSnapshot:
+          path: spec.selector
+          snapshot:
+            app: basic
+            release: RELEASE-NAME
+      - matchInlineSnapshot:
+          path: spec.type
+          snapshot: ClusterIP
+`, string(content))
+
+	result, err = Run(RunOptions{ChartPaths: []string{testV3BasicChart}, TestFiles: []string{suiteFile}})
+
+	a.NoError(err)
+	a.True(result.Passed)
+	unchanged, _ := os.ReadFile(suiteFile)
+	a.Equal(string(content), string(unchanged))
+}
+
+func TestRunWithChangedInlineSnapshot(t *testing.T) {
+	a := assert.New(t)
+	suite := strings.Replace(inlineSnapshotSuite, "{path: spec.type}", "{path: spec.type, snapshot: NodePort}", 1)
+	suiteFile := writeInlineSnapshotSuite(t, suite)
+
+	result, err := Run(RunOptions{ChartPaths: []string{testV3BasicChart}, TestFiles: []string{suiteFile}})
+
+	a.NoError(err)
+	a.False(result.Passed)
+	a.Equal([]string{
+		"Template:\tbasic/templates/service.yaml",
+		"Path:\tspec.type",
+		"Expected to match inline snapshot:",
+		"\t--- Expected",
+		"\t+++ Actual",
+		"\t@@ -1,2 +1,2 @@",
+		"\t-NodePort",
+		"\t+ClusterIP",
+	}, result.Charts[0].SuitesResult[0].TestsResult[0].AssertsResult[1].FailInfo)
+	content, _ := os.ReadFile(suiteFile)
+	a.Contains(string(content), "snapshot: NodePort")
+
+	result, err = Run(RunOptions{ChartPaths: []string{testV3BasicChart}, TestFiles: []string{suiteFile}, UpdateSnapshot: true})
+
+	a.NoError(err)
+	a.True(result.Passed)
+	content, _ = os.ReadFile(suiteFile)
+	a.Contains(string(content), "snapshot: ClusterIP")
+	a.NotContains(string(content), "NodePort")
+}
+
+func TestRunWithMissingInlineSnapshotsInCI(t *testing.T) {
+	a := assert.New(t)
+	suiteFile := writeInlineSnapshotSuite(t, inlineSnapshotSuite)
+
+	result, err := Run(RunOptions{ChartPaths: []string{testV3BasicChart}, TestFiles: []string{suiteFile}, CI: true})
+
+	a.NoError(err)
+	a.False(result.Passed)
+	content, _ := os.ReadFile(suiteFile)
+	a.Equal(inlineSnapshotSuite, string(content))
+}
+
+const multipleTemplatesInlineSnapshotSuite = `suite: inline snapshot of several templates
+templates:
+  - templates/service.yaml
+  - templates/deployment.yaml
+  - templates/configmap.yaml
+tests:
+  - it: should select a single template
+    asserts:
+      - matchInlineSnapshot: {path: kind}
+  - it: should match the inline snapshot of the selected template
+    template: templates/service.yaml
+    asserts:
+      - matchInlineSnapshot: {path: kind}
+`
+
+func TestRunWithInlineSnapshotsOfSeveralTemplates(t *testing.T) {
+	a := assert.New(t)
+	suiteFile := writeInlineSnapshotSuite(t, multipleTemplatesInlineSnapshotSuite)
+
+	result, err := Run(RunOptions{ChartPaths: []string{testV3BasicChart}, TestFiles: []string{suiteFile}, UpdateSnapshot: true})
+
+	a.NoError(err)
+	a.False(result.Passed)
+	tests := result.Charts[0].SuitesResult[0].TestsResult
+	a.Equal([]string{
+		"Error:",
+		"matchInlineSnapshot asserts a single template, select one of basic/templates/configmap.yaml, " +
+			"basic/templates/deployment.yaml, basic/templates/service.yaml with `template`",
+	}, tests[0].AssertsResult[0].FailInfo)
+	a.True(tests[1].Passed)
+	content, _ := os.ReadFile(suiteFile)
+	a.Equal(strings.Replace(multipleTemplatesInlineSnapshotSuite,
+		"    template: templates/service.yaml\n    asserts:\n      - matchInlineSnapshot: {path: kind}\n",
+		"    template: templates/service.yaml\n    asserts:\n      - matchInlineSnapshot:\n          path: kind\n          snapshot: Service\n", 1),
+		string(content))
+}
+
+const formattedInlineSnapshotSuite = `# suites indented by 4 spaces
+suite:   first formatted suite
+templates:
+    - templates/service.yaml
+set:
+    service:
+        type: "NodePort"   # quoted on purpose
+
+
+tests:
+    -   it: should keep the formatting
+        asserts:
+
+            # the type of the service
+            - matchInlineSnapshot:
+                  path: spec.type
+
+            - equal: {path: kind, value: Service}
+---
+# second suite
+suite: second formatted suite
+templates:
+    - templates/service.yaml
+tests:
+    - it: should keep the formatting of the second suite
+      asserts:
+          - matchInlineSnapshot:   {path: spec.selector}   
+          - isKind:
+                of: Service
+# trailing comment
+`
+
+func TestRunWithInlineSnapshotsKeepsTheFormatting(t *testing.T) {
+	a := assert.New(t)
+	suiteFile := writeInlineSnapshotSuite(t, formattedInlineSnapshotSuite)
+
+	result, err := Run(RunOptions{ChartPaths: []string{testV3BasicChart}, TestFiles: []string{suiteFile}})
+
+	a.NoError(err)
+	a.True(result.Passed)
+	content, _ := os.ReadFile(suiteFile)
+	expected := strings.Replace(formattedInlineSnapshotSuite,
+		"                  path: spec.type\n",
+		"                  path: spec.type\n                  snapshot: NodePort\n", 1)
+	expected = strings.Replace(expected,
+		"          - matchInlineSnapshot:   {path: spec.selector}   \n",
+		"          - matchInlineSnapshot:\n                path: spec.selector\n                snapshot:\n                    app: basic\n                    release: RELEASE-NAME   \n", 1)
+	a.Equal(expected, string(content))
+}
+
+func TestRunWithCustomResources(t *testing.T) {
+	a := assert.New(t)
+
+	result, err := Run(RunOptions{ChartPaths: []string{testV3WithCRDsChart}, TestFiles: []string{testTestFiles}})
+
+	a.NoError(err)
+	a.True(result.Passed)
+	suites := result.Charts[0].SuitesResult
+	a.Len(suites, 2)
+	monitorTests := suites[1].TestsResult
+	a.Equal("isValidCustomResource", monitorTests[0].AssertsResult[1].AssertType)
+	a.True(monitorTests[1].AssertsResult[1].Passed)
+}
+
+func TestRunWithInvalidCustomResources(t *testing.T) {
+	a := assert.New(t)
+
+	result, err := Run(RunOptions{ChartPaths: []string{testV3WithCRDsChart}, TestFiles: []string{testTestFailedFiles}})
+
+	a.NoError(err)
+	a.False(result.Passed)
+	a.Equal([]string{
+		"Template:\twith-crds/templates/crontab.yaml",
+		"DocumentIndex:\t0",
+		"Expected to be a valid custom resource:",
+		"\tspec.replicas: should be less than or equal to 10",
+	}, result.Charts[0].SuitesResult[0].TestsResult[0].AssertsResult[1].FailInfo)
+}
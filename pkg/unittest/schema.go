@@ -0,0 +1,445 @@
+package unittest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+//go:generate go run ../../cmd/helm-unittest schema ../../schema/helm-testsuite.json
+
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// jsonSchema is a node of the (draft-07) json schema of the test suite files.
+type jsonSchema struct {
+	Schema               string           `json:"$schema,omitempty"`
+	Ref                  string           `json:"$ref,omitempty"`
+	Type                 interface{}      `json:"type,omitempty"`
+	Description          string           `json:"description,omitempty"`
+	MarkdownDescription  string           `json:"markdownDescription,omitempty"`
+	Examples             []interface{}    `json:"examples,omitempty"`
+	Required             []string         `json:"required,omitempty"`
+	Properties           schemaProperties `json:"properties,omitempty"`
+	Items                *jsonSchema      `json:"items,omitempty"`
+	MinItems             int              `json:"minItems,omitempty"`
+	MaxProperties        *int             `json:"maxProperties,omitempty"`
+	AdditionalProperties interface{}      `json:"additionalProperties,omitempty"`
+	OneOf                []*jsonSchema    `json:"oneOf,omitempty"`
+	Definitions          schemaProperties `json:"definitions,omitempty"`
+	// anything marks the schema which accepts every value, written as `true`.
+	anything bool
+}
+
+// MarshalJSON writes the schema, or `true` when the schema accepts every value.
+func (s *jsonSchema) MarshalJSON() ([]byte, error) {
+	if s.anything {
+		return []byte("true"), nil
+	}
+	type plainSchema jsonSchema
+	return marshalSchemaJSON((*plainSchema)(s))
+}
+
+// schemaProperty is a named schema, properties keep the order of the go types.
+type schemaProperty struct {
+	name   string
+	schema *jsonSchema
+}
+
+type schemaProperties []schemaProperty
+
+// MarshalJSON writes the properties as an object in their defined order.
+func (p schemaProperties) MarshalJSON() ([]byte, error) {
+	buffer := bytes.NewBufferString("{")
+	for idx, property := range p {
+		if idx > 0 {
+			buffer.WriteString(",")
+		}
+		name, err := marshalSchemaJSON(property.name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := marshalSchemaJSON(property.schema)
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(name)
+		buffer.WriteString(":")
+		buffer.Write(value)
+	}
+	buffer.WriteString("}")
+	return buffer.Bytes(), nil
+}
+
+func (p schemaProperties) get(name string) *jsonSchema {
+	for _, property := range p {
+		if property.name == name {
+			return property.schema
+		}
+	}
+	return nil
+}
+
+// marshalSchemaJSON marshals without escaping html characters, as descriptions contain `<` and `>`.
+func marshalSchemaJSON(value interface{}) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buffer.Bytes(), "\n"), nil
+}
+
+// schemaGenerator builds the json schema by reflecting over the test suite types.
+type schemaGenerator struct {
+	definitions schemaProperties
+}
+
+// suiteDefinitions are the properties shared by the suite, test jobs and assertions.
+var suiteDefinitions = map[string]bool{
+	"values":             true,
+	"set":                true,
+	"templates":          true,
+	"excludeTemplates":   true,
+	"release":            true,
+	"chart":              true,
+	"capabilities":       true,
+	"kubernetesProvider": true,
+	"postRenderer":       true,
+	"skip":               true,
+	"documentIndex":      true,
+	"documentSelector":   true,
+}
+
+// validatorDefinitions are the properties shared by the validators.
+var validatorDefinitions = map[string]bool{
+	"path":    true,
+	"paths":   true,
+	"decode":  true,
+	"subPath": true,
+}
+
+// schemaConstraint constrains a property beyond its go type.
+type schemaConstraint struct {
+	// MinItems is the minimum length of an array.
+	MinItems int
+	// OneOfRequired accepts an object with exactly one of the sets of properties, an empty set accepts an empty object.
+	OneOfRequired [][]string
+}
+
+// schemaConstraints are the constraints of the properties which are not reflected from the go types.
+var schemaConstraints = map[string]schemaConstraint{
+	"kubernetesProvider.objects": {MinItems: 1},
+	"failedTemplate":             {OneOfRequired: [][]string{{"errorMessage"}, {"errorPattern"}, {}}},
+}
+
+// GenerateTestSuiteSchema generates the json schema of the test suite files,
+// reflecting over TestSuite, TestJob, Assertion and the registered assertion types.
+func GenerateTestSuiteSchema() ([]byte, error) {
+	root, err := testSuiteSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := marshalSchemaJSON(root)
+	if err != nil {
+		return nil, err
+	}
+	formatted := new(bytes.Buffer)
+	if err := json.Indent(formatted, content, "", "  "); err != nil {
+		return nil, err
+	}
+	formatted.WriteString("\n")
+	return formatted.Bytes(), nil
+}
+
+// testSuiteSchema returns the json schema of the test suite files, with the shared definitions.
+func testSuiteSchema() (*jsonSchema, error) {
+	generator := &schemaGenerator{}
+	root := generator.structSchema("TestSuite", reflect.TypeOf(TestSuite{}), suiteDefinitions)
+	root.Schema = jsonSchemaDraft
+	generator.describe(root, "TestSuite", "", "")
+
+	if err := generator.addAssertTypes(assertionSchema(root)); err != nil {
+		return nil, err
+	}
+	root.Definitions = generator.definitions
+	return root, nil
+}
+
+// assertionSchema returns the schema of an assertion of a test.
+func assertionSchema(root *jsonSchema) *jsonSchema {
+	return root.Properties.get("tests").Items.Properties.get("asserts").Items
+}
+
+// SchemaDocs returns the markdown documentation of the assertion types, with their parameters,
+// and of the keywords of the test suite files, built from the json schema so both document the same.
+func SchemaDocs() (map[string]string, map[string]string, error) {
+	root, err := testSuiteSchema()
+	if err != nil {
+		return nil, nil, err
+	}
+	asserts := assertionSchema(root)
+
+	assertionDocs := make(map[string]string, len(asserts.OneOf))
+	for _, assertType := range asserts.OneOf {
+		name := assertType.Required[0]
+		assertionDocs[name] = assertionMarkdown(root, name, assertType.Properties.get(name))
+	}
+
+	keywordDocs := map[string]string{}
+	scopes := []schemaProperties{root.Properties, root.Properties.get("tests").Items.Properties, asserts.Properties, root.Definitions}
+	for _, properties := range scopes {
+		for _, property := range properties {
+			if _, ok := keywordDocs[property.name]; ok || property.schema.MarkdownDescription == "" {
+				continue
+			}
+			keywordDocs[property.name] = property.schema.MarkdownDescription
+		}
+	}
+	return assertionDocs, keywordDocs, nil
+}
+
+// assertionMarkdown returns the description of the assertion type followed by the list of its parameters.
+func assertionMarkdown(root *jsonSchema, assertType string, validator *jsonSchema) string {
+	lines := []string{schemaDocs[assertType].Text, ""}
+	if validator.Type == "array" {
+		return strings.Join(append(lines, "- *array of assertion*"), "\n")
+	}
+
+	for _, property := range validator.Properties {
+		key := assertType + "." + property.name
+		schema := property.schema
+		if schema.Ref != "" {
+			key = property.name
+			schema = root.Definitions.get(strings.TrimPrefix(schema.Ref, "#/definitions/"))
+		}
+
+		typeName := schemaTypeName(schema)
+		if !slices.Contains(validator.Required, property.name) {
+			typeName += ", optional"
+		}
+		line := fmt.Sprintf("- **%s**: *%s*", property.name, typeName)
+		if text := schemaDocs[key].Text; text != "" {
+			// The paragraphs of the text are indented to stay in the item of the list
+			line += ". " + strings.ReplaceAll(text, "\n", "\n  ")
+		}
+		lines = append(lines, line)
+	}
+	if len(validator.Properties) == 0 {
+		lines = lines[:1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// assertionSchemaRef references the schema of an assertion of a test.
+const assertionSchemaRef = "#/properties/tests/items/properties/asserts/items"
+
+// addAssertTypes adds the assertion types as properties of the assertion,
+// and requires exactly one of them with the parameters of its validator.
+func (g *schemaGenerator) addAssertTypes(asserts *jsonSchema) error {
+	assertTypes := AssertTypes()
+	properties := make(schemaProperties, 0, len(assertTypes)+len(asserts.Properties))
+	for _, assertType := range assertTypes {
+		if _, ok := schemaDocs[assertType]; !ok {
+			return fmt.Errorf("assertion type '%s' is not documented in the schema", assertType)
+		}
+
+		properties = append(properties, schemaProperty{assertType, &jsonSchema{anything: true}})
+		var validator *jsonSchema
+		if _, ok := compositeAssertTypes[assertType]; ok {
+			// The nested assertions of composite assertions are assertions themselves
+			validator = &jsonSchema{Type: "array", Items: &jsonSchema{Ref: assertionSchemaRef}}
+		} else {
+			validator = g.structSchema(assertType, assertTypeMapping[assertType].validatorType, validatorDefinitions)
+		}
+		g.describe(validator, assertType, assertType, "")
+		constrain(validator, assertType)
+		asserts.OneOf = append(asserts.OneOf, &jsonSchema{
+			Properties: schemaProperties{{assertType, validator}},
+			Required:   []string{assertType},
+		})
+	}
+	asserts.Properties = append(properties, asserts.Properties...)
+	return nil
+}
+
+// structSchema reflects the exported fields of a struct as the properties of an object,
+// the properties in the given definitions are referenced instead.
+func (g *schemaGenerator) structSchema(scope string, structType reflect.Type, definitions map[string]bool) *jsonSchema {
+	schema := &jsonSchema{
+		Type:                 "object",
+		Properties:           schemaProperties{},
+		AdditionalProperties: false,
+	}
+	for idx := 0; idx < structType.NumField(); idx++ {
+		field := structType.Field(idx)
+		name := schemaFieldName(field)
+		if !field.IsExported() || name == "" {
+			continue
+		}
+
+		key := scope + "." + name
+		var property *jsonSchema
+		if definitions[name] {
+			property = &jsonSchema{Ref: g.definition(name, field.Type, definitions)}
+		} else {
+			property = g.typeSchema(key, field.Type, definitions)
+			g.document(property, key, name)
+		}
+		if schemaDocs[key].Level == levelRequired {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties = append(schema.Properties, schemaProperty{name, property})
+	}
+	return schema
+}
+
+// definition returns the reference to the shared definition, adding the definition when it is first used.
+func (g *schemaGenerator) definition(name string, definitionType reflect.Type, definitions map[string]bool) string {
+	if g.definitions.get(name) == nil {
+		definition := g.typeSchema(name, definitionType, definitions)
+		g.document(definition, name, name)
+		g.definitions = append(g.definitions, schemaProperty{name, definition})
+	}
+	return "#/definitions/" + name
+}
+
+// typeSchema returns the schema of a go type as it is decoded from yaml.
+func (g *schemaGenerator) typeSchema(scope string, valueType reflect.Type, definitions map[string]bool) *jsonSchema {
+	for valueType.Kind() == reflect.Ptr {
+		valueType = valueType.Elem()
+	}
+
+	var schema *jsonSchema
+	switch valueType.Kind() {
+	case reflect.String:
+		schema = &jsonSchema{Type: "string"}
+	case reflect.Bool:
+		schema = &jsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		schema = &jsonSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		schema = &jsonSchema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		schema = &jsonSchema{Type: "array", Items: g.typeSchema(scope, valueType.Elem(), definitions)}
+	case reflect.Map:
+		schema = &jsonSchema{Type: "object", AdditionalProperties: true}
+		if elem := g.typeSchema(scope, valueType.Elem(), definitions); elem.Type != nil {
+			schema.AdditionalProperties = elem
+		}
+	case reflect.Struct:
+		// Nested named types are documented by their type, anonymous types by their field.
+		if valueType.Name() != "" && strings.Contains(scope, ".") {
+			scope = valueType.Name()
+		}
+		schema = g.structSchema(scope, valueType, definitions)
+	default:
+		schema = &jsonSchema{}
+	}
+	return schema
+}
+
+// document applies the type override and the description of the documentation to the property.
+func (g *schemaGenerator) document(schema *jsonSchema, key, name string) {
+	if types := schemaDocs[key].Types; len(types) > 0 {
+		schema.Type = types
+	}
+	level := schemaDocs[key].Level
+	if level == "" {
+		level = levelOptional
+	}
+	g.describe(schema, key, name, level)
+	constrain(schema, key)
+}
+
+// constrain applies the constraints of the property to the schema.
+func constrain(schema *jsonSchema, key string) {
+	constraint, ok := schemaConstraints[key]
+	if !ok {
+		return
+	}
+
+	schema.MinItems = constraint.MinItems
+	for _, required := range constraint.OneOfRequired {
+		if len(required) == 0 {
+			empty := 0
+			schema.OneOf = append(schema.OneOf, &jsonSchema{MaxProperties: &empty})
+			continue
+		}
+		schema.OneOf = append(schema.OneOf, &jsonSchema{Required: required})
+	}
+}
+
+// describe adds the (markdown) description and examples of the documentation to the schema.
+func (g *schemaGenerator) describe(schema *jsonSchema, key, name, level string) {
+	doc, ok := schemaDocs[key]
+	if !ok || doc.Text == "" {
+		return
+	}
+
+	schema.Description = plainDescription(doc.Text)
+	if name != "" {
+		header := fmt.Sprintf("**%s** (%s)", name, schemaTypeName(schema))
+		if level != "" {
+			header += fmt.Sprintf(" _%s_", level)
+		}
+		schema.MarkdownDescription = header + "\n\n" + doc.Text
+	}
+	schema.Examples = doc.Examples
+}
+
+// schemaTypeName returns the human readable type of the schema, used in the markdown description.
+func schemaTypeName(schema *jsonSchema) string {
+	switch schemaType := schema.Type.(type) {
+	case string:
+		if schemaType == "array" && schema.Items != nil {
+			return "array<" + schemaTypeName(schema.Items) + ">"
+		}
+		return schemaType
+	case []string:
+		return strings.Join(schemaType, "|")
+	}
+	return "any"
+}
+
+var markdownPattern = regexp.MustCompile("`|\\*\\*|\\[([^]]*)\\]\\([^)]*\\)")
+
+// plainDescription strips the markdown of a description.
+func plainDescription(markdown string) string {
+	plain := markdownPattern.ReplaceAllString(markdown, "$1")
+	return strings.Join(strings.Fields(plain), " ")
+}
+
+// schemaFieldName returns the property name of a field, empty when the field is not decoded.
+// Fields without a yaml tag are named like mapstructure decodes them, in lower camel case.
+func schemaFieldName(field reflect.StructField) string {
+	if tag, ok := field.Tag.Lookup("yaml"); ok {
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+
+	runes := []rune(field.Name)
+	for idx := range runes {
+		// Keep the last upper case letter of an abbreviation, which starts the next word.
+		if idx > 0 && idx+1 < len(runes) && unicode.IsLower(runes[idx+1]) {
+			break
+		}
+		if !unicode.IsUpper(runes[idx]) {
+			break
+		}
+		runes[idx] = unicode.ToLower(runes[idx])
+	}
+	return string(runes)
+}
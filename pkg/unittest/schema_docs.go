@@ -0,0 +1,225 @@
+package unittest
+
+const (
+	levelRequired    = "required"
+	levelRecommended = "recommended"
+	levelOptional    = "optional"
+)
+
+// schemaDoc documents a property of the test suite json schema.
+type schemaDoc struct {
+	// Level is shown in the description, only required properties are enforced by the schema.
+	Level string
+	// Types overrides the types reflected from the go type.
+	Types []string
+	// Text is the markdown description, the plain description is derived from it.
+	Text     string
+	Examples []interface{}
+}
+
+// schemaDocs documents the test suite json schema.
+// Properties are keyed by their scope, which is the go type, assertion type or definition name.
+var schemaDocs = map[string]schemaDoc{
+	"TestSuite":                           {Text: "A helm test suite is a collection of tests with the same purpose and scope defined in one single file."},
+	"TestSuite.suite":                     {Text: "The suite name to show on test result output."},
+	"TestSuite.snapshotId":                {Text: "A suffix to your snapshot file for the tests. Ideal for helm tests."},
+	"TestSuite.snapshotKeys":              {Text: "How the snapshots of the tests are keyed, by the `order` of the snapshot assertions, default, or by the `identity` of the document, its template, kind, namespace and name. Named snapshots are always keyed by their name.", Examples: []interface{}{"identity"}},
+	"TestSuite.snapshotDir":               {Text: "The directory to store the snapshot file of the suite in, relative to the suite file. Overrides the `--snapshot-dir` option, default to `__snapshot__` next to the suite file.", Examples: []interface{}{"../snapshots"}},
+	"TestSuite.validateKubernetesObjects": {Text: "Set to `true` to assert the rendered documents of each test are valid Kubernetes objects, like the `isValidKubernetesObject` assertion. Tests asserting a failed rendering are not validated."},
+	"TestSuite.validateCustomResources":   {Text: "Set to `true` to assert the rendered custom resources of each test are valid against their CustomResourceDefinition, like the `isValidCustomResource` assertion. Tests asserting a failed rendering are not validated."},
+	"TestSuite.crdDirs":                   {Text: "The directories of CustomResourceDefinitions which are not part of the chart, relative to the suite file, used by `validateCustomResources`.", Examples: []interface{}{[]string{"crds"}}},
+	"TestSuite.tests":                     {Level: levelRequired, Text: "Where you define your test jobs to run."},
+	"TestJob.it":                          {Level: levelRecommended, Text: "Define the name of the test with TDD style or any message you like."},
+	"TestJob.template":                    {Text: "The template file(s) which render the manifest to be tested, default to the list of template file defined in templates of suite file, unless template is defined in the assertion(s)."},
+	"TestJob.asserts":                     {Level: levelRequired, Text: "The assertions to validate the rendered chart."},
+	"Assertion.not":                       {Text: "Set to `true` to assert contrarily, default to `false`."},
+	"Assertion.templated":                 {Text: "Set to `true` to render the string values of the assertion as Go templates with the [sprig](https://masterminds.github.io/sprig/) functions, with `.Release`, `.Chart`, `.Capabilities` and `.Values` of the rendering, like `{{ .Release.Name }}-web`. A value of a single field like `{{ .Values.replicaCount }}` keeps the type of the field, a value of another single action is read as yaml, pipe it to `quote` to expect a string."},
+	"Assertion.template":                  {Text: "The template file which render the manifest to be asserted, default to the list of template files defined in `templates` of the suite file, unless the template is in the testjob."},
+	"containsDocument":                    {Text: "Asserts the documents rendered by the `kind` and `apiVersion` specified."},
+	"containsDocument.kind":               {Level: levelRequired, Text: "Expected `kind` of manifest.", Examples: []interface{}{"Deployment"}},
+	"containsDocument.apiVersion":         {Level: levelRequired, Text: "Expected `apiVersion` of manifest.", Examples: []interface{}{"apps/v1"}},
+	"containsDocument.name":               {Text: "The value of the `metadata.name`.", Examples: []interface{}{"foo"}},
+	"containsDocument.namespace":          {Text: "The value of the `metadata.namespace`.", Examples: []interface{}{"bar"}},
+	"containsDocument.any":                {Text: "Ignores any other documents."},
+	"contains":                            {Text: "Assert the array as the value of specified path contains the content."},
+	"contains.path":                       {Level: levelRequired},
+	"contains.content":                    {Level: levelRequired, Text: "The content to be contained."},
+	"contains.count":                      {Text: "The count of content to be contained."},
+	"contains.any":                        {Text: "Ignores any other values within the found content."},
+	"notContains":                         {Text: "Assert the array as the value of specified path NOT contains the content."},
+	"notContains.path":                    {Level: levelRequired},
+	"notContains.content":                 {Level: levelRequired, Text: "The content NOT to be contained."},
+	"notContains.any":                     {Text: "Ignores any other values within the found content."},
+	"stringContains":                      {Text: "Assert the string value of specified path contains the content."},
+	"stringContains.path":                 {Level: levelRequired},
+	"stringContains.content":              {Level: levelRequired, Text: "The content to be contained, structured content is compared when `fromJson` or `fromYaml` is set."},
+	"stringContains.ignoreFormatting":     {Text: "Ignores spaces, tabs and line breaks in the comparison."},
+	"stringContains.fromJson":             {Text: "Treats the string value as `json` and asserts it contains the content."},
+	"stringContains.fromYaml":             {Text: "Treats the string value as `yaml` and asserts it contains the content."},
+	"equal":                               {Text: "Assert the value of the specified path is equal to the value."},
+	"equal.path":                          {Level: levelRequired},
+	"equal.value":                         {Level: levelRequired, Text: "The expected value."},
+	"equal.decodeBase64":                  {Text: "Decode the base64 before checking."},
+	"notEqual":                            {Text: "Assert the value of specified path NOT equal to the value."},
+	"notEqual.path":                       {Level: levelRequired},
+	"notEqual.value":                      {Level: levelRequired, Text: "The value expected not to be."},
+	"notEqual.decodeBase64":               {Text: "Decode the base64 before checking."},
+	"equalRaw":                            {Text: "Assert equal to the raw value."},
+	"equalRaw.value":                      {Level: levelRequired, Text: "Assert the expected value in a `NOTES.txt` file."},
+	"notEqualRaw":                         {Text: "Assert equal NOT to the value."},
+	"notEqualRaw.value":                   {Level: levelRequired, Text: "Assert the expected value in a `NOTES.txt` file not to be."},
+	"exists":                              {Text: "Assert if the specified path `exists`."},
+	"exists.path":                         {Level: levelRequired},
+	"notExists":                           {Text: "Assert if the specified path NOT `exists`."},
+	"notExists.path":                      {Level: levelRequired},
+	"isNull":                              {Text: "Assert if the specified path NOT `exists`. Deprecated, use `notExists` instead."},
+	"isNull.path":                         {Level: levelRequired},
+	"isNotNull":                           {Text: "Assert if the specified path `exists`. Deprecated, use `exists` instead."},
+	"isNotNull.path":                      {Level: levelRequired},
+	"failedTemplate":                      {Text: "Assert the value of `errorMessage` or `errorPattern` is the same as the human readable template error, or assert that a template failure occurs."},
+	"failedTemplate.errorMessage":         {Text: "The (human readable) `errorMessage` that should occur.", Examples: []interface{}{"Required value"}},
+	"failedTemplate.errorPattern":         {Text: "The regex pattern to match the error (without quoting `/`).", Examples: []interface{}{"Required Pattern"}},
+	"notFailedTemplate":                   {Text: "Assert that no failure occurs while templating."},
+	"greaterOrEqual":                      {Text: "Assert the value of specified path is greater or equal to the value."},
+	"greaterOrEqual.path":                 {Level: levelRequired},
+	"greaterOrEqual.value":                {Level: levelRequired, Text: "The expected value. Numbers of any type, resource quantities like `500m` or `1Gi` and durations like `30s` are compared by value, other strings lexically."},
+	"notGreaterOrEqual":                   {Text: "Assert the value of specified path is NOT greater or equal to the value."},
+	"notGreaterOrEqual.path":              {Level: levelRequired},
+	"notGreaterOrEqual.value":             {Level: levelRequired, Text: "The expected value. Numbers of any type, resource quantities like `500m` or `1Gi` and durations like `30s` are compared by value, other strings lexically."},
+	"greaterThan":                         {Text: "Assert the value of specified path is greater than the value."},
+	"greaterThan.path":                    {Level: levelRequired},
+	"greaterThan.value":                   {Level: levelRequired, Text: "The expected value. Numbers of any type, resource quantities like `500m` or `1Gi` and durations like `30s` are compared by value, other strings lexically."},
+	"notGreaterThan":                      {Text: "Assert the value of specified path is NOT greater than the value."},
+	"notGreaterThan.path":                 {Level: levelRequired},
+	"notGreaterThan.value":                {Level: levelRequired, Text: "The expected value. Numbers of any type, resource quantities like `500m` or `1Gi` and durations like `30s` are compared by value, other strings lexically."},
+	"hasDocuments":                        {Text: "Assert the documents count rendered by the `template` specified. The `documentIndex` or `documentSelector` option is by default ignored here."},
+	"hasDocuments.count":                  {Level: levelRequired, Text: "Expected count of documents rendered."},
+	"hasDocuments.filterAware":            {Text: "When true `documentIndex` or `documentSelector` is taken into account."},
+	"lessOrEqual":                         {Text: "Assert the value of specified path is less or equal to the value."},
+	"lessOrEqual.path":                    {Level: levelRequired},
+	"lessOrEqual.value":                   {Level: levelRequired, Text: "The expected value. Numbers of any type, resource quantities like `500m` or `1Gi` and durations like `30s` are compared by value, other strings lexically."},
+	"notLessOrEqual":                      {Text: "Assert the value of specified path is NOT less or equal to the value."},
+	"notLessOrEqual.path":                 {Level: levelRequired},
+	"notLessOrEqual.value":                {Level: levelRequired, Text: "The expected value. Numbers of any type, resource quantities like `500m` or `1Gi` and durations like `30s` are compared by value, other strings lexically."},
+	"lessThan":                            {Text: "Assert the value of specified path is less than the value."},
+	"lessThan.path":                       {Level: levelRequired},
+	"lessThan.value":                      {Level: levelRequired, Text: "The expected value. Numbers of any type, resource quantities like `500m` or `1Gi` and durations like `30s` are compared by value, other strings lexically."},
+	"notLessThan":                         {Text: "Assert the value of specified path is NOT less than the value."},
+	"notLessThan.path":                    {Level: levelRequired},
+	"notLessThan.value":                   {Level: levelRequired, Text: "The expected value. Numbers of any type, resource quantities like `500m` or `1Gi` and durations like `30s` are compared by value, other strings lexically."},
+	"isAPIVersion":                        {Text: "Assert the `apiVersion` value of manifest."},
+	"isAPIVersion.of":                     {Level: levelRequired, Text: "Expected `apiVersion` of manifest."},
+	"isValidKubernetesObject":             {Text: "Assert the documents strictly decode into the Kubernetes type of their `apiVersion` and `kind`, reporting unknown fields and type mismatches by path. Kinds which are not built into Kubernetes, like custom resources, are not validated."},
+	"isValidCustomResource":               {Text: "Assert the custom resources are valid against the `openAPIV3Schema` of their CustomResourceDefinition, from the `crds` directory of the chart or from `crdDirs`, reporting schema violations and unknown fields by path. Documents without a matching CustomResourceDefinition are not validated."},
+	"isValidCustomResource.crdDirs":       {Text: "The directories of CustomResourceDefinitions which are not part of the chart, like the definitions of third-party operators, relative to the suite file.", Examples: []interface{}{[]string{"crds"}}},
+	"satisfies":                           {Text: "Assert the documents satisfy the [CEL](https://cel.dev) expression, with the variables `self` for the document, and `release`, `values` and `capabilities` of the rendering. The failure shows the evaluated values of the fields and function calls of the expression."},
+	"satisfies.expression":                {Level: levelRequired, Text: "The CEL expression, which must evaluate to a bool.", Examples: []interface{}{"self.spec.replicas >= values.pdb.minAvailable"}},
+	"jq":                                  {Text: "Assert the outputs of the [jq](https://jqlang.github.io/jq/manual/) query over the documents equal `value`, or are truthy, not `false` nor `null`, when no `value` is given. Each output of a query with several outputs is asserted."},
+	"jq.query":                            {Level: levelRequired, Text: "The jq query, run over the document.", Examples: []interface{}{"[.spec.template.spec.containers[].name] | sort"}},
+	"jq.value":                            {Text: "The expected value of the outputs, which can be `null`, the outputs must be truthy when not given."},
+	"isSemver":                            {Text: "Assert the value of specified path is a strict semantic version, with an optional `v` prefix, so partial versions like `1.2` fail. The tag of image references, like `nginx:1.25.3`, is asserted."},
+	"isSemver.path":                       {Level: levelRequired},
+	"semverSatisfies":                     {Text: "Assert the semantic version of specified path satisfies the constraint. The version is parsed as strictly as by `isSemver`, so partial versions like `1.2` fail. The tag of image references, like `nginx:1.25.3`, is asserted. The failure explains which part of the constraint failed."},
+	"semverSatisfies.path":                {Level: levelRequired},
+	"semverSatisfies.constraint":          {Level: levelRequired, Text: "The [semver constraint](https://github.com/Masterminds/semver#checking-version-constraints) the version must satisfy.", Examples: []interface{}{">=1.2.0 <2.0.0", "~1.25"}},
+	"hasValidReferences":                  {Text: "Assert the references between the documents of the rendered release resolve, listing the dangling references: the `selector` of Services matches the pod template of a workload, the ConfigMaps, Secrets and ServiceAccounts of pods and role bindings, the backend Services and ports of Ingresses and the `scaleTargetRef` of HorizontalPodAutoscalers are rendered. Optional references and the `default` ServiceAccount are not checked."},
+	"hasValidReferences.checks":           {Text: "The references to check, default to all of them.", Examples: []interface{}{[]string{"serviceSelectors", "configMaps", "secrets", "ingressBackends", "scaleTargets", "serviceAccounts"}}},
+	"hasValidReferences.ignore":           {Text: "The referenced objects which are not rendered by the chart, like pre-existing Secrets, as `Kind/name`. A Service selecting pods not rendered by the chart, like those of a disabled subchart, is ignored as `Service/name`.", Examples: []interface{}{[]string{"Secret/registry-credentials"}}},
+	"image":                               {Text: "Assert the images of the containers, init containers and ephemeral containers of pods and workloads match the image policy. Each image is parsed into registry, repository, tag and digest, images without registry are of `docker.io`."},
+	"image.containers":                    {Text: "The names of the containers to assert, default to all of them.", Examples: []interface{}{[]string{"app"}}},
+	"image.registries":                    {Text: "The allowed registries.", Examples: []interface{}{[]string{"ghcr.io", "docker.io"}}},
+	"image.repository":                    {Text: "The expected repository, without registry. The official images of Docker Hub match with or without `library/`, like `nginx`.", Examples: []interface{}{"bitnami/nginx"}},
+	"image.tag":                           {Text: "The expected tag."},
+	"image.tagFromValues":                 {Text: "The `set` path of the expected tag in the values.", Examples: []interface{}{"image.tag"}},
+	"image.noLatest":                      {Text: "Forbid the `latest` tag, images without tag and digest are `latest`."},
+	"image.requireDigest":                 {Text: "Require the images to be pinned by digest."},
+	"anyOf":                               {Text: "Assert at least one of the nested assertions passes. Each nested assertion keeps its own `template`, `documentIndex` and `documentSelector`, default to the ones of the composite assertion. The failure lists which nested assertions passed."},
+	"allOf":                               {Text: "Assert all of the nested assertions pass, like asserting them in the test, grouped to be combined in `anyOf` or `noneOf`. The failure lists which nested assertions passed."},
+	"noneOf":                              {Text: "Assert none of the nested assertions passes. The failure lists which nested assertions passed."},
+	"isKind":                              {Text: "Assert the `kind` value of manifest."},
+	"isKind.of":                           {Level: levelRequired, Text: "Expected `kind` of manifest."},
+	"isNullOrEmpty":                       {Text: "Assert the value of specified path is null or empty (`null`, `\"\"`, `0`, `[]`, `{}`)."},
+	"isNullOrEmpty.path":                  {Level: levelRequired},
+	"isNotNullOrEmpty":                    {Text: "Assert the value of specified path is NOT null or empty (`null`, `\"\"`, `0`, `[]`, `{}`)."},
+	"isNotNullOrEmpty.path":               {Level: levelRequired},
+	"isSubset":                            {Text: "Assert the object as the value of specified path that contains the content."},
+	"isSubset.path":                       {Level: levelRequired},
+	"isSubset.content":                    {Level: levelRequired, Text: "The content to be contained."},
+	"isNotSubset":                         {Text: "Assert the object as the value of specified path that NOT contains the content."},
+	"isNotSubset.path":                    {Level: levelRequired},
+	"isNotSubset.content":                 {Level: levelRequired, Text: "The content NOT to be contained."},
+	"isType":                              {Text: "Assert the value of specified path is the type."},
+	"isType.path":                         {Level: levelRequired},
+	"isType.type":                         {Level: levelRequired, Text: "The expected type."},
+	"isNotType":                           {Text: "Assert the value of specified path is NOT the type."},
+	"isNotType.path":                      {Level: levelRequired},
+	"isNotType.type":                      {Level: levelRequired, Text: "The expected type."},
+	"isNotEmpty":                          {Text: "Assert the value of specified path is NOT empty."},
+	"isNotEmpty.path":                     {Level: levelRequired},
+	"isEmpty":                             {Text: "Assert the value of specified path is empty."},
+	"isEmpty.path":                        {Level: levelRequired},
+	"lengthEqual":                         {Text: "Assert the spec count rendered by the `path|paths` specified."},
+	"lengthEqual.count":                   {Text: "Expected count of spec rendered."},
+	"notLengthEqual":                      {Text: "Assert the spec count rendered by the `path|paths` specified NOT to be equal."},
+	"notLengthEqual.count":                {Text: "Expected count of spec rendered."},
+	"matchRegex":                          {Text: "Assert the value of specified path match pattern."},
+	"matchRegex.path":                     {Level: levelRequired},
+	"matchRegex.pattern":                  {Level: levelRequired, Text: "The regex pattern to match (without quoting `/`).", Examples: []interface{}{"-my-chart$"}},
+	"matchRegex.decodeBase64":             {Text: "Decode the base64 before checking."},
+	"notMatchRegex":                       {Text: "Assert the value of specified path NOT match pattern."},
+	"notMatchRegex.path":                  {Level: levelRequired},
+	"notMatchRegex.pattern":               {Level: levelRequired, Text: "The regex pattern NOT to match (without quoting `/`).", Examples: []interface{}{"-my-chart$"}},
+	"notMatchRegex.decodeBase64":          {Text: "Decode the base64 before checking."},
+	"matchJsonSchema":                     {Text: "Assert the value of `path`, or the whole document when `path` is empty, is valid against the JSON schema given by `schema` or `schemaFile`. Each violation is listed with its JSON pointer."},
+	"matchJsonSchema.schema":              {Text: "The inline JSON schema to validate against.", Examples: []interface{}{map[string]interface{}{"type": "object", "required": []string{"app"}}}},
+	"matchJsonSchema.schemaFile":          {Text: "The file of the JSON schema to validate against, relative to the test suite. The schema can be written in `json` or `yaml`.", Examples: []interface{}{"schemas/config.json"}},
+	"matchJsonSchema.fromJson":            {Text: "Decodes the string value as `json` before validating, like a json payload of a ConfigMap."},
+	"matchJsonSchema.fromYaml":            {Text: "Decodes the string value as `yaml` before validating, like a yaml payload of a ConfigMap."},
+	"matchRegexRaw":                       {Text: "Assert the value match pattern."},
+	"matchRegexRaw.pattern":               {Level: levelRequired, Text: "The regex pattern to match (without quoting `/`) in a `NOTES.txt` file.", Examples: []interface{}{"-my-notes$"}},
+	"notMatchRegexRaw":                    {Text: "Assert the value NOT match pattern."},
+	"notMatchRegexRaw.pattern":            {Level: levelRequired, Text: "The regex pattern NOT to match (without quoting `/`) in a `NOTES.txt` file.", Examples: []interface{}{"-my-notes$"}},
+	"matchInlineSnapshot":                 {Text: "Assert the value of `path` is the same as the `snapshot` written in the suite file. A missing `snapshot` is written to the suite file, a changed one only with `--update-snapshot`, and none in CI mode. It asserts a single template, select one with `template`."},
+	"matchInlineSnapshot.snapshot":        {Text: "The expected value of `path`, written to the suite file by the tool.", Examples: []interface{}{map[string]interface{}{"app": "web"}}},
+	"matchReleaseSnapshot":                {Text: "Assert the whole rendered release of the test is the same as snapshotted last time. The resources are ordered the way Helm installs them, the hooks are listed separately and the NOTES.txt of the chart is included. The `template`, `documentIndex` and `documentSelector` of the assertion are ignored."},
+	"matchReleaseSnapshot.name":           {Text: "The name of the snapshot in the test, to key the snapshot by name instead of by order.", Examples: []interface{}{"release"}},
+	"matchSnapshot":                       {Text: "Assert the value of `path` is the same as snapshotted last time."},
+	"matchSnapshot.name":                  {Text: "The name of the snapshot in the test, to key the snapshot by name instead of by order.", Examples: []interface{}{"labels"}},
+	"matchSnapshot.ignorePaths":           {Text: "The paths of the document to mask with `<ignored>` before comparing to the snapshot, like checksum annotations or generated values. The masked values are stored in the snapshot as `<ignored>`.", Examples: []interface{}{[]string{"metadata.annotations.checksum/config"}}},
+	"matchSnapshotRaw":                    {Text: "Assert the value in the NOTES.txt is the same as snapshotted last time."},
+	"matchSnapshotRaw.name":               {Text: "The name of the snapshot in the test, to key the snapshot by name instead of by order.", Examples: []interface{}{"notes"}},
+	"paths":                               {Text: "The paths to assert.\n\nMap keys in path containing periods (.) are supported with the use of a jq-like syntax."},
+	"path":                                {Text: "The path to assert.\n\nMap keys in path containing periods (.) are supported with the use of a jq-like syntax."},
+	"decode":                              {Types: []string{"string", "array"}, Text: "Decode the value of `path` before asserting, by a format or a list of formats decoded in order, like `[base64, json]` for a Secret holding a JSON document. The formats are `base64`, `json`, `yaml`, `toml`, `properties` and `ini`.", Examples: []interface{}{"base64", []string{"base64", "json"}}},
+	"subPath":                             {Text: "The path to assert in the decoded value of `path`, like the key of a decoded `properties` or the `section.key` of a decoded `ini`.\n\nMap keys in path containing periods (.) are supported with the use of a jq-like syntax.", Examples: []interface{}{"database.host"}},
+	"capabilities":                        {Text: "Define the `{{ .Capabilities }}` object."},
+	"capabilities.majorVersion":           {Types: []string{"integer", "string"}, Text: "The kubernetes major version, default to the major version which is set by helm."},
+	"capabilities.minorVersion":           {Types: []string{"integer", "string"}, Text: "The kubernetes minor version, default to the minor version which is set by helm."},
+	"capabilities.apiVersions":            {Types: []string{"array", "null"}, Text: "A set of versions, default to the versionset used by the defined kubernetes version."},
+	"chart":                               {Text: "Define the `{{ .Chart }}` object."},
+	"chart.version":                       {Text: "The semantic version of the chart, default to the version set in the Chart."},
+	"chart.appVersion":                    {Text: "The app-version of the chart, default to the app-version set in the Chart."},
+	"documentIndex":                       {Text: "The index of rendered documents (divided by ---) to be tested, default to -1, which results in asserting all documents (see Assertion).\n\nGenerally you can ignored this field if the template file render only one document."},
+	"documentSelector":                    {Text: "The path of the key to be match and the match value to assert.\n\nUsing this information, helm-unittest will automatically discover the documentIndex.\n\nGenerally you can ignored this field if the template file render only one document."},
+	"documentSelector.path":               {Level: levelRequired, Text: "The `documentSelector` path to assert."},
+	"documentSelector.value":              {Level: levelRequired, Text: "The expected value."},
+	"documentSelector.matchMany":          {Text: "Set to **true** to allow matching multiple documents. Defaults to **false** which means selector has to match single document across all templates."},
+	"documentSelector.skipEmptyTemplates": {Text: "Set to **true** to skip asserting templates which didn't render any matching documents. Defaults to **false** which means selector have to find at least one document in every template."},
+	"skip":                                {Text: "Using this flag, helm-unittest will automatically skip the 'suite' or 'test'."},
+	"skip.reason":                         {Text: "The reason to skip the `suite` or 'test'."},
+	"postRenderer":                        {Text: "A helm [post-renderer](https://helm.sh/docs/topics/advanced/#post-rendering) to apply after chart rendering but before validation."},
+	"postRenderer.cmd":                    {Text: "The full path to the command to invoke, or just its name if it's on '$PATH'."},
+	"postRenderer.args":                   {Text: "A list of command-line arguments to pass to the `cmd`."},
+	"kubernetesProvider":                  {Text: "Define Kubernetes resources to fake."},
+	"kubernetesProvider.scheme":           {Text: "Define the Kubernetes schema to fake."},
+	"kubernetesProvider.objects":          {Level: levelRequired, Text: "Define the Kubernetes objects to fake."},
+	"release":                             {Text: "Define the `{{ .Release }}` object."},
+	"release.name":                        {Text: "The release name, default to `\"RELEASE-NAME\"`."},
+	"release.namespace":                   {Text: "The namespace which release be installed to, default to `\"NAMESPACE\"`."},
+	"release.revision":                    {Text: "The revision of current build, default to `0`."},
+	"release.upgrade":                     {Text: "Whether the build is an upgrade, default to `false`."},
+	"templates":                           {Level: levelRecommended, Text: "The template files scope to test in this suite. The full chart will be rendered, however only the listed templates are filtered for validation.\n\n Template files that are put in a templates sub-folder can be addressed with a linux path separator. Also the `templates/` can be omitted.\n\nPartial templates (which are prefixed with and _) are added automatically even if it is in a templates sub-folder, you don't need to add them again."},
+	"excludeTemplates":                    {Text: "The template files which should be excluded from the scope of this test suite. Using wildcards it is possible to exclude multiple templates without listing them one-by-one."},
+	"set":                                 {Text: "Set the values directly in the suite file. The key is the value path with the format just like `--set` option of `helm install`, for example `image.pullPolicy`.\n\nThe value is anything you want to set to the path specified by the key, which can be even an array or an object."},
+	"values":                              {Level: levelRecommended, Text: "The test values to apply for rendering of this chart."},
+}
@@ -0,0 +1,142 @@
+package unittest_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest"
+	"github.com/stretchr/testify/assert"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+func TestGenerateTestSuiteSchemaMatchesCommittedSchema(t *testing.T) {
+	a := assert.New(t)
+
+	generated, err := GenerateTestSuiteSchema()
+	a.NoError(err)
+
+	committed, err := os.ReadFile(testSuiteSchemaLocal)
+	a.NoError(err)
+
+	a.Equal(string(committed), string(generated),
+		"schema/helm-testsuite.json is outdated, regenerate it with `go generate ./pkg/unittest`")
+}
+
+func TestGenerateTestSuiteSchemaContainsAllAssertTypes(t *testing.T) {
+	a := assert.New(t)
+
+	generated, err := GenerateTestSuiteSchema()
+	a.NoError(err)
+
+	schema := struct {
+		Properties struct {
+			Tests struct {
+				Items struct {
+					Properties struct {
+						Asserts struct {
+							Items struct {
+								Properties map[string]interface{}
+								OneOf      []struct {
+									Required []string
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}{}
+	a.NoError(json.Unmarshal(generated, &schema))
+
+	asserts := schema.Properties.Tests.Items.Properties.Asserts.Items
+	a.Len(asserts.OneOf, len(AssertTypes()))
+	for idx, assertType := range AssertTypes() {
+		a.Equal(true, asserts.Properties[assertType])
+		a.Equal([]string{assertType}, asserts.OneOf[idx].Required)
+	}
+	a.Contains(asserts.Properties, "not")
+	a.Contains(asserts.Properties, "documentSelector")
+}
+
+const constrainedSuite = `suite: constraints
+tests:
+  - it: should validate
+    asserts:
+      - %s
+`
+
+func TestGenerateTestSuiteSchemaKeepsConstraints(t *testing.T) {
+	generated, err := GenerateTestSuiteSchema()
+	assert.NoError(t, err)
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(generated))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		suite         string
+		expectedValid bool
+	}{
+		{
+			name:          "failedTemplate with an errorMessage",
+			suite:         fmt.Sprintf(constrainedSuite, "failedTemplate: {errorMessage: Required value}"),
+			expectedValid: true,
+		},
+		{
+			name:          "failedTemplate with an errorPattern",
+			suite:         fmt.Sprintf(constrainedSuite, "failedTemplate: {errorPattern: Required}"),
+			expectedValid: true,
+		},
+		{
+			name:          "failedTemplate without error",
+			suite:         fmt.Sprintf(constrainedSuite, "failedTemplate: {}"),
+			expectedValid: true,
+		},
+		{
+			name:          "failedTemplate with an errorMessage and an errorPattern",
+			suite:         fmt.Sprintf(constrainedSuite, "failedTemplate: {errorMessage: Required value, errorPattern: Required}"),
+			expectedValid: false,
+		},
+		{
+			name:          "kubernetesProvider with objects",
+			suite:         "kubernetesProvider:\n  objects:\n    - kind: Pod\n" + fmt.Sprintf(constrainedSuite, "hasDocuments: {count: 1}"),
+			expectedValid: true,
+		},
+		{
+			name:          "kubernetesProvider without objects",
+			suite:         "kubernetesProvider:\n  objects: []\n" + fmt.Sprintf(constrainedSuite, "hasDocuments: {count: 1}"),
+			expectedValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var suite interface{}
+			assert.NoError(t, common.YmlUnmarshal(tt.suite, &suite))
+
+			result, err := schema.Validate(gojsonschema.NewGoLoader(suite))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedValid, result.Valid(), result.Errors())
+		})
+	}
+}
+
+func TestSchemaDocsDocumentAssertTypesAndKeywords(t *testing.T) {
+	a := assert.New(t)
+
+	assertionDocs, keywordDocs, err := SchemaDocs()
+	a.NoError(err)
+
+	a.Len(assertionDocs, len(AssertTypes()))
+	a.Contains(assertionDocs["equal"], "- **path**: *string*. ")
+	a.Contains(assertionDocs["equal"], "- **decode**: *string|array, optional*. ")
+	a.Contains(assertionDocs["equal"], "- **subPath**: *string, optional*. ")
+	a.Contains(assertionDocs["matchSnapshot"], "- **name**: *string, optional*. ")
+	a.Contains(assertionDocs["matchSnapshot"], "- **ignorePaths**: *array<string>, optional*. ")
+	a.Contains(assertionDocs["anyOf"], "- *array of assertion*")
+	a.Equal("**not** (boolean) _optional_\n\nSet to `true` to assert contrarily, default to `false`.", keywordDocs["not"])
+	a.Contains(keywordDocs, "templated")
+	a.Contains(keywordDocs, "suite")
+}
@@ -3,98 +3,211 @@ package snapshot
 import (
 	"bytes"
 	"os"
+	"path/filepath"
+	"strconv"
 
 	"github.com/helm-unittest/helm-unittest/internal/common"
 	yaml "sigs.k8s.io/yaml"
 )
 
-// CompareResult result return by Cache.Compare
+// CompareResult result return by Cache.Compare and Cache.CompareNamed
 type CompareResult struct {
-	Passed         bool
-	Test           string
-	Index          uint
+	Passed bool
+	Test   string
+	// Index of the snapshot in the test, which is 0 for named snapshots
+	Index uint
+	// Key of the snapshot in the test, the Index for ordered snapshots or the name of named snapshots
+	Key string
+	// Missing is set when no snapshot was cached and the Cache is ReadOnly, the compare fails
+	Missing        bool
 	NewSnapshot    string
 	CachedSnapshot string
 }
 
+// UpdateFilter selects the snapshots to update by test name and snapshot key, when the Cache IsUpdating
+type UpdateFilter func(test, key string) bool
+
 // Cache manage snapshot caching
 type Cache struct {
-	Filepath      string
-	Existed       bool
-	IsUpdating    bool
-	cached        map[string]map[uint]string
-	current       map[string]map[uint]string
+	Filepath   string
+	Existed    bool
+	IsUpdating bool
+	// UpdateFilter limits the snapshots updated when IsUpdating, all snapshots are updated when nil
+	UpdateFilter UpdateFilter
+	// KeepVanished keeps the cached snapshots not compared current time when storing, instead of removing them
+	KeepVanished bool
+	// ReadOnly never creates or stores snapshots, comparing to a snapshot not cached fails
+	ReadOnly bool
+	// PerTest stores the snapshots of each test in a file of its own, Filepath is the directory of the files
+	PerTest       bool
+	cached        map[string]map[string]string
+	current       map[string]map[string]string
 	updatedCount  uint
+	acceptedCount uint
 	insertedCount uint
 	currentCount  uint
+	missingCount  uint
+	// migrated are the ordered snapshots moved to named snapshots current time
+	migrated map[string]map[string]bool
 }
 
-// RestoreFromFile restore cached snapshot from cache file
+// RestoreFromFile restore cached snapshot from cache file, or from the cache files of the tests when PerTest
 func (s *Cache) RestoreFromFile() error {
-	content, err := os.ReadFile(s.Filepath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil
+	files := []string{s.Filepath}
+	if s.PerTest {
+		var err error
+		if files, err = filepath.Glob(filepath.Join(s.Filepath, "*"+snapshotFileExt)); err != nil {
+			return err
 		}
-		return err
 	}
 
-	if err := yaml.Unmarshal(content, &s.cached); err != nil {
-		return err
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+
+		// The keys of the ordered snapshots are read as strings, to be combined with named snapshots
+		var cached map[string]map[string]string
+		if err := yaml.Unmarshal(content, &cached); err != nil {
+			return err
+		}
+		if s.cached == nil {
+			s.cached = make(map[string]map[string]string, len(cached))
+		}
+		for test, cachedOfTest := range cached {
+			s.cached[test] = cachedOfTest
+		}
+		s.Existed = true
 	}
-	s.Existed = true
 	return nil
 }
 
-func (s *Cache) getCached(test string, idx uint) (string, bool) {
+// FileOf returns the cache file storing the snapshots of the test
+func (s *Cache) FileOf(test string) string {
+	if s.PerTest {
+		return filepath.Join(s.Filepath, testFileName(test))
+	}
+	return s.Filepath
+}
+
+func (s *Cache) getCached(test, key string) (string, bool) {
 	if cachedByTest, ok := s.cached[test]; ok {
-		if cachedOfAssertion, ok := cachedByTest[idx]; ok {
+		if cachedOfAssertion, ok := cachedByTest[key]; ok {
 			return cachedOfAssertion, true
 		}
 	}
 	return "", false
 }
 
-// Compare compare content to cached last time, return CompareResult
+// Compare compare content to cached last time by the index of the snapshot in the test, return CompareResult
 func (s *Cache) Compare(test string, idx uint, content interface{}) *CompareResult {
+	result := s.compare(test, strconv.FormatUint(uint64(idx), 10), content)
+	result.Index = idx
+	return result
+}
+
+// CompareNamed compare content to cached last time by the name of the snapshot in the test, return CompareResult.
+// When no snapshot with the name was cached, the ordered snapshot at idx is migrated to the name if it was cached.
+func (s *Cache) CompareNamed(test, name string, idx uint, content interface{}) *CompareResult {
+	if _, exsisted := s.getCached(test, name); !exsisted && idx > 0 {
+		orderedKey := strconv.FormatUint(uint64(idx), 10)
+		if cached, exsisted := s.getCached(test, orderedKey); exsisted && !s.isMigrated(test, orderedKey) {
+			s.setMigrated(test, orderedKey)
+			return s.compareCached(test, name, cached, true, content)
+		}
+	}
+	return s.compare(test, name, content)
+}
+
+func (s *Cache) compare(test, key string, content interface{}) *CompareResult {
+	cached, exsisted := s.getCached(test, key)
+	return s.compareCached(test, key, cached, exsisted, content)
+}
+
+func (s *Cache) compareCached(test, key, cached string, exsisted bool, content interface{}) *CompareResult {
 	s.currentCount++
-	cached, exsisted := s.getCached(test, idx)
+	if !exsisted && s.ReadOnly {
+		s.missingCount++
+		return &CompareResult{
+			Passed:      false,
+			Test:        test,
+			Key:         key,
+			Missing:     true,
+			NewSnapshot: common.TrustedMarshalYAML(content),
+		}
+	}
 	if !exsisted {
 		s.insertedCount++
 	}
 
 	match := true
+	updating := s.shouldUpdate(test, key)
 	newSnapshot := common.TrustedMarshalYAML(content)
 	if exsisted && newSnapshot != cached {
 		match = false
 		s.updatedCount++
+		if updating {
+			s.acceptedCount++
+		}
 	}
 
 	var snapshotToSave string
-	if s.IsUpdating || !exsisted {
+	if updating || !exsisted {
 		snapshotToSave = newSnapshot
 	} else {
 		snapshotToSave = cached
 	}
 
-	s.setNewSnapshot(test, idx, snapshotToSave)
+	s.setNewSnapshot(test, key, snapshotToSave)
 	return &CompareResult{
-		Passed:         s.IsUpdating || match,
+		Passed:         updating || match,
 		Test:           test,
-		Index:          idx,
+		Key:            key,
 		CachedSnapshot: cached,
 		NewSnapshot:    newSnapshot,
 	}
 }
 
-func (s *Cache) setNewSnapshot(test string, idx uint, snapshot string) {
+// shouldUpdate check if the snapshot of the test is updated, which never happens when ReadOnly
+func (s *Cache) shouldUpdate(test, key string) bool {
+	return !s.ReadOnly && s.IsUpdating && (s.UpdateFilter == nil || s.UpdateFilter(test, key))
+}
+
+func (s *Cache) isMigrated(test, key string) bool {
+	return s.migrated[test][key]
+}
+
+func (s *Cache) setMigrated(test, key string) {
+	if s.migrated == nil {
+		s.migrated = make(map[string]map[string]bool)
+	}
+	if _, ok := s.migrated[test]; !ok {
+		s.migrated[test] = make(map[string]bool)
+	}
+	s.migrated[test][key] = true
+}
+
+// MigratedCount return snapshot count that was cached by order last time and moved to a named snapshot current time
+func (s *Cache) MigratedCount() uint {
+	var count uint
+	for _, migratedOfTest := range s.migrated {
+		count += uint(len(migratedOfTest))
+	}
+	return count
+}
+
+func (s *Cache) setNewSnapshot(test, key string, snapshot string) {
 	if s.current == nil {
-		s.current = make(map[string]map[uint]string)
+		s.current = make(map[string]map[string]string)
 	}
 	if newCacheOfTest, ok := s.current[test]; ok {
-		newCacheOfTest[idx] = snapshot
+		newCacheOfTest[key] = snapshot
 	} else {
-		s.current[test] = map[uint]string{idx: snapshot}
+		s.current[test] = map[string]string{key: snapshot}
 	}
 }
 
@@ -108,8 +221,8 @@ func (s *Cache) Changed() bool {
 		if _, ok := s.current[test]; !ok {
 			return true
 		}
-		for idx := range cachedFiles {
-			if _, ok := s.current[test][idx]; !ok {
+		for key := range cachedFiles {
+			if _, ok := s.current[test][key]; !ok {
 				return true
 			}
 		}
@@ -117,24 +230,16 @@ func (s *Cache) Changed() bool {
 	return false
 }
 
-// StoreToFileIfNeeded store current cache to file if snapshot content changed
+// StoreToFileIfNeeded store current cache to file if snapshot content changed, never when ReadOnly
 func (s *Cache) StoreToFileIfNeeded() (bool, error) {
-	if !s.Changed() {
+	if s.ReadOnly || !s.Changed() {
 		return false, nil
 	}
 
-	if s.IsUpdating || s.insertedCount > 0 || s.VanishedCount() > 0 {
-		byteBuffer := new(bytes.Buffer)
-		yamlEncoder := common.YamlNewEncoder(byteBuffer)
-		yamlEncoder.SetIndent(common.YAMLINDENTION)
-		if err := yamlEncoder.Encode(s.current); err != nil {
+	if s.acceptedCount > 0 || s.insertedCount > 0 || s.MigratedCount() > 0 || (!s.KeepVanished && s.VanishedCount() > 0) {
+		if err := s.storeToFiles(s.snapshotsToStore()); err != nil {
 			return false, err
 		}
-
-		if err := os.WriteFile(s.Filepath, byteBuffer.Bytes(), 0644); err != nil {
-			return false, err
-		}
-
 		s.Existed = true
 		return true, nil
 	}
@@ -142,6 +247,88 @@ func (s *Cache) StoreToFileIfNeeded() (bool, error) {
 	return false, nil
 }
 
+// storeToFiles store the snapshots to the cache file, or to the cache files of the tests when PerTest,
+// removing the cache files of the tests without snapshots
+func (s *Cache) storeToFiles(snapshots map[string]map[interface{}]string) error {
+	snapshotsByFile := make(map[string]map[string]map[interface{}]string)
+	for test, snapshotsOfTest := range snapshots {
+		file := s.FileOf(test)
+		if _, ok := snapshotsByFile[file]; !ok {
+			snapshotsByFile[file] = make(map[string]map[interface{}]string)
+		}
+		snapshotsByFile[file][test] = snapshotsOfTest
+	}
+
+	for file, snapshotsOfFile := range snapshotsByFile {
+		if err := writeSnapshots(file, snapshotsOfFile); err != nil {
+			return err
+		}
+	}
+
+	if s.PerTest {
+		for test := range s.cached {
+			if _, ok := snapshotsByFile[s.FileOf(test)]; ok {
+				continue
+			}
+			if err := os.Remove(s.FileOf(test)); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeSnapshots(file string, snapshots map[string]map[interface{}]string) error {
+	byteBuffer := new(bytes.Buffer)
+	yamlEncoder := common.YamlNewEncoder(byteBuffer)
+	yamlEncoder.SetIndent(common.YAMLINDENTION)
+	if err := yamlEncoder.Encode(snapshots); err != nil {
+		return err
+	}
+
+	if err := ensureDir(filepath.Dir(file)); err != nil {
+		return err
+	}
+	return os.WriteFile(file, byteBuffer.Bytes(), 0644)
+}
+
+// snapshotsToStore returns the current snapshots, with the vanished snapshots when KeepVanished.
+// The keys of ordered snapshots are stored as numbers, sorted before the names of named snapshots.
+func (s *Cache) snapshotsToStore() map[string]map[interface{}]string {
+	snapshots := make(map[string]map[interface{}]string, len(s.current))
+	add := func(test, key, snapshot string) {
+		if _, ok := snapshots[test]; !ok {
+			snapshots[test] = make(map[interface{}]string)
+		}
+		snapshots[test][storedKey(key)] = snapshot
+	}
+
+	if s.KeepVanished {
+		for test, cachedFiles := range s.cached {
+			for key, cached := range cachedFiles {
+				if s.isMigrated(test, key) {
+					continue
+				}
+				add(test, key, cached)
+			}
+		}
+	}
+	for test, currentFiles := range s.current {
+		for key, current := range currentFiles {
+			add(test, key, current)
+		}
+	}
+	return snapshots
+}
+
+// storedKey returns the key of an ordered snapshot as number and the key of a named snapshot as is
+func storedKey(key string) interface{} {
+	if idx, err := strconv.ParseUint(key, 10, 0); err == nil && strconv.FormatUint(idx, 10) == key {
+		return uint(idx)
+	}
+	return key
+}
+
 // UpdatedCount return snapshot count that was cached before and updated current time
 func (s *Cache) UpdatedCount() uint {
 	return s.updatedCount
@@ -157,21 +344,31 @@ func (s *Cache) CurrentCount() uint {
 	return s.currentCount
 }
 
+// AcceptedCount return snapshot count that was cached before and updated with the new content current time
+func (s *Cache) AcceptedCount() uint {
+	return s.acceptedCount
+}
+
 // FailedCount return snapshot count that was failed when Compare
 func (s *Cache) FailedCount() uint {
-	if s.IsUpdating {
-		return 0
-	}
-	return s.updatedCount
+	return s.updatedCount - s.acceptedCount + s.missingCount
+}
+
+// MissingCount return snapshot count that was not cached and not created current time, because the Cache is ReadOnly
+func (s *Cache) MissingCount() uint {
+	return s.missingCount
 }
 
 // VanishedCount return snapshot count that was cached last time but not exists this time
 func (s *Cache) VanishedCount() uint {
 	var count uint
 	for test, cachedFiles := range s.cached {
-		for idx := range cachedFiles {
+		for key := range cachedFiles {
+			if s.isMigrated(test, key) {
+				continue
+			}
 			if newTestCache, ok := s.current[test]; ok {
-				if _, ok := newTestCache[idx]; ok {
+				if _, ok := newTestCache[key]; ok {
 					continue
 				}
 			}
@@ -3,6 +3,7 @@ package snapshot_test
 import (
 	"os"
 	"path/filepath"
+	"strconv"
 	"testing"
 
 	. "github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
@@ -58,6 +59,7 @@ func createCacheResult(index uint, passed bool, cachedSnapshot, newSnapshot stri
 	return &CompareResult{
 		Test:           cache_before,
 		Index:          index,
+		Key:            strconv.FormatUint(uint64(index), 10),
 		Passed:         passed,
 		CachedSnapshot: cachedSnapshot,
 		NewSnapshot:    newSnapshot,
@@ -206,6 +208,61 @@ func TestCacheWhenChangedIfIsUpdating(t *testing.T) {
 `, string(bytes))
 }
 
+func TestCacheWhenChangedIfIsUpdatingFiltered(t *testing.T) {
+	a := assert.New(t)
+	cache := createCache(a, true)
+	cache.IsUpdating = true
+	cache.UpdateFilter = func(test, key string) bool {
+		return test == cache_before && key == "2"
+	}
+	err := cache.RestoreFromFile()
+	a.Nil(err)
+
+	result1 := cache.Compare(cache_before, 1, contentNew)
+	a.Equal(createCacheResult(1, false, snapshot1, snapshotNew), result1)
+	verifyCache(a, cache, true, true, 1, 0, 1, 1, 1)
+
+	result2 := cache.Compare(cache_before, 2, contentNew)
+	a.Equal(createCacheResult(2, true, snapshot2, snapshotNew), result2)
+	verifyCache(a, cache, true, true, 2, 0, 2, 1, 0)
+	a.Equal(uint(1), cache.AcceptedCount())
+
+	stored, storeErr := cache.StoreToFileIfNeeded()
+	a.True(stored)
+	a.Nil(storeErr)
+
+	bytes, _ := os.ReadFile(cache.Filepath)
+	a.Equal(`cached before:
+  1: |
+    a:
+      b: c
+  2: |
+    x:
+      "y": z
+`, string(bytes))
+}
+
+func TestCacheWhenChangedIfIsUpdatingFilteredOut(t *testing.T) {
+	a := assert.New(t)
+	cache := createCache(a, true)
+	cache.IsUpdating = true
+	cache.UpdateFilter = func(string, string) bool { return false }
+	err := cache.RestoreFromFile()
+	a.Nil(err)
+
+	cache.Compare(cache_before, 1, content1)
+	result2 := cache.Compare(cache_before, 2, contentNew)
+	a.Equal(createCacheResult(2, false, snapshot2, snapshotNew), result2)
+	verifyCache(a, cache, true, true, 2, 0, 1, 1, 0)
+
+	stored, storeErr := cache.StoreToFileIfNeeded()
+	a.False(stored)
+	a.Nil(storeErr)
+
+	bytes, _ := os.ReadFile(cache.Filepath)
+	a.Equal(lastTimeContent, string(bytes))
+}
+
 func TestCacheWhenHasVanished(t *testing.T) {
 	a := assert.New(t)
 	cache := createCache(a, true)
@@ -230,6 +287,40 @@ func TestCacheWhenHasVanished(t *testing.T) {
 `, string(bytes))
 }
 
+func TestCacheWhenHasVanishedIfKeepVanished(t *testing.T) {
+	a := assert.New(t)
+	cache := createCache(a, true)
+	cache.KeepVanished = true
+	err := cache.RestoreFromFile()
+	a.Nil(err)
+
+	cache.Compare(cache_before, 1, content1)
+	verifyCache(a, cache, true, true, 1, 0, 0, 0, 1)
+
+	stored, storeErr := cache.StoreToFileIfNeeded()
+	a.False(stored)
+	a.Nil(storeErr)
+
+	cache.Compare("new test", 1, contentNew)
+	stored, storeErr = cache.StoreToFileIfNeeded()
+	a.True(stored)
+	a.Nil(storeErr)
+
+	bytes, _ := os.ReadFile(cache.Filepath)
+	a.Equal(`cached before:
+  1: |
+    a:
+      b: c
+  2: |
+    d:
+      e: f
+new test:
+  1: |
+    x:
+      "y": z
+`, string(bytes))
+}
+
 func TestCacheWhenHasInserted(t *testing.T) {
 	a := assert.New(t)
 	cache := createCache(a, true)
@@ -343,3 +434,111 @@ func TestCacheWhenNewOneAtMiddleIfIsUpdating(t *testing.T) {
       e: f
 `, string(bytes))
 }
+
+func TestCacheWhenNamed(t *testing.T) {
+	a := assert.New(t)
+	cache := createCache(a, true)
+	err := cache.RestoreFromFile()
+	a.Nil(err)
+
+	cache.Compare(cache_before, 1, content1)
+	cache.Compare(cache_before, 2, content2)
+	result := cache.CompareNamed(cache_before, "Deployment/my-app", 0, contentNew)
+	a.Equal(&CompareResult{
+		Test:        cache_before,
+		Key:         "Deployment/my-app",
+		Passed:      true,
+		NewSnapshot: snapshotNew,
+	}, result)
+	verifyCache(a, cache, true, true, 3, 1, 0, 0, 0)
+
+	stored, storeErr := cache.StoreToFileIfNeeded()
+	a.True(stored)
+	a.Nil(storeErr)
+
+	bytes, _ := os.ReadFile(cache.Filepath)
+	a.Equal(`cached before:
+  1: |
+    a:
+      b: c
+  2: |
+    d:
+      e: f
+  Deployment/my-app: |
+    x:
+      "y": z
+`, string(bytes))
+
+	restored := &Cache{Filepath: cache.Filepath}
+	a.Nil(restored.RestoreFromFile())
+	restored.Compare(cache_before, 1, content1)
+	restored.Compare(cache_before, 2, content2)
+	named := restored.CompareNamed(cache_before, "Deployment/my-app", 0, contentNew)
+	a.True(named.Passed)
+	a.Equal(snapshotNew, named.CachedSnapshot)
+	verifyCache(a, restored, true, false, 3, 0, 0, 0, 0)
+}
+
+func TestCacheWhenNamedMigratesOrdered(t *testing.T) {
+	a := assert.New(t)
+	cache := createCache(a, true)
+	err := cache.RestoreFromFile()
+	a.Nil(err)
+
+	result1 := cache.CompareNamed(cache_before, "first", 1, content1)
+	a.Equal(&CompareResult{
+		Test:           cache_before,
+		Key:            "first",
+		Passed:         true,
+		CachedSnapshot: snapshot1,
+		NewSnapshot:    snapshot1,
+	}, result1)
+	result2 := cache.CompareNamed(cache_before, "second", 2, contentNew)
+	a.False(result2.Passed)
+	a.Equal(snapshot2, result2.CachedSnapshot)
+	verifyCache(a, cache, true, true, 2, 0, 1, 1, 0)
+	a.Equal(uint(2), cache.MigratedCount())
+
+	stored, storeErr := cache.StoreToFileIfNeeded()
+	a.True(stored)
+	a.Nil(storeErr)
+
+	bytes, _ := os.ReadFile(cache.Filepath)
+	a.Equal(`cached before:
+  first: |
+    a:
+      b: c
+  second: |
+    d:
+      e: f
+`, string(bytes))
+}
+
+func TestCacheWhenReadOnly(t *testing.T) {
+	a := assert.New(t)
+	cache := createCache(a, true)
+	cache.ReadOnly = true
+	cache.IsUpdating = true
+	err := cache.RestoreFromFile()
+	a.Nil(err)
+
+	result1 := cache.Compare(cache_before, 1, contentNew)
+	a.Equal(createCacheResult(1, false, snapshot1, snapshotNew), result1)
+	result3 := cache.Compare(cache_before, 3, content1)
+	a.Equal(&CompareResult{
+		Test:        cache_before,
+		Index:       3,
+		Key:         "3",
+		Missing:     true,
+		NewSnapshot: snapshot1,
+	}, result3)
+	verifyCache(a, cache, true, true, 2, 0, 1, 2, 1)
+	a.Equal(uint(1), cache.MissingCount())
+
+	stored, storeErr := cache.StoreToFileIfNeeded()
+	a.False(stored)
+	a.Nil(storeErr)
+
+	bytes, _ := os.ReadFile(cache.Filepath)
+	a.Equal(lastTimeContent, string(bytes))
+}
@@ -4,28 +4,20 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 )
 
 const snapshotDirName = "__snapshot__"
 const snapshotFileExt = ".snap"
 
-// CreateSnapshotOfSuite retruns snapshot.Cache for suite file, create `__snapshot__` dir if not existed
-func CreateSnapshotOfSuite(path string, isUpdating bool) (*Cache, error) {
-	cacheDir := filepath.Join(filepath.Dir(path), snapshotDirName)
-	if err := ensureDir(cacheDir); err != nil {
-		return nil, err
-	}
-	cacheFileName := filepath.Base(path) + snapshotFileExt
-	cache := &Cache{
-		Filepath:   filepath.Join(cacheDir, cacheFileName),
-		IsUpdating: isUpdating,
-	}
+// SnapshotFilePath returns the path of the snapshot file of the suite file, in the `__snapshot__` dir next to it
+func SnapshotFilePath(path string) string {
+	return Layout{}.SnapshotPath(path)
+}
 
-	if err := cache.RestoreFromFile(); err != nil {
-		return nil, err
-	}
-	return cache, nil
+// CreateSnapshotOfSuite retruns snapshot.Cache for suite file, create `__snapshot__` dir if not existed.
+// A readOnly cache never writes to disk, the `__snapshot__` dir is not created.
+func CreateSnapshotOfSuite(path string, isUpdating, readOnly bool) (*Cache, error) {
+	return Layout{}.CreateSnapshotOfSuite(path, isUpdating, readOnly)
 }
 
 func ensureDir(path string) error {
@@ -11,8 +11,8 @@ import (
 
 func TestCreateSnapshotOfSuiteReturnCacheRight(t *testing.T) {
 	dir, _ := os.MkdirTemp("", "test")
-	cache, err := CreateSnapshotOfSuite(filepath.Join(dir, "my_test.yaml"), true)
-	cache2, err2 := CreateSnapshotOfSuite(filepath.Join(dir, "another_test.yaml"), false)
+	cache, err := CreateSnapshotOfSuite(filepath.Join(dir, "my_test.yaml"), true, false)
+	cache2, err2 := CreateSnapshotOfSuite(filepath.Join(dir, "another_test.yaml"), false, false)
 
 	a := assert.New(t)
 	a.Nil(err)
@@ -26,7 +26,7 @@ func TestCreateSnapshotOfSuiteReturnCacheRight(t *testing.T) {
 
 func TestCreateSnapshotOfSuiteWhenNoCacheDir(t *testing.T) {
 	dir, _ := os.MkdirTemp("", "test")
-	cache, _ := CreateSnapshotOfSuite(filepath.Join(dir, "service_test.yaml"), false)
+	cache, _ := CreateSnapshotOfSuite(filepath.Join(dir, "service_test.yaml"), false, false)
 
 	info, err := os.Stat(filepath.Join(dir, "__snapshot__"))
 
@@ -49,7 +49,7 @@ func TestCreateSnapshotOfSuiteWhenCacheDirExisted(t *testing.T) {
 	if dirErr != nil {
 		a.FailNow("Failed to create cache dir")
 	}
-	cache, _ := CreateSnapshotOfSuite(filepath.Join(dir, "service_test.yaml"), false)
+	cache, _ := CreateSnapshotOfSuite(filepath.Join(dir, "service_test.yaml"), false, false)
 
 	info, err := os.Stat(filepath.Join(dir, "__snapshot__"))
 
@@ -78,7 +78,7 @@ func TestCreateSnapshotOfSuiteWhenCacheFileExisted(t *testing.T) {
 	if fileErr != nil {
 		a.FailNow("Failed to create cache file")
 	}
-	cache, _ := CreateSnapshotOfSuite(filepath.Join(dir, "service_test.yaml"), false)
+	cache, _ := CreateSnapshotOfSuite(filepath.Join(dir, "service_test.yaml"), false, false)
 
 	info, err := os.Stat(filepath.Join(dir, "__snapshot__"))
 
@@ -91,3 +91,14 @@ func TestCreateSnapshotOfSuiteWhenCacheFileExisted(t *testing.T) {
 
 	os.RemoveAll(dir)
 }
+
+func TestCreateSnapshotOfSuiteWhenReadOnly(t *testing.T) {
+	a := assert.New(t)
+	dir := t.TempDir()
+
+	cache, err := CreateSnapshotOfSuite(filepath.Join(dir, "service_test.yaml"), false, true)
+
+	a.Nil(err)
+	a.True(cache.ReadOnly)
+	a.NoDirExists(filepath.Join(dir, "__snapshot__"))
+}
@@ -0,0 +1,121 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Layout locates the snapshot files of the suite files
+type Layout struct {
+	// Dir stores the snapshot files, keeping the paths of the suite files relative to Root.
+	// The snapshot files are stored in the `__snapshot__` dir next to the suite files when empty.
+	Dir string
+	// Root is the directory the paths of the suite files are kept relative to in Dir, usually the chart
+	Root string
+	// PerTest stores the snapshots of each test in a file of its own, in a directory per suite file
+	PerTest bool
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// SnapshotPath returns the path of the snapshot file of the suite file, or the path of the directory of
+// its snapshot files when PerTest. Suite files outside Root keep their snapshots in `__snapshot__` next to them.
+func (l Layout) SnapshotPath(path string) string {
+	ext := snapshotFileExt
+	if l.PerTest {
+		ext = ""
+	}
+	if relPath, ok := l.relativePath(path); ok {
+		return filepath.Join(l.Dir, relPath+ext)
+	}
+	return filepath.Join(filepath.Dir(path), snapshotDirName, filepath.Base(path)+ext)
+}
+
+// relativePath returns the path of the suite file relative to Root, and false when it is not stored in Dir
+func (l Layout) relativePath(path string) (string, bool) {
+	if l.Dir == "" {
+		return "", false
+	}
+	absRoot, rootErr := filepath.Abs(l.Root)
+	absPath, pathErr := filepath.Abs(path)
+	if rootErr != nil || pathErr != nil {
+		return "", false
+	}
+	return relativeInside(absRoot, absPath)
+}
+
+// relativeInside returns the path relative to the dir, and false when the path is not inside the dir
+func relativeInside(dir, path string) (string, bool) {
+	relPath, err := filepath.Rel(dir, path)
+	if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return relPath, true
+}
+
+// suitePath returns the path the snapshot file or directory was created for, which is the suite file
+// with the `_<SnapshotId>` suffix, and false when the snapshot file is not located by the layout
+func (l Layout) suitePath(snapshotPath string) (string, bool) {
+	name := snapshotPath
+	if !l.PerTest {
+		name = strings.TrimSuffix(snapshotPath, snapshotFileExt)
+	}
+	if l.Dir == "" {
+		return filepath.Join(filepath.Dir(filepath.Dir(name)), filepath.Base(name)), true
+	}
+
+	absDir, dirErr := filepath.Abs(l.Dir)
+	absName, nameErr := filepath.Abs(name)
+	if dirErr != nil || nameErr != nil {
+		return "", false
+	}
+	relPath, ok := relativeInside(absDir, absName)
+	if !ok {
+		return "", false
+	}
+	return filepath.Join(l.Root, relPath), true
+}
+
+// CreateSnapshotOfSuite retruns snapshot.Cache for suite file, create `__snapshot__` dir if not existed.
+// A readOnly cache never writes to disk, the `__snapshot__` dir is not created.
+// The directories of a Dir are created when the snapshots are stored.
+func (l Layout) CreateSnapshotOfSuite(path string, isUpdating, readOnly bool) (*Cache, error) {
+	cacheFilePath := l.SnapshotPath(path)
+	if !readOnly && l.Dir == "" {
+		if err := ensureDir(filepath.Dir(cacheFilePath)); err != nil {
+			return nil, err
+		}
+	}
+	cache := &Cache{
+		Filepath:   cacheFilePath,
+		IsUpdating: isUpdating,
+		ReadOnly:   readOnly,
+		PerTest:    l.PerTest,
+	}
+
+	if err := cache.RestoreFromFile(); err != nil {
+		return nil, err
+	}
+	return cache, nil
+}
+
+// testFileName returns the name of the snapshot file of the test, when the snapshots are stored per test
+func testFileName(test string) string {
+	name := strings.Trim(unsafeFileNameChars.ReplaceAllString(test, "_"), "_")
+	if name == "" {
+		name = "_"
+	}
+	return name + snapshotFileExt
+}
+
+// isSnapshotDir check if the directory contains snapshot files
+func isSnapshotDir(path string) bool {
+	files, err := filepath.Glob(filepath.Join(path, "*"+snapshotFileExt))
+	if err != nil || len(files) == 0 {
+		return false
+	}
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,80 @@
+package snapshot_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayoutSnapshotPath(t *testing.T) {
+	a := assert.New(t)
+	chart := filepath.Join("charts", "my-chart")
+	suiteFile := filepath.Join(chart, "tests", "deployment_test.yaml")
+	subchartSuiteFile := filepath.Join(chart, "charts", "child", "tests", "service_test.yaml")
+	outsideSuiteFile := filepath.Join("central-tests", "ingress_test.yaml")
+	snapshotDir := filepath.Join(chart, "snapshots")
+
+	a.Equal(filepath.Join(chart, "tests", "__snapshot__", "deployment_test.yaml.snap"), Layout{}.SnapshotPath(suiteFile))
+	a.Equal(filepath.Join(chart, "tests", "__snapshot__", "deployment_test.yaml"), Layout{PerTest: true}.SnapshotPath(suiteFile))
+
+	layout := Layout{Dir: snapshotDir, Root: chart}
+	a.Equal(filepath.Join(snapshotDir, "tests", "deployment_test.yaml.snap"), layout.SnapshotPath(suiteFile))
+	a.Equal(filepath.Join(snapshotDir, "charts", "child", "tests", "service_test.yaml.snap"), layout.SnapshotPath(subchartSuiteFile))
+	a.Equal(filepath.Join("central-tests", "__snapshot__", "ingress_test.yaml.snap"), layout.SnapshotPath(outsideSuiteFile))
+
+	layout.PerTest = true
+	a.Equal(filepath.Join(snapshotDir, "tests", "deployment_test.yaml"), layout.SnapshotPath(suiteFile))
+}
+
+func TestLayoutCreateSnapshotOfSuiteWhenDir(t *testing.T) {
+	a := assert.New(t)
+	dir := t.TempDir()
+	layout := Layout{Dir: filepath.Join(dir, "snapshots"), Root: dir}
+
+	cache, err := layout.CreateSnapshotOfSuite(filepath.Join(dir, "tests", "service_test.yaml"), false, false)
+
+	a.Nil(err)
+	a.Equal(filepath.Join(dir, "snapshots", "tests", "service_test.yaml.snap"), cache.Filepath)
+	a.NoDirExists(filepath.Join(dir, "snapshots"))
+
+	cache.Compare("should match", 1, content1)
+	stored, storeErr := cache.StoreToFileIfNeeded()
+	a.True(stored)
+	a.Nil(storeErr)
+	a.FileExists(cache.Filepath)
+}
+
+func TestLayoutCreateSnapshotOfSuiteWhenPerTest(t *testing.T) {
+	a := assert.New(t)
+	dir := t.TempDir()
+	layout := Layout{PerTest: true}
+
+	cache, err := layout.CreateSnapshotOfSuite(filepath.Join(dir, "service_test.yaml"), false, false)
+	a.Nil(err)
+	a.True(cache.PerTest)
+
+	cache.Compare("should match snapshot", 1, content1)
+	cache.Compare("should render/with values", 1, content2)
+	stored, storeErr := cache.StoreToFileIfNeeded()
+	a.True(stored)
+	a.Nil(storeErr)
+
+	snapshotDir := filepath.Join(dir, "__snapshot__", "service_test.yaml")
+	bytes, _ := os.ReadFile(filepath.Join(snapshotDir, "should_match_snapshot.snap"))
+	a.Equal("should match snapshot:\n  1: |\n    a:\n      b: c\n", string(bytes))
+	bytes, _ = os.ReadFile(filepath.Join(snapshotDir, "should_render_with_values.snap"))
+	a.Equal("should render/with values:\n  1: |\n    d:\n      e: f\n", string(bytes))
+
+	restored, err := layout.CreateSnapshotOfSuite(filepath.Join(dir, "service_test.yaml"), false, false)
+	a.Nil(err)
+	a.True(restored.Existed)
+	a.True(restored.Compare("should match snapshot", 1, content1).Passed)
+	stored, storeErr = restored.StoreToFileIfNeeded()
+	a.True(stored)
+	a.Nil(storeErr)
+	a.FileExists(filepath.Join(snapshotDir, "should_match_snapshot.snap"))
+	a.NoFileExists(filepath.Join(snapshotDir, "should_render_with_values.snap"))
+}
@@ -0,0 +1,87 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// OrphanedFiles returns the snapshot files in the snapshot dirs of the suiteFiles, which belong to no suite.
+// suiteFiles maps the snapshot files of the tested suites to their suite file. A snapshot file is orphaned
+// when its suite file no longer exists, or when its suite file is tested but did not produce it,
+// like a `SnapshotId` suffixed file of a removed suite. Snapshot files of untested suite files are kept.
+func OrphanedFiles(suiteFiles map[string]string) ([]string, error) {
+	return Layout{}.OrphanedFiles(suiteFiles)
+}
+
+// OrphanedFiles returns the snapshot files of the layout in the snapshot dirs of the suiteFiles, which belong to no suite.
+// The snapshot files are directories of snapshot files when PerTest.
+func (l Layout) OrphanedFiles(suiteFiles map[string]string) ([]string, error) {
+	testedFiles := make(map[string]bool, len(suiteFiles))
+	snapshotDirs := make(map[string]bool)
+	for snapshotFile, suiteFile := range suiteFiles {
+		testedFiles[filepath.Clean(suiteFile)] = true
+		snapshotDirs[filepath.Dir(snapshotFile)] = true
+	}
+
+	var orphans []string
+	for snapshotDir := range snapshotDirs {
+		snapshotFiles, err := l.snapshotFilesIn(snapshotDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, snapshotFile := range snapshotFiles {
+			if _, ok := suiteFiles[snapshotFile]; ok {
+				continue
+			}
+			suiteFile, exists, located := l.ownerSuiteFile(snapshotFile)
+			if located && (!exists || testedFiles[suiteFile]) {
+				orphans = append(orphans, snapshotFile)
+			}
+		}
+	}
+	sort.Strings(orphans)
+	return orphans, nil
+}
+
+// snapshotFilesIn returns the snapshot files of the layout in the dir
+func (l Layout) snapshotFilesIn(dir string) ([]string, error) {
+	if !l.PerTest {
+		return filepath.Glob(filepath.Join(dir, "*"+snapshotFileExt))
+	}
+
+	entries, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil {
+		return nil, err
+	}
+	var snapshotDirs []string
+	for _, entry := range entries {
+		if isSnapshotDir(entry) {
+			snapshotDirs = append(snapshotDirs, entry)
+		}
+	}
+	return snapshotDirs, nil
+}
+
+// ownerSuiteFile returns the suite file the snapshot file was created for, whether it exists and whether the
+// snapshot file is located by the layout. The `_<SnapshotId>` suffixes are stripped until an existing suite file is found.
+func (l Layout) ownerSuiteFile(snapshotFile string) (string, bool, bool) {
+	suitePath, located := l.suitePath(snapshotFile)
+	if !located {
+		return "", false, false
+	}
+	suiteDir := filepath.Dir(suitePath)
+	name := filepath.Base(suitePath)
+	for {
+		suiteFile := filepath.Join(suiteDir, name)
+		if info, err := os.Stat(suiteFile); err == nil && !info.IsDir() {
+			return suiteFile, true, true
+		}
+		idx := strings.LastIndex(name, "_")
+		if idx <= 0 {
+			return "", false, true
+		}
+		name = name[:idx]
+	}
+}
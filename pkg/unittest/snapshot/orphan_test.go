@@ -0,0 +1,100 @@
+package snapshot_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	"github.com/stretchr/testify/assert"
+)
+
+func createFiles(a *assert.Assertions, dir string, files ...string) {
+	for _, file := range files {
+		path := filepath.Join(dir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			a.FailNow("Failed to create dir")
+		}
+		if err := os.WriteFile(path, []byte{}, 0644); err != nil {
+			a.FailNow("Failed to create file")
+		}
+	}
+}
+
+func TestOrphanedFiles(t *testing.T) {
+	a := assert.New(t)
+	dir := t.TempDir()
+	createFiles(a, dir,
+		"deployment_test.yaml",
+		"__snapshot__/deployment_test.yaml.snap",
+		"rendered.yaml",
+		"__snapshot__/rendered.yaml_0.snap",
+		"__snapshot__/rendered.yaml_1.snap",
+		"untested_test.yaml",
+		"__snapshot__/untested_test.yaml.snap",
+		"__snapshot__/removed_test.yaml.snap",
+		"__snapshot__/removed_test.yaml_0.snap",
+	)
+
+	orphans, err := OrphanedFiles(map[string]string{
+		SnapshotFilePath(filepath.Join(dir, "deployment_test.yaml")): filepath.Join(dir, "deployment_test.yaml"),
+		SnapshotFilePath(filepath.Join(dir, "rendered.yaml_0")):      filepath.Join(dir, "rendered.yaml"),
+	})
+
+	a.Nil(err)
+	a.Equal([]string{
+		filepath.Join(dir, "__snapshot__", "removed_test.yaml.snap"),
+		filepath.Join(dir, "__snapshot__", "removed_test.yaml_0.snap"),
+		filepath.Join(dir, "__snapshot__", "rendered.yaml_1.snap"),
+	}, orphans)
+}
+
+func TestOrphanedFilesWhenNoSnapshotDir(t *testing.T) {
+	a := assert.New(t)
+	dir := t.TempDir()
+
+	orphans, err := OrphanedFiles(map[string]string{
+		SnapshotFilePath(filepath.Join(dir, "service_test.yaml")): filepath.Join(dir, "service_test.yaml"),
+	})
+
+	a.Nil(err)
+	a.Empty(orphans)
+}
+
+func TestLayoutOrphanedFilesWhenDir(t *testing.T) {
+	a := assert.New(t)
+	dir := t.TempDir()
+	layout := Layout{Dir: filepath.Join(dir, "snapshots"), Root: dir}
+	createFiles(a, dir,
+		"tests/deployment_test.yaml",
+		"snapshots/tests/deployment_test.yaml.snap",
+		"snapshots/tests/removed_test.yaml.snap",
+		"tests/__snapshot__/untouched_test.yaml.snap",
+	)
+
+	orphans, err := layout.OrphanedFiles(map[string]string{
+		layout.SnapshotPath(filepath.Join(dir, "tests", "deployment_test.yaml")): filepath.Join(dir, "tests", "deployment_test.yaml"),
+	})
+
+	a.Nil(err)
+	a.Equal([]string{filepath.Join(dir, "snapshots", "tests", "removed_test.yaml.snap")}, orphans)
+}
+
+func TestLayoutOrphanedFilesWhenPerTest(t *testing.T) {
+	a := assert.New(t)
+	dir := t.TempDir()
+	layout := Layout{PerTest: true}
+	createFiles(a, dir,
+		"deployment_test.yaml",
+		"__snapshot__/deployment_test.yaml/should_render.snap",
+		"__snapshot__/removed_test.yaml/should_render.snap",
+		"__snapshot__/service_test.yaml.snap",
+	)
+
+	orphans, err := layout.OrphanedFiles(map[string]string{
+		layout.SnapshotPath(filepath.Join(dir, "deployment_test.yaml")): filepath.Join(dir, "deployment_test.yaml"),
+	})
+
+	a.Nil(err)
+	a.Equal([]string{filepath.Join(dir, "__snapshot__", "removed_test.yaml")}, orphans)
+}
@@ -0,0 +1,81 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+
+	yaml "sigs.k8s.io/yaml"
+)
+
+// ReviewEntry accepts the changed snapshots of a test, matching a CompareResult of a snapshot file
+type ReviewEntry struct {
+	// File is the snapshot file, relative to the review file, any file matches when empty
+	File string `json:"file,omitempty"`
+	// Test is the name of the test, any test matches when empty
+	Test string `json:"test,omitempty"`
+	// Index is the index of the ordered snapshot in the test, starting at 1, any snapshot matches when 0
+	Index uint `json:"index,omitempty"`
+	// Name is the name of the named snapshot in the test, any snapshot matches when empty
+	Name string `json:"name,omitempty"`
+}
+
+// Review lists the changed snapshots to accept when updating snapshots
+type Review struct {
+	Accept []ReviewEntry `json:"accept"`
+}
+
+// LoadReview load the review file, the file paths of its entries are made absolute
+func LoadReview(path string) (*Review, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	review := &Review{}
+	if err := yaml.UnmarshalStrict(content, review); err != nil {
+		return nil, err
+	}
+
+	reviewDir := filepath.Dir(path)
+	for idx, entry := range review.Accept {
+		if entry.File == "" {
+			continue
+		}
+		if !filepath.IsAbs(entry.File) {
+			entry.File = filepath.Join(reviewDir, entry.File)
+		}
+		if review.Accept[idx].File, err = filepath.Abs(entry.File); err != nil {
+			return nil, err
+		}
+	}
+	return review, nil
+}
+
+// Accepts check if the snapshot with the key of the test in the snapshot file is accepted by an entry of the review
+func (r *Review) Accepts(snapshotFile, test, key string) bool {
+	if r == nil {
+		return false
+	}
+
+	absSnapshotFile, err := filepath.Abs(snapshotFile)
+	if err != nil {
+		absSnapshotFile = snapshotFile
+	}
+	for _, entry := range r.Accept {
+		if entry.File != "" && entry.File != absSnapshotFile {
+			continue
+		}
+		if entry.Test != "" && entry.Test != test {
+			continue
+		}
+		if entry.Index != 0 && strconv.FormatUint(uint64(entry.Index), 10) != key {
+			continue
+		}
+		if entry.Name != "" && entry.Name != key {
+			continue
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package snapshot_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	"github.com/stretchr/testify/assert"
+)
+
+const reviewContent = `accept:
+  - file: __snapshot__/deployment_test.yaml.snap
+    test: should render deployment
+    index: 2
+  - test: should render service
+  - file: __snapshot__/configmap_test.yaml.snap
+`
+
+func writeReview(a *assert.Assertions, content string) (string, string) {
+	dir, _ := os.MkdirTemp("", "review")
+	reviewFile := filepath.Join(dir, "review.yaml")
+	if err := os.WriteFile(reviewFile, []byte(content), 0644); err != nil {
+		a.FailNow("Failed to create review file")
+	}
+	return dir, reviewFile
+}
+
+func TestLoadReviewAcceptsListedSnapshots(t *testing.T) {
+	a := assert.New(t)
+	dir, reviewFile := writeReview(a, reviewContent)
+
+	review, err := LoadReview(reviewFile)
+	a.Nil(err)
+	a.Len(review.Accept, 3)
+
+	deploymentSnapshot := filepath.Join(dir, "__snapshot__", "deployment_test.yaml.snap")
+	a.True(review.Accepts(deploymentSnapshot, "should render deployment", "2"))
+	a.False(review.Accepts(deploymentSnapshot, "should render deployment", "1"))
+	a.False(review.Accepts(deploymentSnapshot, "should render ingress", "2"))
+	a.True(review.Accepts(deploymentSnapshot, "should render service", "1"))
+
+	configmapSnapshot := filepath.Join(dir, "__snapshot__", "configmap_test.yaml.snap")
+	a.True(review.Accepts(configmapSnapshot, "any test", "3"))
+	a.False(review.Accepts(filepath.Join(dir, "configmap_test.yaml.snap"), "any test", "3"))
+}
+
+func TestLoadReviewFailsOnUnknownFields(t *testing.T) {
+	a := assert.New(t)
+	_, reviewFile := writeReview(a, "accept:\n  - suite: unknown\n")
+
+	_, err := LoadReview(reviewFile)
+	a.Error(err)
+}
+
+func TestLoadReviewFailsWhenNotExists(t *testing.T) {
+	a := assert.New(t)
+
+	_, err := LoadReview(filepath.Join(t.TempDir(), "review.yaml"))
+	a.Error(err)
+}
+
+func TestNilReviewAcceptsNothing(t *testing.T) {
+	var review *Review
+	assert.False(t, review.Accepts("any.snap", "any test", "1"))
+}
@@ -104,6 +104,9 @@ func TestValidateExampleChartsWithTestSuitsAgainstLocalSchema(t *testing.T) {
 		{
 			testsPath: "../../test/data/v3/with-samenamesubsubcharts/charts/with-subsubchartssub/charts/with-subsubchartssubsub/tests",
 		},
+		{
+			testsPath: "../../test/data/v3/with-crds/tests",
+		},
 		{
 			testsPath: "../../test/data/v3/with-schema/tests",
 		},
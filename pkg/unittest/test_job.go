@@ -18,6 +18,7 @@ import (
 	"github.com/helm-unittest/helm-unittest/internal/common"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/results"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
 	log "github.com/sirupsen/logrus"
 
@@ -157,17 +158,47 @@ func writeRenderedOutput(renderPath string, outputOfFiles map[string]string) err
 	return nil
 }
 
-type orderedSnapshotComparer struct {
-	cache   *snapshot.Cache
-	test    string
-	counter uint
+// snapshotComparer compares the snapshots of a test by the order of comparing, by the name of the snapshot,
+// or by the identity of the document when byIdentity
+type snapshotComparer struct {
+	cache      *snapshot.Cache
+	test       string
+	counter    uint
+	byIdentity bool
+	keys       map[string]int
 }
 
-func (s *orderedSnapshotComparer) CompareToSnapshot(content interface{}) *snapshot.CompareResult {
+func (s *snapshotComparer) CompareToSnapshot(content interface{}) *snapshot.CompareResult {
 	s.counter++
 	return s.cache.Compare(s.test, s.counter, content)
 }
 
+func (s *snapshotComparer) CompareToKeyedSnapshot(key validators.SnapshotKey, content interface{}) *snapshot.CompareResult {
+	name := key.Name
+	if name == "" && s.byIdentity {
+		name = key.Identity
+	}
+	if name == "" {
+		return s.CompareToSnapshot(content)
+	}
+
+	// The counter keeps counting, so the ordered snapshots are migrated to the named snapshots at the same position
+	s.counter++
+	return s.cache.CompareNamed(s.test, s.uniqueKey(name), s.counter, content)
+}
+
+// uniqueKey suffixes the name with the number of times it is used in the test after the first time
+func (s *snapshotComparer) uniqueKey(name string) string {
+	if s.keys == nil {
+		s.keys = make(map[string]int)
+	}
+	s.keys[name]++
+	if s.keys[name] > 1 {
+		return fmt.Sprintf("%s#%d", name, s.keys[name])
+	}
+	return name
+}
+
 type Capabilities struct {
 	MajorVersion string   `yaml:"majorVersion"`
 	MinorVersion string   `yaml:"minorVersion"`
@@ -184,8 +215,8 @@ type TestJob struct {
 	Set              map[string]interface{}
 	Template         string
 	Templates        []string
-	DocumentIndex    *int `yaml:"documentIndex"`
-	DocumentIndices  map[string][]int
+	DocumentIndex    *int                         `yaml:"documentIndex"`
+	DocumentIndices  map[string][]int             `yaml:"-"`
 	DocumentSelector *valueutils.DocumentSelector `yaml:"documentSelector"`
 	Release          struct {
 		Name      string
@@ -220,6 +251,9 @@ type TestJob struct {
 	// requireSuccess
 	requireRenderSuccess bool
 	config               TestConfig
+
+	// the values the chart is rendered with, like .Release and .Values in the templates
+	renderValues v3util.Values
 }
 
 func (t *TestJob) WithConfig(config TestConfig) {
@@ -283,16 +317,23 @@ func (t *TestJob) RunV3(
 		return result
 	}
 
-	snapshotComparer := &orderedSnapshotComparer{cache: t.configOrDefault().cache, test: t.Name}
+	snapshotComparer := &snapshotComparer{
+		cache:      t.configOrDefault().cache,
+		test:       t.Name,
+		byIdentity: t.configOrDefault().snapshotKeys == SnapshotKeysIdentity,
+	}
 
 	assertionsConfig := AssertionConfig{
-		templatesResult:     manifestsOfFiles,
-		snapshotComparer:    snapshotComparer,
-		renderSucceed:       renderSucceed,
-		failFast:            t.configOrDefault().failFast,
-		didPostRender:       didPostRender,
-		renderError:         renderError,
-		isSkipEmptyTemplate: t.configOrDefault().isSkipEmptyTemplate,
+		templatesResult:           manifestsOfFiles,
+		snapshotComparer:          snapshotComparer,
+		renderSucceed:             renderSucceed,
+		failFast:                  t.configOrDefault().failFast,
+		didPostRender:             didPostRender,
+		renderError:               renderError,
+		isSkipEmptyTemplate:       t.configOrDefault().isSkipEmptyTemplate,
+		customResourceDefinitions: chartCRDs(t.configOrDefault().targetChart),
+		customResourceSchemas:     t.configOrDefault().customResourceSchemas,
+		renderValues:              t.renderValues,
 	}
 
 	result.Passed, result.AssertsResult = t.runAssertions(assertionsConfig)
@@ -374,6 +415,7 @@ func (t *TestJob) renderV3Chart(userValues []byte) (map[string]string, bool, err
 	if err != nil {
 		return nil, false, err
 	}
+	t.renderValues = vals
 	// When defaultTemplatesToAssert is empty, ensure all templates will be validated.
 	if len(t.defaultTemplatesToAssert) == 0 {
 		// Set all files
@@ -607,7 +649,10 @@ func (t *TestJob) runAssertions(
 			continue
 		}
 
-		assertion.WithConfig(cfg)
+		assertionCfg := cfg
+		assertionCfg.baseDirectory = filepath.Dir(t.definitionFile)
+		assertionCfg.inlineSnapshotUpdater = t.configOrDefault().inlineSnapshots.updater(t.Name, t.configOrDefault().testIndex, idx)
+		assertion.WithConfig(assertionCfg)
 		result := assertion.Assert(
 			&results.AssertionResult{Index: idx},
 		)
@@ -631,6 +676,15 @@ func (t *TestJob) runAssertions(
 	return testPass, assertsResult
 }
 
+// chartCRDs returns the content of the CRD files of the chart and its dependencies
+func chartCRDs(chart *v3chart.Chart) [][]byte {
+	var crds [][]byte
+	for _, crd := range chart.CRDObjects() {
+		crds = append(crds, crd.File.Data)
+	}
+	return crds
+}
+
 // determine if the success for rendering is required,
 // to return an errorCode direct.
 func (t *TestJob) determineRenderSuccess() {
@@ -654,9 +708,18 @@ func (t *TestJob) polishAssertionsTemplate(targetChartName string, outputOfFiles
 			continue
 		}
 
-		t.updateAssertionDocumentFilters(assertion)
-		templatesToAssert, prefixedChartsNameFiles := t.determineTemplatesToAssert(assertion, outputOfFiles)
-		assertion.defaultTemplates = t.prefixTemplatesToAssert(templatesToAssert, prefixedChartsNameFiles)
+		t.polishAssertionTemplate(assertion, outputOfFiles)
+	}
+}
+
+// polishAssertionTemplate sets the templates to assert of the assertion and of its nested assertions
+func (t *TestJob) polishAssertionTemplate(assertion *Assertion, outputOfFiles map[string]string) {
+	t.updateAssertionDocumentFilters(assertion)
+	templatesToAssert, prefixedChartsNameFiles := t.determineTemplatesToAssert(assertion, outputOfFiles)
+	assertion.defaultTemplates = t.prefixTemplatesToAssert(templatesToAssert, prefixedChartsNameFiles)
+
+	for _, nested := range assertion.composite {
+		t.polishAssertionTemplate(nested, outputOfFiles)
 	}
 }
 
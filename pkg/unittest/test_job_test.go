@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path"
+	"slices"
+	"strings"
 
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
 	"github.com/stretchr/testify/mock"
@@ -1276,3 +1279,108 @@ asserts:
 	assert.True(t, testResult.Passed)
 	assert.Equal(t, 1, len(testResult.AssertsResult))
 }
+
+func TestV3RunJobWithSnapshotKeys(t *testing.T) {
+	c, _ := loader.Load(testV3BasicChart)
+	manifest := `
+it: should snapshot by keys
+template: templates/service.yaml
+asserts:
+  - matchSnapshot:
+      path: spec
+  - matchSnapshot:
+      path: metadata.labels
+      name: labels
+  - matchSnapshot:
+      path: metadata.labels
+      name: labels
+`
+	var tj TestJob
+	common.YmlUnmarshalTestHelper(manifest, &tj, t)
+
+	cache := &snapshot.Cache{Filepath: path.Join(t.TempDir(), "service_test.yaml.snap")}
+	tj.WithConfig(*NewTestConfig(c, cache, WithSnapshotKeys(SnapshotKeysIdentity)))
+	testResult := tj.RunV3(&results.TestJobResult{})
+
+	a := assert.New(t)
+	a.Nil(testResult.ExecError)
+	a.True(testResult.Passed)
+
+	stored, err := cache.StoreToFileIfNeeded()
+	a.True(stored)
+	a.Nil(err)
+
+	content, _ := os.ReadFile(cache.Filepath)
+	var snapshots map[string]map[string]string
+	a.Nil(common.YmlUnmarshal(string(content), &snapshots))
+	a.Equal([]string{
+		"basic/templates/service.yaml Service/RELEASE-NAME-basic spec",
+		"labels",
+		"labels#2",
+	}, slices.Sorted(maps.Keys(snapshots["should snapshot by keys"])))
+}
+
+func TestV3RunJobWithSnapshotIgnorePaths(t *testing.T) {
+	c, _ := loader.Load(testV3BasicChart)
+	manifest := `
+it: should snapshot without volatile values
+template: templates/service.yaml
+asserts:
+  - matchSnapshot:
+      path: metadata.labels
+      ignorePaths:
+        - metadata.labels.chart
+`
+	var tj TestJob
+	common.YmlUnmarshalTestHelper(manifest, &tj, t)
+
+	cache := &snapshot.Cache{Filepath: path.Join(t.TempDir(), "service_test.yaml.snap")}
+	tj.WithConfig(*NewTestConfig(c, cache))
+	testResult := tj.RunV3(&results.TestJobResult{})
+
+	a := assert.New(t)
+	a.Nil(testResult.ExecError)
+	a.True(testResult.Passed)
+
+	stored, err := cache.StoreToFileIfNeeded()
+	a.True(stored)
+	a.Nil(err)
+
+	content, _ := os.ReadFile(cache.Filepath)
+	a.Contains(string(content), "chart: <ignored>")
+}
+
+func TestV3RunJobWithReleaseSnapshot(t *testing.T) {
+	c, _ := loader.Load(testV3BasicChart)
+	manifest := `
+it: should snapshot the release
+template: templates/service.yaml
+asserts:
+  - matchReleaseSnapshot: {}
+`
+	var tj TestJob
+	common.YmlUnmarshalTestHelper(manifest, &tj, t)
+
+	cache := &snapshot.Cache{Filepath: path.Join(t.TempDir(), "release_test.yaml.snap")}
+	tj.WithConfig(*NewTestConfig(c, cache))
+	testResult := tj.RunV3(&results.TestJobResult{})
+
+	a := assert.New(t)
+	a.Nil(testResult.ExecError)
+	a.True(testResult.Passed)
+	a.Equal(uint(1), cache.InsertedCount())
+
+	stored, err := cache.StoreToFileIfNeeded()
+	a.True(stored)
+	a.Nil(err)
+
+	content, _ := os.ReadFile(cache.Filepath)
+	snapshotted := string(content)
+	a.Contains(snapshotted, "source: basic/templates/deployment.yaml")
+	a.Contains(snapshotted, "source: basic/templates/service.yaml")
+	a.Contains(snapshotted, "notes: |")
+	a.Less(
+		strings.Index(snapshotted, "source: basic/templates/service.yaml"),
+		strings.Index(snapshotted, "source: basic/templates/deployment.yaml"),
+	)
+}
@@ -3,12 +3,16 @@ package unittest
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"time"
 
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/formatter"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/reporter"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/results"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
 	log "github.com/sirupsen/logrus"
@@ -19,115 +23,109 @@ import (
 
 const LOG_TEST_RUNNER = "test-runner"
 
-// testUnitCounting stores counting numbers of test unit status
-type testUnitCounting struct {
-	passed  uint
-	failed  uint
-	errored uint
-	skipped uint
-}
-
-// sprint returns string of counting result
-func (counting testUnitCounting) sprint(printer *printer.Printer) string {
-	var failedLabel string
-	if counting.failed > 0 {
-		failedLabel = printer.Danger("%d failed, ", counting.failed)
-	}
-	var erroredLabel string
-	if counting.errored > 0 {
-		erroredLabel = fmt.Sprintf("%d errored, ", counting.errored)
-	}
-	result := failedLabel + erroredLabel
-	if counting.skipped > 0 {
-		result += fmt.Sprintf(
-			"%d passed, %d skipped, %d total",
-			counting.passed,
-			counting.skipped,
-			counting.passed+counting.failed+counting.skipped,
-		)
-	} else {
-		result += fmt.Sprintf(
-			"%d passed, %d total",
-			counting.passed,
-			counting.passed+counting.failed,
-		)
-	}
-	return result
-}
-
-// testUnitCountingWithSnapshotFailed store testUnitCounting with snapshotFailed field
-type testUnitCountingWithSnapshotFailed struct {
-	testUnitCounting
-	snapshotFailed uint
-}
-
-// totalSnapshotCounting store testUnitCounting with snapshotFailed field
-type totalSnapshotCounting struct {
-	testUnitCounting
-	created  uint
-	vanished uint
-}
-
 // TestRunner stores basic settings and testing status for running all tests
 type TestRunner struct {
-	Printer          *printer.Printer
-	Formatter        formatter.Formatter
-	UpdateSnapshot   bool
-	WithSubChart     bool
-	Strict           bool
-	Failfast         bool
-	TestFiles        []string
-	ChartTestsPath   string
-	ValuesFiles      []string
-	OutputFile       string
-	RenderPath       string
-	suiteCounting    testUnitCountingWithSnapshotFailed
-	testCounting     testUnitCounting
-	chartCounting    testUnitCounting
-	snapshotCounting totalSnapshotCounting
-	testResults      []*results.TestSuiteResult
+	Printer        *printer.Printer
+	Formatter      formatter.Formatter
+	UpdateSnapshot bool
+	WithSubChart   bool
+	Strict         bool
+	Failfast       bool
+	TestFiles      []string
+	ChartTestsPath string
+	ValuesFiles    []string
+	OutputFile     string
+	RenderPath     string
+	// UpdateSnapshotPattern limits the snapshots updated by UpdateSnapshot to the suites or tests
+	// with a name matching the pattern, all snapshots are updated when nil
+	UpdateSnapshotPattern *regexp.Regexp
+	// SnapshotReview lists the changed snapshots to update, next to the snapshots updated by UpdateSnapshot
+	SnapshotReview *snapshot.Review
+	// PruneSnapshots removes the obsolete snapshots of the suites and the orphaned snapshot files
+	PruneSnapshots bool
+	// CI never writes snapshots, and fails on missing snapshots, obsolete snapshots and orphaned snapshot files
+	CI bool
+	// SnapshotDir stores the snapshot files of the charts, keeping the paths of the suite files relative to the chart.
+	// A relative SnapshotDir is relative to the chart, the snapshots are stored next to the suite files when empty
+	SnapshotDir string
+	// SnapshotPerTest stores the snapshots of each test in a file of its own, instead of a file per suite
+	SnapshotPerTest bool
+	// Suites are in-memory test suites, which run next to the TestFiles of every chart
+	Suites []SuiteDefinition
+	// Reporters receive the results while the tests are running, next to the Printer and Formatter
+	Reporters []reporter.Reporter
+	// formatterOutput is written by the Formatter instead of the OutputFile, when set
+	formatterOutput io.Writer
+	result          *results.RunResult
+	reporter        reporter.Reporter
 }
 
 // RunV3 test suites in chart in ChartPaths.
 func (tr *TestRunner) RunV3(ChartPaths []string) bool {
-	allPassed := true
+	result, _ := tr.run(ChartPaths)
+	return result.Passed
+}
+
+// run the test suites in the charts of chartPaths and returns the result,
+// the error is returned when the formatted output could not be written.
+func (tr *TestRunner) run(chartPaths []string) (*results.RunResult, error) {
+	tr.result = &results.RunResult{Passed: true}
+	printerReporter := reporter.NewPrinterReporter(tr.Printer)
+	reporters := tr.outputReporters()
+	tr.reporter = reporter.Multi(append([]reporter.Reporter{printerReporter}, reporters...)...)
+
 	start := time.Now()
-	for _, chartPath := range ChartPaths {
+	for _, chartPath := range chartPaths {
+		chartResult := &results.ChartResult{Path: chartPath}
+		tr.result.Charts = append(tr.result.Charts, chartResult)
+
 		chart, err := v3loader.Load(chartPath)
 		if err != nil {
-			tr.printErroredChartHeader(err)
-			tr.countChart(false, err)
-			allPassed = false
+			tr.countChart(chartResult, false, err)
 			if tr.Failfast {
 				break
 			}
 			continue
 		}
+		chartResult.Name = chart.Name()
 		chartRoute := chart.Name()
-		testSuites, err := tr.getV3TestSuites(chartPath, chartRoute, chart)
+		testSuites, err := tr.getV3TestSuites(chartPath, chartRoute, chart, chartResult)
 		if err != nil {
-			tr.printErroredChartHeader(err)
-			tr.countChart(false, err)
-			allPassed = false
+			tr.countChart(chartResult, false, err)
 			if tr.Failfast {
 				break
 			}
 			continue
 		}
 
-		tr.printChartHeader(chart.Name(), chartPath)
-		chartPassed := tr.runV3SuitesOfChart(testSuites, chartPath)
+		tr.reporter.ChartStarted(chartResult)
+		chartPassed := tr.runV3SuitesOfChart(testSuites, chartPath, chartResult)
 
-		tr.countChart(chartPassed, nil)
-		allPassed = allPassed && chartPassed
+		tr.countChart(chartResult, chartPassed, nil)
 	}
-	err := tr.writeTestOutput()
+	tr.result.Duration = time.Since(start)
+
+	// The output reporters finish first, so their errors are printed before the summary
+	reporter.Multi(reporters...).RunFinished(tr.result)
+	err := reporter.Err(reporters...)
 	if err != nil {
-		tr.printErroredChartHeader(err)
+		printerReporter.PrintError(err)
+	}
+	printerReporter.RunFinished(tr.result)
+	return tr.result, err
+}
+
+// outputReporters returns the reporter of the Formatter, when set, and the Reporters.
+func (tr *TestRunner) outputReporters() []reporter.Reporter {
+	reporters := make([]reporter.Reporter, 0, len(tr.Reporters)+1)
+	if tr.Formatter != nil {
+		if tr.formatterOutput != nil {
+			reporters = append(reporters, reporter.NewFormatterReporter(tr.Formatter, tr.formatterOutput))
+		} else {
+			reporters = append(reporters, reporter.NewFormatterFileReporter(tr.Formatter, tr.OutputFile))
+		}
 	}
-	tr.printSnapshotSummary()
-	tr.printSummary(time.Since(start))
-	return allPassed
+	return append(reporters, tr.Reporters...)
 }
 
 // getTestSuites retrieves the list of test suites for the given chart.
@@ -137,7 +135,7 @@ func (tr *TestRunner) RunV3(ChartPaths []string) bool {
 // chartRoute is the route/path to the chart within the chart repository.
 //
 // It returns a slice of _TestSuite structs and an error if any occurred during processing.
-func (tr *TestRunner) getTestSuites(chartPath, chartRoute string) ([]*TestSuite, error) {
+func (tr *TestRunner) getTestSuites(chartPath, chartRoute string, chartResult *results.ChartResult) ([]*TestSuite, error) {
 	testFilesSet, terr := GetFiles(chartPath, tr.TestFiles, false)
 	if terr != nil {
 		return nil, terr
@@ -161,11 +159,11 @@ func (tr *TestRunner) getTestSuites(chartPath, chartRoute string) ([]*TestSuite,
 		}
 	}
 
-	resultSuites := make([]*TestSuite, 0, len(testFilesSet)+len(renderedTestSuites))
+	resultSuites := make([]*TestSuite, 0, len(testFilesSet)+len(tr.Suites)+len(renderedTestSuites))
 	for _, file := range testFilesSet {
 		suites, err := ParseTestSuiteFile(file, chartRoute, tr.Strict, valuesFilesSet)
 		if err != nil {
-			tr.handleSuiteResult(&results.TestSuiteResult{
+			tr.handleSuiteResult(chartResult, &results.TestSuiteResult{
 				FilePath:  file,
 				ExecError: err,
 			})
@@ -173,6 +171,18 @@ func (tr *TestRunner) getTestSuites(chartPath, chartRoute string) ([]*TestSuite,
 		}
 		resultSuites = append(resultSuites, suites...)
 	}
+	for idx, definition := range tr.Suites {
+		suiteFilePath := definition.filePath(chartPath, idx)
+		suites, err := ParseTestSuiteContent(suiteFilePath, chartRoute, definition.Content, tr.Strict, valuesFilesSet)
+		if err != nil {
+			tr.handleSuiteResult(chartResult, &results.TestSuiteResult{
+				FilePath:  suiteFilePath,
+				ExecError: err,
+			})
+			return nil, err
+		}
+		resultSuites = append(resultSuites, suites...)
+	}
 	resultSuites = append(resultSuites, renderedTestSuites...)
 	return resultSuites, nil
 }
@@ -185,8 +195,8 @@ func (tr *TestRunner) getTestSuites(chartPath, chartRoute string) ([]*TestSuite,
 // chart is the chart object representing the chart being processed.
 //
 // It returns a slice of TestSuite pointers and an error if any occurred during processing.
-func (tr *TestRunner) getV3TestSuites(chartPath, chartRoute string, chart *v3chart.Chart) ([]*TestSuite, error) {
-	resultSuites, err := tr.getTestSuites(chartPath, chartRoute)
+func (tr *TestRunner) getV3TestSuites(chartPath, chartRoute string, chart *v3chart.Chart, chartResult *results.ChartResult) ([]*TestSuite, error) {
+	resultSuites, err := tr.getTestSuites(chartPath, chartRoute, chartResult)
 	if err != nil {
 		return nil, err
 	}
@@ -197,6 +207,7 @@ func (tr *TestRunner) getV3TestSuites(chartPath, chartRoute string, chart *v3cha
 				filepath.Join(chartPath, "charts", subchart.Metadata.Name),
 				filepath.Join(chartRoute, "charts", subchart.Metadata.Name),
 				subchart,
+				chartResult,
 			)
 			if err != nil {
 				continue
@@ -208,30 +219,62 @@ func (tr *TestRunner) getV3TestSuites(chartPath, chartRoute string, chart *v3cha
 }
 
 // runV3SuitesOfChart runs suite files of the chart and print output
-func (tr *TestRunner) runV3SuitesOfChart(suites []*TestSuite, chartPath string) bool {
+func (tr *TestRunner) runV3SuitesOfChart(suites []*TestSuite, chartPath string, chartResult *results.ChartResult) bool {
 	chartPassed := true
 	for _, suite := range suites {
-		snapshotCache, err := snapshot.CreateSnapshotOfSuite(suite.SnapshotFileUrl(), tr.UpdateSnapshot)
+		snapshotCache, err := tr.snapshotLayout(suite, chartPath).CreateSnapshotOfSuite(suite.SnapshotFileUrl(), tr.UpdateSnapshot || tr.SnapshotReview != nil, tr.CI)
 		if err != nil {
-			tr.handleSuiteResult(&results.TestSuiteResult{
+			tr.handleSuiteResult(chartResult, &results.TestSuiteResult{
 				FilePath:  suite.definitionFile,
 				ExecError: err,
 			})
 			chartPassed = false
 			continue
 		}
-		result := suite.RunV3(chartPath, snapshotCache, tr.Failfast, tr.RenderPath, &results.TestSuiteResult{})
+		snapshotCache.UpdateFilter = tr.snapshotUpdateFilter(suite, snapshotCache)
+		suite.WithInlineSnapshots(&InlineSnapshots{
+			IsUpdating:   tr.UpdateSnapshot,
+			ReadOnly:     tr.CI,
+			UpdateFilter: tr.inlineSnapshotUpdateFilter(suite),
+		})
+		result := &results.TestSuiteResult{
+			DisplayName: suite.Name,
+			FilePath:    suite.definitionFile,
+		}
+		tr.reporter.SuiteStarted(result)
+		suite.WithReporter(tr.reporter)
+		suite.RunV3(chartPath, snapshotCache, tr.Failfast, tr.RenderPath, result)
+
+		// Only the snapshots of suites running all their tests are known to be obsolete
+		obsoleteSnapshots := uint(0)
+		if allTestsRun(result) {
+			obsoleteSnapshots = result.SnapshotCounting.Vanished
+		}
+		if tr.CI && obsoleteSnapshots > 0 {
+			result.Passed = false
+			result.ExecError = fmt.Errorf("%d obsolete snapshot(s) in %s, use `--prune-snapshots` to remove them", obsoleteSnapshots, snapshotCache.Filepath)
+		}
 		chartPassed = chartPassed && result.Passed
-		tr.handleSuiteResult(result)
-		tr.testResults = append(tr.testResults, result)
+		tr.handleSuiteResult(chartResult, result)
 
-		_, storeErr := snapshotCache.StoreToFileIfNeeded()
+		snapshotCache.KeepVanished = obsoleteSnapshots == 0 || !tr.removesVanishedSnapshots()
+		stored, storeErr := snapshotCache.StoreToFileIfNeeded()
 		if storeErr != nil {
-			tr.handleSuiteResult(&results.TestSuiteResult{
+			tr.handleSuiteResult(chartResult, &results.TestSuiteResult{
 				FilePath:  suite.SnapshotFileUrl(),
 				ExecError: storeErr,
 			})
 			chartPassed = false
+		} else if stored && !snapshotCache.KeepVanished && tr.PruneSnapshots {
+			tr.result.SnapshotCounting.Pruned += obsoleteSnapshots
+		}
+
+		if _, inlineErr := suite.StoreInlineSnapshots(); inlineErr != nil {
+			tr.handleSuiteResult(chartResult, &results.TestSuiteResult{
+				FilePath:  suite.definitionFile,
+				ExecError: inlineErr,
+			})
+			chartPassed = false
 		}
 
 		if !chartPassed && result.FailFast {
@@ -239,145 +282,187 @@ func (tr *TestRunner) runV3SuitesOfChart(suites []*TestSuite, chartPath string)
 		}
 	}
 
+	if tr.PruneSnapshots || tr.CI {
+		chartPassed = tr.handleOrphanedSnapshots(suites, chartPath, chartResult) && chartPassed
+	}
 	return chartPassed
 }
 
-// handleSuiteResult print suite result and count suites and tests status
-func (tr *TestRunner) handleSuiteResult(result *results.TestSuiteResult) {
-	result.Print(tr.Printer, 0)
-	tr.countSuite(result)
-	for _, testsResult := range result.TestsResult {
-		if testsResult == nil {
-			if tr.Failfast {
-				log.WithField("test-runner", "handle-suite-result").Debug("--failfast skip test")
-			}
-			continue
+// allTestsRun check if all tests of the suite are run without skipping or erroring
+func allTestsRun(result *results.TestSuiteResult) bool {
+	if result.FailFast || result.ExecError != nil {
+		return false
+	}
+	for _, test := range result.TestsResult {
+		if test == nil || test.Skipped || test.ExecError != nil {
+			return false
 		}
-		tr.countTest(testsResult)
 	}
+	return true
 }
 
-// printSummary print summary footer
-func (tr *TestRunner) printSummary(elapsed time.Duration) {
-	summaryFormat := `
-Charts:      %s
-Test Suites: %s
-Tests:       %s
-Snapshot:    %s
-Time:        %s
-`
-	tr.Printer.Println(
-		fmt.Sprintf(
-			summaryFormat,
-			tr.chartCounting.sprint(tr.Printer),
-			tr.suiteCounting.sprint(tr.Printer),
-			tr.testCounting.sprint(tr.Printer),
-			tr.snapshotCounting.sprint(tr.Printer),
-			elapsed.String(),
-		),
-		0,
-	)
+// removesVanishedSnapshots check if the snapshots not compared any more are removed,
+// which happens when pruning or when updating all snapshots
+func (tr *TestRunner) removesVanishedSnapshots() bool {
+	return tr.PruneSnapshots ||
+		(tr.UpdateSnapshot && tr.UpdateSnapshotPattern == nil && tr.SnapshotReview == nil)
+}
 
+// snapshotLayout returns the layout of the snapshot files of the suite of the chart
+func (tr *TestRunner) snapshotLayout(suite *TestSuite, chartPath string) snapshot.Layout {
+	return suite.SnapshotLayout(chartPath, tr.SnapshotDir, tr.SnapshotPerTest)
 }
 
-// printChartHeader print header before suite result of a chart
-func (tr *TestRunner) printChartHeader(chartName, path string) {
-	headerFormat := `
-### Chart [ %s ] %s
-`
-	header := fmt.Sprintf(
-		headerFormat,
-		tr.Printer.Highlight("%s", chartName),
-		tr.Printer.Faint("%s", path),
-	)
-	tr.Printer.Println(header, 0)
+// handleOrphanedSnapshots finds the snapshot files of the suites which belong to no suite,
+// they are removed when pruning, or fail the chart in CI.
+func (tr *TestRunner) handleOrphanedSnapshots(suites []*TestSuite, chartPath string, chartResult *results.ChartResult) bool {
+	suiteFilesByLayout := make(map[snapshot.Layout]map[string]string)
+	for _, suite := range suites {
+		layout := tr.snapshotLayout(suite, chartPath)
+		if _, ok := suiteFilesByLayout[layout]; !ok {
+			suiteFilesByLayout[layout] = make(map[string]string)
+		}
+		suiteFilesByLayout[layout][layout.SnapshotPath(suite.SnapshotFileUrl())] = suite.definitionFile
+	}
+
+	var orphans []string
+	for layout, suiteFiles := range suiteFilesByLayout {
+		orphansOfLayout, err := layout.OrphanedFiles(suiteFiles)
+		if err != nil {
+			log.WithField(LOG_TEST_RUNNER, "handle-orphaned-snapshots").Warn("unable to find orphaned snapshots: ", err)
+			return true
+		}
+		orphans = append(orphans, orphansOfLayout...)
+	}
+	sort.Strings(orphans)
+
+	for _, orphan := range orphans {
+		// Snapshot files are never removed in CI
+		if tr.PruneSnapshots && !tr.CI {
+			if err := os.RemoveAll(orphan); err != nil {
+				log.WithField(LOG_TEST_RUNNER, "handle-orphaned-snapshots").Warn("unable to remove orphaned snapshot: ", err)
+				chartResult.OrphanedSnapshots = append(chartResult.OrphanedSnapshots, orphan)
+				tr.result.SnapshotCounting.Orphaned++
+				continue
+			}
+			chartResult.PrunedSnapshots = append(chartResult.PrunedSnapshots, orphan)
+			tr.result.SnapshotCounting.Pruned++
+		} else {
+			chartResult.OrphanedSnapshots = append(chartResult.OrphanedSnapshots, orphan)
+			tr.result.SnapshotCounting.Orphaned++
+		}
+	}
+	return !tr.CI || len(chartResult.OrphanedSnapshots) == 0
 }
 
-// printErroredChartHeader if chart has exexution error print header with error
-func (tr *TestRunner) printErroredChartHeader(err error) {
-	headerFormat := `
-### ` + tr.Printer.Danger("%s", "Error: ") + ` %s
-`
-	header := fmt.Sprintf(headerFormat, err)
-	tr.Printer.Println(header, 0)
+// snapshotUpdateFilter returns the filter of the snapshots to update of the suite,
+// which is nil when all snapshots are updated
+func (tr *TestRunner) snapshotUpdateFilter(suite *TestSuite, cache *snapshot.Cache) snapshot.UpdateFilter {
+	if tr.UpdateSnapshotPattern == nil && tr.SnapshotReview == nil {
+		return nil
+	}
+
+	return func(test, key string) bool {
+		if tr.UpdateSnapshot && (tr.UpdateSnapshotPattern == nil ||
+			tr.UpdateSnapshotPattern.MatchString(suite.Name) ||
+			tr.UpdateSnapshotPattern.MatchString(test)) {
+			return true
+		}
+		return tr.SnapshotReview.Accepts(cache.FileOf(test), test, key)
+	}
 }
 
-// printSnapshotSummary print snapshot summary in footer
-func (tr *TestRunner) printSnapshotSummary() {
-	if tr.snapshotCounting.failed > 0 {
-		snapshotFormat := `
-Snapshot Summary: %s`
+// inlineSnapshotUpdateFilter returns the filter of the inline snapshots to update of the suite,
+// which is nil when all inline snapshots are updated
+func (tr *TestRunner) inlineSnapshotUpdateFilter(suite *TestSuite) snapshot.UpdateFilter {
+	if tr.UpdateSnapshotPattern == nil {
+		return nil
+	}
 
-		summary := tr.Printer.Danger("%d snapshot failed", tr.snapshotCounting.failed) +
-			fmt.Sprintf(" in %d test suite.", tr.suiteCounting.snapshotFailed) +
-			tr.Printer.Faint("%s", " Check changes and use `-u` to update snapshot.")
+	return func(test, _ string) bool {
+		return tr.UpdateSnapshotPattern.MatchString(suite.Name) || tr.UpdateSnapshotPattern.MatchString(test)
+	}
+}
 
-		tr.Printer.Println(fmt.Sprintf(snapshotFormat, summary), 0)
+// handleSuiteResult add suite result to the chart result, count suites and tests status and report it
+func (tr *TestRunner) handleSuiteResult(chartResult *results.ChartResult, result *results.TestSuiteResult) {
+	chartResult.SuitesResult = append(chartResult.SuitesResult, result)
+	tr.countSuite(result)
+	for _, testsResult := range result.TestsResult {
+		if testsResult == nil {
+			if tr.Failfast {
+				log.WithField("test-runner", "handle-suite-result").Debug("--failfast skip test")
+			}
+			continue
+		}
+		tr.countTest(testsResult)
 	}
+	tr.reporter.SuiteFinished(result)
 }
 
 // countSuite count suite status and snapshot status
 func (tr *TestRunner) countSuite(suite *results.TestSuiteResult) {
+	counting := &tr.result.SuiteCounting
 	if suite.Skipped {
-		tr.suiteCounting.skipped++
+		counting.Skipped++
 	} else if suite.Passed {
-		tr.suiteCounting.passed++
+		counting.Passed++
 	} else {
-		tr.suiteCounting.failed++
+		counting.Failed++
 		if suite.ExecError != nil {
-			tr.suiteCounting.errored++
+			counting.Errored++
 		}
 		if suite.SnapshotCounting.Failed > 0 {
-			tr.suiteCounting.snapshotFailed++
+			counting.SnapshotFailed++
 		}
 	}
-	tr.snapshotCounting.failed += suite.SnapshotCounting.Failed
-	tr.snapshotCounting.passed += suite.SnapshotCounting.Total - suite.SnapshotCounting.Failed
-	tr.snapshotCounting.created += suite.SnapshotCounting.Created
-	tr.snapshotCounting.vanished += suite.SnapshotCounting.Vanished
+	snapshotCounting := &tr.result.SnapshotCounting
+	snapshotCounting.Failed += suite.SnapshotCounting.Failed
+	snapshotCounting.Passed += suite.SnapshotCounting.Total - suite.SnapshotCounting.Failed
+	snapshotCounting.Created += suite.SnapshotCounting.Created
+	snapshotCounting.Vanished += suite.SnapshotCounting.Vanished
 }
 
-// countTest count test status
+// countTest count test status and the status of its assertions
 func (tr *TestRunner) countTest(test *results.TestJobResult) {
+	counting := &tr.result.TestCounting
 	if test.Passed {
-		tr.testCounting.passed++
+		counting.Passed++
 	} else if test.Skipped {
-		tr.testCounting.skipped++
+		counting.Skipped++
 	} else {
-		tr.testCounting.failed++
+		counting.Failed++
 		if test.ExecError != nil {
-			tr.testCounting.errored++
+			counting.Errored++
 		}
 	}
-}
 
-func (tr *TestRunner) countChart(passed bool, err error) {
-	if passed {
-		tr.chartCounting.passed++
-	} else {
-		tr.chartCounting.failed++
-		if err != nil {
-			tr.chartCounting.errored++
+	for _, assertion := range test.AssertsResult {
+		if assertion == nil {
+			continue
+		}
+		if assertion.Skipped {
+			tr.result.AssertionCounting.Skipped++
+		} else if assertion.Passed {
+			tr.result.AssertionCounting.Passed++
+		} else {
+			tr.result.AssertionCounting.Failed++
 		}
 	}
 }
 
-func (tr *TestRunner) writeTestOutput() error {
-	// Check if formatter exits to write
-	if tr.Formatter != nil {
-		// Create outputfile for testsuite
-		writer, ferr := os.Create(tr.OutputFile)
-		if ferr != nil {
-			return ferr
-		}
-		defer writer.Close()
-
-		//
-		jerr := tr.Formatter.WriteTestOutput(tr.testResults, true, writer)
-		if jerr != nil {
-			return jerr
+// countChart count chart status, completes the chart result and report it
+func (tr *TestRunner) countChart(chartResult *results.ChartResult, passed bool, err error) {
+	chartResult.Passed = passed
+	chartResult.ExecError = err
+	tr.result.Passed = tr.result.Passed && passed
+	if passed {
+		tr.result.ChartCounting.Passed++
+	} else {
+		tr.result.ChartCounting.Failed++
+		if err != nil {
+			tr.result.ChartCounting.Errored++
 		}
 	}
-
-	return nil
+	tr.reporter.ChartFinished(chartResult)
 }
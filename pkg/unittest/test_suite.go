@@ -4,15 +4,16 @@ import (
 	"cmp"
 	"errors"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 
 	"github.com/helm-unittest/helm-unittest/internal/common"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/reporter"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/results"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
 	v3loader "helm.sh/helm/v3/pkg/chart/loader"
 	v3util "helm.sh/helm/v3/pkg/chartutil"
 	v3engine "helm.sh/helm/v3/pkg/engine"
@@ -31,17 +32,28 @@ func ParseTestSuiteFile(suiteFilePath, chartRoute string, strict bool, valueFile
 		return []*TestSuite{{chartRoute: chartRoute}}, err
 	}
 
+	testSuites, err := ParseTestSuiteContent(suiteFilePath, chartRoute, string(content), strict, valueFilesSet)
+	for _, testSuite := range testSuites {
+		testSuite.suiteFile = suiteFilePath
+	}
+	return testSuites, err
+}
+
+// ParseTestSuiteContent parse the content of a suite file that contain one or more suites and returns an array of TestSuite,
+// the suiteFilePath is used to resolve the values files and snapshots relative to the suite.
+func ParseTestSuiteContent(suiteFilePath, chartRoute, content string, strict bool, valueFilesSet []string) ([]*TestSuite, error) {
 	// The pattern matches lines that contain only three hyphens (---), which is a common
 	// delimiter used in various file formats (e.g., YAML, Markdown) to separate sections.
 	// The -1 passed as the third argument to Split tells it to return all parts,
 	// including the parts matched by the regular expression pattern.
-	parts := splitterPattern.Split(string(content), -1)
-	log.WithField(common.LOG_TEST_SUITE, "parse-test-suite-file").Debug("suite '", suiteFilePath, "' total parts ", len(parts))
+	parts := splitterPattern.Split(content, -1)
+	log.WithField(common.LOG_TEST_SUITE, "parse-test-suite-content").Debug("suite '", suiteFilePath, "' total parts ", len(parts))
 	var testSuites []*TestSuite
 	for _, part := range parts {
 		if len(strings.TrimSpace(part)) > 0 {
 			testSuite, suiteErr := createTestSuite(suiteFilePath, chartRoute, part, strict, valueFilesSet, false)
 			if testSuite != nil {
+				testSuite.documentIndex = len(testSuites)
 				for _, test := range testSuite.Tests {
 					if test != nil {
 						testSuite.polishSkipSettings(test)
@@ -52,7 +64,7 @@ func ParseTestSuiteFile(suiteFilePath, chartRoute string, strict bool, valueFile
 				testSuites = append(testSuites, testSuite)
 			}
 			if suiteErr != nil {
-				log.WithField(common.LOG_TEST_SUITE, "parse-test-suite-file").Debug("error '", suiteErr.Error(), "' strict ", strict)
+				log.WithField(common.LOG_TEST_SUITE, "parse-test-suite-content").Debug("error '", suiteErr.Error(), "' strict ", strict)
 				return testSuites, suiteErr
 			}
 		}
@@ -202,6 +214,19 @@ func iterateTemplates(template string, suites []*TestSuite, absPath string, char
 	return subYamlErrs, previousSuitesLen, suites
 }
 
+const (
+	// SnapshotKeysOrder keys the snapshots of a test by the order of the snapshot assertions, unless named
+	SnapshotKeysOrder = "order"
+	// SnapshotKeysIdentity keys the snapshots of a test by the template, kind, namespace and name of the document, unless named
+	SnapshotKeysIdentity = "identity"
+)
+
+// The assertions added to the tests of suites validating the Kubernetes objects and custom resources
+const (
+	validKubernetesObjectAssertType = "isValidKubernetesObject"
+	validCustomResourceAssertType   = "isValidCustomResource"
+)
+
 // TestSuite defines scope and templates to render and tests to run
 type TestSuite struct {
 	Name             string `yaml:"suite"`
@@ -237,9 +262,45 @@ type TestSuite struct {
 	fromRender bool
 	// An identifier to append to snapshot files
 	SnapshotId string `yaml:"snapshotId"`
-	Skip       struct {
+	// How the snapshots of the tests are keyed, by order or by identity of the document
+	SnapshotKeys string `yaml:"snapshotKeys"`
+	// The directory to store the snapshot file in, relative to the suite file
+	SnapshotDir string `yaml:"snapshotDir"`
+	// Validates the rendered documents of each test are valid Kubernetes objects, like isValidKubernetesObject
+	ValidateKubernetesObjects bool `yaml:"validateKubernetesObjects"`
+	// Validates the rendered custom resources of each test against their CRD, like isValidCustomResource
+	ValidateCustomResources bool `yaml:"validateCustomResources"`
+	// The directories of CRDs which are not part of the chart, relative to the suite file
+	CrdDirs []string `yaml:"crdDirs"`
+	Skip    struct {
 		Reason string `yaml:"reason"`
 	} `yaml:"skip"`
+	// receives the results of the tests while running
+	reporter reporter.Reporter
+	// the suite file the suite is read from, empty for rendered and in-memory suites
+	suiteFile string
+	// the index of the suite in the suite file
+	documentIndex int
+	// collects the inline snapshots to write to the suite file
+	inlineSnapshots *InlineSnapshots
+}
+
+// WithReporter sets the reporter receiving the results of the tests while running.
+func (s *TestSuite) WithReporter(r reporter.Reporter) {
+	s.reporter = r
+}
+
+// WithInlineSnapshots sets the inline snapshots written by the tests, which are only written for suites read from a file.
+func (s *TestSuite) WithInlineSnapshots(snapshots *InlineSnapshots) {
+	s.inlineSnapshots = snapshots
+}
+
+// StoreInlineSnapshots writes the inline snapshots of the tests to the suite file and returns whether it is written.
+func (s *TestSuite) StoreInlineSnapshots() (bool, error) {
+	if s.suiteFile == "" || s.inlineSnapshots == nil {
+		return false, nil
+	}
+	return s.inlineSnapshots.StoreToSuiteFile(s.suiteFile, s.documentIndex)
 }
 
 // RunV3 runs all the test jobs defined in TestSuite.
@@ -284,6 +345,7 @@ func (s *TestSuite) polishTestJobsPathInfo() {
 			s.polishKubernetesProviderSettings(test)
 			s.polishChartSettings(test)
 			s.polishSkipSettings(test)
+			s.polishValidationSettings(test)
 
 			// Make deep clone of global set
 			test.globalSet = copySet(s.Set)
@@ -320,6 +382,33 @@ func (s *TestSuite) polishSkipSettings(test *TestJob) {
 	}
 }
 
+// polishValidationSettings adds the isValidKubernetesObject and isValidCustomResource assertions to the test
+// when the suite validates them, unless the test asserts them itself or asserts a failed rendering
+func (s *TestSuite) polishValidationSettings(test *TestJob) {
+	for _, assertion := range test.Assertions {
+		if assertion != nil && !assertion.requireRenderSuccess {
+			return
+		}
+	}
+
+	if s.ValidateKubernetesObjects {
+		addAssertionIfMissing(test, validKubernetesObjectAssertType, validators.IsValidKubernetesObjectValidator{})
+	}
+	if s.ValidateCustomResources {
+		addAssertionIfMissing(test, validCustomResourceAssertType, validators.IsValidCustomResourceValidator{CrdDirs: s.CrdDirs})
+	}
+}
+
+// addAssertionIfMissing adds the assertion of the assert type to the test, unless the test has one already
+func addAssertionIfMissing(test *TestJob, assertType string, validator validators.Validatable) {
+	for _, assertion := range test.Assertions {
+		if assertion != nil && assertion.AssertType == assertType {
+			return
+		}
+	}
+	test.Assertions = append(test.Assertions, newAssertion(assertType, validator))
+}
+
 // override release settings in testjobs when defined in testsuite
 func (s *TestSuite) polishReleaseSettings(test *TestJob) {
 
@@ -382,19 +471,25 @@ func (s *TestSuite) runV3TestJobs(
 	jobResults := make([]*results.TestJobResult, len(s.Tests))
 	skipped := 0
 
+	testReporter := s.reporter
+	if testReporter == nil {
+		testReporter = reporter.NopReporter{}
+	}
+	customResourceSchemas := &validators.CustomResourceSchemas{}
+
 	for idx, testJob := range s.Tests {
-		// (Re)load the chart used by this suite (with logging temporarily disabled)
-		log.SetOutput(io.Discard)
+		// (Re)load the chart used by this suite
 		chart, _ := v3loader.Load(chartPath)
-		log.SetOutput(os.Stdout)
 
 		var jobResult *results.TestJobResult
 		job := results.TestJobResult{DisplayName: testJob.Name, Index: idx}
+		testReporter.TestStarted(&job)
 
 		if testJob.Skip.Reason != "" {
 			job.Skipped = true
 			skipped++
 			jobResults[idx] = &job
+			testReporter.TestFinished(&job)
 			if idx == 0 {
 				result.Pass = true
 			}
@@ -404,9 +499,18 @@ func (s *TestSuite) runV3TestJobs(
 				WithFailFast(failFast),
 				WithPostRendererConfig(s.PostRendererConfig),
 				WithDocumentSelector(testJob.DocumentSelector),
+				WithSnapshotKeys(s.SnapshotKeys),
+				WithInlineSnapshots(s.writableInlineSnapshots(), idx),
+				WithCustomResourceSchemas(customResourceSchemas),
 			))
 			jobResult = testJob.RunV3(&job)
 			jobResults[idx] = jobResult
+			for _, assertResult := range jobResult.AssertsResult {
+				if assertResult != nil {
+					testReporter.AssertionFinished(assertResult)
+				}
+			}
+			testReporter.TestFinished(jobResult)
 			if idx == 0 {
 				result.Pass = jobResult.Passed
 			}
@@ -422,6 +526,14 @@ func (s *TestSuite) runV3TestJobs(
 	return &result
 }
 
+// writableInlineSnapshots returns the inline snapshots when they can be written to the suite file, nil otherwise
+func (s *TestSuite) writableInlineSnapshots() *InlineSnapshots {
+	if s.suiteFile == "" {
+		return nil
+	}
+	return s.inlineSnapshots
+}
+
 func (s *TestSuite) validateTestSuite() error {
 	if len(s.Tests) == 0 {
 		return fmt.Errorf("no tests found")
@@ -430,6 +542,12 @@ func (s *TestSuite) validateTestSuite() error {
 		return fmt.Errorf("helm chart based test suites must include `suite` field")
 	}
 
+	switch s.SnapshotKeys {
+	case "", SnapshotKeysOrder, SnapshotKeysIdentity:
+	default:
+		return fmt.Errorf("invalid snapshotKeys %q, expected %q or %q", s.SnapshotKeys, SnapshotKeysOrder, SnapshotKeysIdentity)
+	}
+
 	for _, testJob := range s.Tests {
 		if len(testJob.Assertions) == 0 {
 			log.WithField(common.LOG_TEST_SUITE, "validate-test-suite").Debugln("no asserts found", testJob)
@@ -447,3 +565,27 @@ func (s *TestSuite) SnapshotFileUrl() string {
 	}
 	return s.definitionFile
 }
+
+// SnapshotLayout returns the layout of the snapshot files of the suite of the chart, the snapshotDir of the suite
+// is relative to the suite file and the snapshotDir of the run to the chart, perTest stores a file per test
+func (s *TestSuite) SnapshotLayout(chartPath, snapshotDir string, perTest bool) snapshot.Layout {
+	layout := snapshot.Layout{PerTest: perTest}
+	switch {
+	case s.SnapshotDir != "":
+		suiteDir := filepath.Dir(s.definitionFile)
+		layout.Dir = resolvePath(suiteDir, s.SnapshotDir)
+		layout.Root = suiteDir
+	case snapshotDir != "":
+		layout.Dir = resolvePath(chartPath, snapshotDir)
+		layout.Root = chartPath
+	}
+	return layout
+}
+
+// resolvePath returns the path relative to the dir, or the path itself when absolute
+func resolvePath(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
@@ -34,6 +34,7 @@ const testV3WithSubSubFolderChart string = "../../test/data/v3/with-subsubcharts
 const testV3WithFilesChart string = "../../test/data/v3/with-files"
 const testV3WithFailingTemplateChart string = "../../test/data/v3/failing-template"
 const testV3WithSchemaChart string = "../../test/data/v3/with-schema"
+const testV3WithCRDsChart string = "../../test/data/v3/with-crds"
 const testV3WithPackagedChart string = "../../test/data/v3/with-packaged-0.1.0.tgz"
 const testV3WithPackagedSubChart string = "../../test/data/v3/with-subchart/charts/postgresql-0.8.3.tgz"
 const testV3GlobalDoubleChart string = "../../test/data/v3/global-double-setting"
@@ -326,6 +327,23 @@ func TestV3RenderSuites_InvalidDirectory(t *testing.T) {
 	a.ErrorIs(err, os.ErrNotExist)
 }
 
+func TestV3ParseTestSuiteInvalidSnapshotKeysFail(t *testing.T) {
+	a := assert.New(t)
+	suiteFile := path.Join(t.TempDir(), "deployment_test.yaml")
+	suite := `
+suite: test deployment
+snapshotKeys: random
+tests:
+  - it: should snapshot
+    asserts:
+      - matchSnapshot: {}
+`
+	a.NoError(writeToFile(suite, suiteFile))
+
+	_, err := ParseTestSuiteFile(suiteFile, "basic", false, []string{})
+	a.EqualError(err, `invalid snapshotKeys "random", expected "order" or "identity"`)
+}
+
 func TestV3RenderSuites_LoadError(t *testing.T) {
 	a := assert.New(t)
 	tmp := t.TempDir()
@@ -447,7 +465,7 @@ tests:
 	testSuite := TestSuite{}
 	common.YmlUnmarshalTestHelper(suiteDoc, &testSuite, t)
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_noasserts_template_test.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_noasserts_template_test.yaml"), false, false)
 	suiteResult := testSuite.RunV3(testV3BasicChart, cache, true, "", &results.TestSuiteResult{})
 
 	validateTestResultAndSnapshots(t, suiteResult, false, "validate empty asserts", 1, 0, 0, 0, 0)
@@ -486,7 +504,7 @@ tests:
 	testSuite := TestSuite{}
 	common.YmlUnmarshalTestHelper(suiteDoc, &testSuite, t)
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_multiple_template_test.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_multiple_template_test.yaml"), false, false)
 	suiteResult := testSuite.RunV3(testV3BasicChart, cache, true, "", &results.TestSuiteResult{})
 
 	validateTestResultAndSnapshots(t, suiteResult, true, "validate metadata", 1, 5, 5, 0, 0)
@@ -510,7 +528,7 @@ tests:
 	testSuite := TestSuite{}
 	common.YmlUnmarshalTestHelper(suiteDoc, &testSuite, t)
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_suite_test.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_suite_test.yaml"), false, false)
 	suiteResult := testSuite.RunV3(testV3BasicChart, cache, true, "", &results.TestSuiteResult{})
 
 	validateTestResultAndSnapshots(t, suiteResult, true, "test suite name", 1, 2, 2, 0, 0)
@@ -546,7 +564,7 @@ tests:
 	testSuite := TestSuite{}
 	common.YmlUnmarshalTestHelper(suiteDoc, &testSuite, t)
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_suite_override_test.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_suite_override_test.yaml"), false, false)
 	suiteResult := testSuite.RunV3(testV3BasicChart, cache, true, "", &results.TestSuiteResult{})
 
 	validateTestResultAndSnapshots(t, suiteResult, true, "test suite name", 1, 1, 1, 0, 0)
@@ -569,7 +587,7 @@ tests:
 	testSuite := TestSuite{}
 	common.YmlUnmarshalTestHelper(suiteDoc, &testSuite, t)
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_failed_suite_test.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_failed_suite_test.yaml"), false, false)
 	suiteResult := testSuite.RunV3(testV3BasicChart, cache, true, "", &results.TestSuiteResult{})
 
 	validateTestResultAndSnapshots(t, suiteResult, false, "test suite name", 1, 0, 0, 0, 0)
@@ -592,7 +610,7 @@ tests:
 	testSuite := TestSuite{}
 	common.YmlUnmarshalTestHelper(suiteDoc, &testSuite, t)
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_subfolder_test.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_subfolder_test.yaml"), false, false)
 	suiteResult := testSuite.RunV3(testV3WithSubFolderChart, cache, true, "", &results.TestSuiteResult{})
 
 	validateTestResultAndSnapshots(t, suiteResult, true, "test suite name", 1, 2, 2, 0, 0)
@@ -614,7 +632,7 @@ tests:
 	testSuite := TestSuite{}
 	common.YmlUnmarshalTestHelper(suiteDoc, &testSuite, t)
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_subchart_test.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_subchart_test.yaml"), false, false)
 	suiteResult := testSuite.RunV3(testV3WithSubChart, cache, true, "", &results.TestSuiteResult{})
 
 	validateTestResultAndSnapshots(t, suiteResult, true, "test suite with subchart", 1, 1, 1, 0, 0)
@@ -658,7 +676,7 @@ tests:
 	testSuite := TestSuite{}
 	common.YmlUnmarshalTestHelper(suiteDoc, &testSuite, t)
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_subchartwithtrimming_test.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_subchartwithtrimming_test.yaml"), false, false)
 	suiteResult := testSuite.RunV3(testV3WithSubChart, cache, true, "", &results.TestSuiteResult{})
 
 	validateTestResultAndSnapshots(t, suiteResult, true, "test cert-manager rbac with trimming", 1, 0, 0, 0, 0)
@@ -688,7 +706,7 @@ tests:
 	testSuite := TestSuite{}
 	common.YmlUnmarshalTestHelper(suiteDoc, &testSuite, t)
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_subchartwithalias_test.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_subchartwithalias_test.yaml"), false, false)
 	suiteResult := testSuite.RunV3(testV3WithSubChart, cache, true, "", &results.TestSuiteResult{})
 
 	validateTestResultAndSnapshots(t, suiteResult, true, "test suite with subchart", 2, 2, 2, 0, 0)
@@ -795,7 +813,7 @@ tests:
 	testSuite := TestSuite{}
 	common.YmlUnmarshalTestHelper(suiteDoc, &testSuite, t)
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_nameoverride_failed_suite_test.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_nameoverride_failed_suite_test.yaml"), false, false)
 	suiteResult := testSuite.RunV3(testV3BasicChart, cache, true, "", &results.TestSuiteResult{})
 
 	validateTestResultAndSnapshots(t, suiteResult, true, "test suite name too long", 1, 0, 0, 0, 0)
@@ -1474,7 +1492,7 @@ func TestV3RunSuiteWithSkipTests(t *testing.T) {
 		},
 	}
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "non-empty-snapshot.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "non-empty-snapshot.yaml"), false, false)
 	cases := []struct {
 		failFast bool
 	}{
@@ -1510,7 +1528,7 @@ func TestV3RunSuiteWithSuiteLevelSkip(t *testing.T) {
 		},
 	}
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "non-empty-snapshot.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "non-empty-snapshot.yaml"), false, false)
 
 	cases := []struct {
 		failFast bool
@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/helm-unittest/helm-unittest/internal/common"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/yamldiff"
 	"github.com/pmezard/go-difflib/difflib"
 )
 
@@ -25,6 +28,42 @@ type SnapshotComparer interface {
 	CompareToSnapshot(content interface{}) *snapshot.CompareResult
 }
 
+// SnapshotKey identifies a snapshot by name or by the document of the content, instead of the order of comparing
+type SnapshotKey struct {
+	// Name is the explicit name of the snapshot
+	Name string
+	// Identity is the template, kind, namespace and name of the document of the content
+	Identity string
+}
+
+// KeyedSnapshotComparer provide CompareToKeyedSnapshot utility to validator
+type KeyedSnapshotComparer interface {
+	CompareToKeyedSnapshot(key SnapshotKey, content interface{}) *snapshot.CompareResult
+}
+
+// InlineSnapshotUpdater provide UpdateInlineSnapshot utility to validator
+type InlineSnapshotUpdater interface {
+	// UpdateInlineSnapshot writes the content as the inline snapshot of the assertion, when the snapshot is missing
+	// or when the inline snapshots are updated, and returns whether the content is written
+	UpdateInlineSnapshot(content interface{}, missing bool) bool
+	// IsReadOnly returns whether the inline snapshots are never written, like in CI mode
+	IsReadOnly() bool
+}
+
+// ReleaseValidatable validators validate the documents of all templates of the release at once,
+// instead of the documents of each selected template
+type ReleaseValidatable interface {
+	Validatable
+	ValidatesRelease()
+}
+
+// SingleTemplateValidatable validators validate the documents of a single template,
+// the assertion fails when it selects several templates
+type SingleTemplateValidatable interface {
+	Validatable
+	ValidatesSingleTemplate()
+}
+
 // ValidateContext the context passed to validators
 type ValidateContext struct {
 	Docs         []common.K8sManifest
@@ -33,6 +72,93 @@ type ValidateContext struct {
 	SnapshotComparer
 	RenderError error
 	FailFast    bool
+	// Template is the template file of the validated documents
+	Template string
+	// Release is the documents of all rendered templates by template file, for ReleaseValidatable validators
+	Release map[string][]common.K8sManifest
+	// InlineSnapshotUpdater writes the inline snapshot of the assertion, inline snapshots are never written when nil
+	InlineSnapshotUpdater InlineSnapshotUpdater
+	// BaseDirectory is the directory of the test suite, to resolve the files of assertions relative to the suite
+	BaseDirectory string
+	// CustomResourceDefinitions are the CRD files of the chart
+	CustomResourceDefinitions [][]byte
+	// CustomResourceSchemas caches the schemas of the CustomResourceDefinitions, they are parsed for every assertion when nil
+	CustomResourceSchemas *CustomResourceSchemas
+	// RenderValues are the values the chart is rendered with, like .Release, .Values and .Capabilities in the templates
+	RenderValues map[string]interface{}
+}
+
+// compareToSnapshot compare the content to the snapshot with the key,
+// or to the next snapshot when the comparer does not support keyed snapshots
+func (c *ValidateContext) compareToSnapshot(key SnapshotKey, content interface{}) *snapshot.CompareResult {
+	if keyed, ok := c.SnapshotComparer.(KeyedSnapshotComparer); ok {
+		return keyed.CompareToKeyedSnapshot(key, content)
+	}
+	return c.CompareToSnapshot(content)
+}
+
+// updateInlineSnapshot writes the content as the inline snapshot and returns whether it is written
+func (c *ValidateContext) updateInlineSnapshot(content interface{}, missing bool) bool {
+	return c.InlineSnapshotUpdater != nil && c.InlineSnapshotUpdater.UpdateInlineSnapshot(content, missing)
+}
+
+// inlineSnapshotsReadOnly returns whether the inline snapshots are not written as in CI mode
+func (c *ValidateContext) inlineSnapshotsReadOnly() bool {
+	return c.InlineSnapshotUpdater != nil && c.InlineSnapshotUpdater.IsReadOnly()
+}
+
+// documentIdentity returns the template, kind, namespace and name of the manifest,
+// which is empty when the manifest has no kind or name
+func documentIdentity(template string, manifest common.K8sManifest) string {
+	kind, _ := manifest["kind"].(string)
+	metadata := asMap(manifest["metadata"])
+	name, _ := metadata["name"].(string)
+	if kind == "" || name == "" {
+		return ""
+	}
+
+	identity := kind + "/" + name
+	if namespace, _ := metadata["namespace"].(string); namespace != "" {
+		identity = kind + "/" + namespace + "/" + name
+	}
+	if template != "" {
+		identity = template + " " + identity
+	}
+	return identity
+}
+
+// asMap returns the nested map of a manifest, which is decoded either as map or as common.K8sManifest
+func asMap(value interface{}) map[string]interface{} {
+	switch m := value.(type) {
+	case map[string]interface{}:
+		return m
+	case common.K8sManifest:
+		return m
+	}
+	return nil
+}
+
+// missingSnapshotMessage returns the customized message of a snapshot not created in CI mode
+func missingSnapshotMessage(compared *snapshot.CompareResult) string {
+	return " snapshot " + snapshotName(compared) + " to exist, snapshots are not created in CI mode"
+}
+
+// snapshotName returns the name of the compared snapshot to show
+func snapshotName(compared *snapshot.CompareResult) string {
+	if compared.Key != "" {
+		return compared.Key
+	}
+	return strconv.Itoa(int(compared.Index))
+}
+
+// valuesOfPath returns the values of the path of the manifest, decoded by the formats of decode,
+// like base64 and then json, and the values of the sub path in the decoded content
+func valuesOfPath(manifest common.K8sManifest, path string, decode interface{}, subPath string) ([]interface{}, error) {
+	formats, err := valueutils.DecodeFormats(decode)
+	if err != nil {
+		return nil, err
+	}
+	return valueutils.GetValueOfDecodedPath(manifest, path, formats, subPath)
 }
 
 func (c *ValidateContext) getManifests() []common.K8sManifest {
@@ -49,6 +175,12 @@ type Validatable interface {
 	Validate(context *ValidateContext) (bool, []string)
 }
 
+// UnsetFieldsReceiver validators receive the names of their fields missing from the parameters of the assertion,
+// to tell a parameter given as null from a missing one
+type UnsetFieldsReceiver interface {
+	WithUnsetFields(fields []string)
+}
+
 // setFailFormat,
 // setting the formatting for the failure message.
 func setFailFormat(not, path, actual, diff bool, customize string) string {
@@ -122,6 +254,26 @@ func diff(expected string, actual string) string {
 	return diff
 }
 
+// semanticDiff returns the changes by path between the expected and actual maps or lists,
+// and falls back to the line diff of their yaml for other values
+func semanticDiff(expected, actual interface{}, expectedYAML, actualYAML string) string {
+	if yamldiff.IsStructured(expected) && yamldiff.IsStructured(actual) {
+		if changes, err := yamldiff.Compare(expected, actual); err == nil && len(changes) > 0 {
+			return yamldiff.Format(changes)
+		}
+	}
+	return diff(expectedYAML, actualYAML)
+}
+
+// semanticDiffYAML returns the semanticDiff of the expected and actual yaml content
+func semanticDiffYAML(expectedYAML, actualYAML string) string {
+	var expected, actual interface{}
+	if common.YmlUnmarshal(expectedYAML, &expected) != nil || common.YmlUnmarshal(actualYAML, &actual) != nil {
+		return diff(expectedYAML, actualYAML)
+	}
+	return semanticDiff(expected, actual, expectedYAML, actualYAML)
+}
+
 // uniform the content without invalid characters and correct line-endings
 func uniformContent(content interface{}) string {
 	actual := fmt.Sprintf("%v", content)
@@ -3,6 +3,7 @@ package validators_test
 import (
 	"github.com/helm-unittest/helm-unittest/internal/common"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -18,3 +19,26 @@ func (m *mockSnapshotComparer) CompareToSnapshot(content interface{}) *snapshot.
 	args := m.Called(content)
 	return args.Get(0).(*snapshot.CompareResult)
 }
+
+type mockKeyedSnapshotComparer struct {
+	mockSnapshotComparer
+}
+
+func (m *mockKeyedSnapshotComparer) CompareToKeyedSnapshot(key validators.SnapshotKey, content interface{}) *snapshot.CompareResult {
+	args := m.Called(key, content)
+	return args.Get(0).(*snapshot.CompareResult)
+}
+
+type mockInlineSnapshotUpdater struct {
+	mock.Mock
+}
+
+func (m *mockInlineSnapshotUpdater) UpdateInlineSnapshot(content interface{}, missing bool) bool {
+	args := m.Called(content, missing)
+	return args.Bool(0)
+}
+
+func (m *mockInlineSnapshotUpdater) IsReadOnly() bool {
+	args := m.Called()
+	return args.Bool(0)
+}
@@ -7,7 +7,6 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/helm-unittest/helm-unittest/internal/common"
-	"github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
 )
 
 // ContainsValidator validate whether value of Path is an array and contains Content
@@ -16,6 +15,8 @@ type ContainsValidator struct {
 	Content interface{}
 	Count   *int
 	Any     bool
+	Decode  interface{}
+	SubPath string
 }
 
 func (v ContainsValidator) failInfo(actual interface{}, manifestIndex, assertIndex int, not bool) []string {
@@ -108,7 +109,7 @@ func (v ContainsValidator) validateSingle(singleActual []interface{}, manifestIn
 }
 
 func (v ContainsValidator) validateManifest(manifest common.K8sManifest, manifestIndex int, context *ValidateContext) (bool, []string) {
-	actual, err := valueutils.GetValueOfSetPath(manifest, v.Path)
+	actual, err := valuesOfPath(manifest, v.Path, v.Decode, v.SubPath)
 	if err != nil {
 		return false, splitInfof(errorFormat, manifestIndex, -1, err.Error())
 	}
@@ -2,14 +2,18 @@ package validators
 
 // EqualOrGreaterValidator validate whether the value of Path is greater or equal to Value
 type EqualOrGreaterValidator struct {
-	Path  string
-	Value interface{}
+	Path    string
+	Value   interface{}
+	Decode  interface{}
+	SubPath string
 }
 
 // Validate implement Validatable
 func (g EqualOrGreaterValidator) Validate(context *ValidateContext) (bool, []string) {
 	operatorValidator := operatorValidator{
 		Path:           g.Path,
+		Decode:         g.Decode,
+		SubPath:        g.SubPath,
 		Value:          g.Value,
 		ComparisonType: "greater",
 	}
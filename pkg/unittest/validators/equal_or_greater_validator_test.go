@@ -38,6 +38,48 @@ func TestEqualOrGreaterValidatorOk(t *testing.T) {
 			value:    "600m",
 			expected: true,
 		},
+		{
+			name:     "test case 4: int and float64 values",
+			doc:      "spec: 4",
+			path:     "spec",
+			value:    3.5,
+			expected: true,
+		},
+		{
+			name:     "test case 5: quantity and float64 values",
+			doc:      "cpu: 500m",
+			path:     "cpu",
+			value:    0.4,
+			expected: true,
+		},
+		{
+			name:     "test case 6: int and quantity values",
+			doc:      "cpu: 1",
+			path:     "cpu",
+			value:    "500m",
+			expected: true,
+		},
+		{
+			name:     "test case 7: quantity values with different suffixes",
+			doc:      "memory: 1Gi",
+			path:     "memory",
+			value:    "512Mi",
+			expected: true,
+		},
+		{
+			name:     "test case 8: duration values",
+			doc:      "timeout: 1m",
+			path:     "timeout",
+			value:    "30s",
+			expected: true,
+		},
+		{
+			name:     "test case 9: int and string(int) values",
+			doc:      "value: 50",
+			path:     "value",
+			value:    "50",
+			expected: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -116,6 +158,32 @@ func TestEqualOrGreaterValidatorFail(t *testing.T) {
 				"\tthe actual '600m' is not greater or equal to the expected '690m'",
 			},
 		},
+		{
+			name:  "test case 5: quantity and int values",
+			doc:   "cpu: 500m",
+			path:  "cpu",
+			value: 1,
+			errorMsg: []string{
+				"DocumentIndex:\t0",
+				"ValuesIndex:\t0",
+				"Path:\tcpu",
+				"Expected to be greater then or equal to, got:",
+				"\tthe actual '500m' is not greater or equal to the expected '1'",
+			},
+		},
+		{
+			name:  "test case 6: duration values",
+			doc:   "timeout: 90s",
+			path:  "timeout",
+			value: "2m",
+			errorMsg: []string{
+				"DocumentIndex:\t0",
+				"ValuesIndex:\t0",
+				"Path:\ttimeout",
+				"Expected to be greater then or equal to, got:",
+				"\tthe actual '90s' is not greater or equal to the expected '2m'",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -225,25 +293,25 @@ func TestEqualOrGreaterValidatorWhenTypesDoNotMatch(t *testing.T) {
 		errorMsg        []string
 	}{
 		{
-			name:     "test case 1: compare int and string types",
-			doc:      "value: 500m",
+			name:     "test case 1: compare string and int types",
+			doc:      "value: abc",
 			path:     "value",
 			value:    5,
 			errorMsg: []string{"DocumentIndex:	0", "ValuesIndex:	0", "Error:", "	actual 'string' and expected 'int' types do not match"},
 		},
 		{
-			name:     "test case 1: compare string and int types",
+			name:     "test case 2: compare int and string types",
 			doc:      "value: 50",
 			path:     "value",
-			value:    "50m",
+			value:    "50x",
 			errorMsg: []string{"DocumentIndex:	0", "ValuesIndex:	0", "Error:", "	actual 'int' and expected 'string' types do not match"},
 		},
 		{
-			name:     "test case 1: compare string and string(int) types",
-			doc:      "value: 50",
+			name:     "test case 3: compare bool types",
+			doc:      "value: true",
 			path:     "value",
-			value:    "50",
-			errorMsg: []string{"DocumentIndex:	0", "ValuesIndex:	0", "Error:", "	actual 'int' and expected 'string' types do not match"},
+			value:    false,
+			errorMsg: []string{"DocumentIndex:	0", "ValuesIndex:	0", "Error:", "	unsupported type 'bool'"},
 		},
 	}
 
@@ -272,8 +340,8 @@ func TestEqualOrGreaterValidatorWhenTypesDoNotMatchFailFast(t *testing.T) {
 		errorMsg        []string
 	}{
 		{
-			name:     "test case 1: compare int and string types",
-			doc:      "value: 500m",
+			name:     "test case 1: compare string and int types",
+			doc:      "value: abc",
 			path:     "value",
 			value:    5,
 			errorMsg: []string{"DocumentIndex:	0", "ValuesIndex:	0", "Error:", "	actual 'string' and expected 'int' types do not match"},
@@ -331,3 +399,23 @@ func TestEqualOrGreaterValidatorWhenNoManifestNegativeOk(t *testing.T) {
 	assert.True(t, pass)
 	assert.Equal(t, []string{}, diff)
 }
+
+func TestEqualOrGreaterValidatorWhenNegativeFail(t *testing.T) {
+	v := EqualOrGreaterValidator{
+		Path:  "cpu",
+		Value: 1.0,
+	}
+	pass, diff := v.Validate(&ValidateContext{
+		Docs:     []common.K8sManifest{makeManifest("cpu: 1.1")},
+		Negative: true,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"ValuesIndex:\t0",
+		"Path:\tcpu",
+		"Expected NOT to be greater then or equal to, got:",
+		"\tthe actual '1.1' is greater or equal to the expected '1'",
+	}, diff)
+}
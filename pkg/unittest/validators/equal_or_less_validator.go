@@ -2,14 +2,18 @@ package validators
 
 // EqualOrLessValidator validate whether the value of Path is less or equal to Value
 type EqualOrLessValidator struct {
-	Path  string
-	Value interface{}
+	Path    string
+	Value   interface{}
+	Decode  interface{}
+	SubPath string
 }
 
 // Validate implement Validatable
 func (l EqualOrLessValidator) Validate(context *ValidateContext) (bool, []string) {
 	operatorValidator := operatorValidator{
 		Path:           l.Path,
+		Decode:         l.Decode,
+		SubPath:        l.SubPath,
 		Value:          l.Value,
 		ComparisonType: "less",
 	}
@@ -38,6 +38,27 @@ func TestEqualOrLessValidatorOk(t *testing.T) {
 			value:    "680m",
 			expected: true,
 		},
+		{
+			name:     "Test case 4: float64 and int ok",
+			doc:      "value: 0.3",
+			path:     "value",
+			value:    1,
+			expected: true,
+		},
+		{
+			name:     "Test case 5: quantity and int ok",
+			doc:      "cpu: 500m",
+			path:     "cpu",
+			value:    1,
+			expected: true,
+		},
+		{
+			name:     "Test case 6: duration ok",
+			doc:      "interval: 30s",
+			path:     "interval",
+			value:    "1m",
+			expected: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -181,7 +202,7 @@ spec:
 }
 
 func TestEqualOrLessValidatorWhenTypesDoNotMatch(t *testing.T) {
-	var actual = "value: 0.3"
+	var actual = "value: [1]"
 	manifest := makeManifest(actual)
 
 	v := EqualOrLessValidator{
@@ -197,7 +218,7 @@ func TestEqualOrLessValidatorWhenTypesDoNotMatch(t *testing.T) {
 		"DocumentIndex:	0",
 		"ValuesIndex:	0",
 		"Error:",
-		"	actual 'float64' and expected 'int' types do not match",
+		"	actual '[]interface {}' and expected 'int' types do not match",
 	}, diff)
 }
 
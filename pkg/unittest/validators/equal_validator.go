@@ -8,7 +8,6 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/helm-unittest/helm-unittest/internal/common"
-	"github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
 )
 
 // EqualValidator validate whether the value of Path equal to Value
@@ -16,6 +15,8 @@ type EqualValidator struct {
 	Path         string
 	Value        interface{}
 	DecodeBase64 bool `yaml:"decodeBase64"`
+	Decode       interface{}
+	SubPath      string
 }
 
 func (a EqualValidator) failInfo(actual interface{}, manifestIndex, actualIndex int, not bool) []string {
@@ -43,12 +44,12 @@ func (a EqualValidator) failInfo(actual interface{}, manifestIndex, actualIndex
 		a.Path,
 		expectedYAML,
 		actualYAML,
-		diff(expectedYAML, actualYAML),
+		semanticDiff(a.Value, actual, expectedYAML, actualYAML),
 	)
 }
 
 func (a EqualValidator) validateManifest(manifest common.K8sManifest, manifestIndex int, context *ValidateContext) (bool, []string) {
-	actuals, err := valueutils.GetValueOfSetPath(manifest, a.Path)
+	actuals, err := valuesOfPath(manifest, a.Path, a.Decode, a.SubPath)
 	if err != nil {
 		return false, splitInfof(errorFormat, manifestIndex, -1, err.Error())
 	}
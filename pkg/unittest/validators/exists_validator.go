@@ -1,12 +1,10 @@
 package validators
 
-import (
-	"github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
-)
-
 // ExistsValidator validate value of Path id kind
 type ExistsValidator struct {
-	Path string
+	Path    string
+	Decode  interface{}
+	SubPath string
 }
 
 func (v ExistsValidator) failInfo(manifestIndex, actualIndex int, not bool) []string {
@@ -34,7 +32,7 @@ func (v ExistsValidator) Validate(context *ValidateContext) (bool, []string) {
 	validateErrors := make([]string, 0)
 
 	for idx, manifest := range manifests {
-		actual, err := valueutils.GetValueOfSetPath(manifest, v.Path)
+		actual, err := valuesOfPath(manifest, v.Path, v.Decode, v.SubPath)
 		if err != nil {
 			validateSuccess = false
 			errorMessage := splitInfof(errorFormat, idx, -1, err.Error())
@@ -13,7 +13,7 @@ func TestExistsValidatorWhenOk(t *testing.T) {
 	doc := "a:"
 	manifest := makeManifest(doc)
 
-	v := ExistsValidator{"a"}
+	v := ExistsValidator{Path: "a"}
 	pass, diff := v.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
 	})
@@ -28,7 +28,7 @@ a:
 `
 	manifest := makeManifest(doc)
 
-	v := ExistsValidator{"a[0]"}
+	v := ExistsValidator{Path: "a[0]"}
 	pass, diff := v.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
 	})
@@ -40,7 +40,7 @@ func TestExistsValidatorWhenNegativeAndOk(t *testing.T) {
 	doc := "a: 0"
 	manifest := makeManifest(doc)
 
-	v := ExistsValidator{"b"}
+	v := ExistsValidator{Path: "b"}
 	pass, diff := v.Validate(&ValidateContext{
 		Docs:     []common.K8sManifest{manifest},
 		Negative: true,
@@ -56,7 +56,7 @@ func TestExistsValidatorWhenFail(t *testing.T) {
 
 	log.SetLevel(log.DebugLevel)
 
-	v := ExistsValidator{"b"}
+	v := ExistsValidator{Path: "b"}
 	pass, diff := v.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
 	})
@@ -72,7 +72,7 @@ func TestExistsValidatorWhenNegativeAndFail(t *testing.T) {
 	doc := "a:"
 	manifest := makeManifest(doc)
 
-	v := ExistsValidator{"a"}
+	v := ExistsValidator{Path: "a"}
 	pass, diff := v.Validate(&ValidateContext{
 		Docs:     []common.K8sManifest{manifest},
 		Negative: true,
@@ -89,7 +89,7 @@ func TestExistsValidatorWhenInvalidPath(t *testing.T) {
 	doc := "x:"
 	manifest := makeManifest(doc)
 
-	validator := ExistsValidator{"x[b]"}
+	validator := ExistsValidator{Path: "x[b]"}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
 	})
@@ -107,7 +107,7 @@ func TestExistsValidatorWhenInvalidPathFailFast(t *testing.T) {
 	manifest := makeManifest(doc)
 	secondManifest := makeManifest(doc)
 
-	validator := ExistsValidator{"x[b]"}
+	validator := ExistsValidator{Path: "x[b]"}
 	pass, diff := validator.Validate(&ValidateContext{
 		FailFast: true,
 		Docs:     []common.K8sManifest{manifest, secondManifest},
@@ -122,7 +122,7 @@ func TestExistsValidatorWhenInvalidPathFailFast(t *testing.T) {
 }
 
 func TestExistsValidatorWhenNoManifestFail(t *testing.T) {
-	validator := ExistsValidator{"x"}
+	validator := ExistsValidator{Path: "x"}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{},
 	})
@@ -134,7 +134,7 @@ func TestExistsValidatorWhenNoManifestFail(t *testing.T) {
 }
 
 func TestExistsValidatorWhenNoManifestNegativeOk(t *testing.T) {
-	validator := ExistsValidator{"x"}
+	validator := ExistsValidator{Path: "x"}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs:     []common.K8sManifest{},
 		Negative: true,
@@ -143,3 +143,27 @@ func TestExistsValidatorWhenNoManifestNegativeOk(t *testing.T) {
 	assert.True(t, pass)
 	assert.Equal(t, []string{}, diff)
 }
+
+func TestExistsValidatorWithDecode(t *testing.T) {
+	doc := `
+data:
+  app.ini: |
+    [database]
+    host = db
+`
+	manifest := makeManifest(doc)
+
+	v := ExistsValidator{Path: "data['app.ini']", Decode: "ini", SubPath: "database.host"}
+	pass, diff := v.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{manifest},
+	})
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+
+	v = ExistsValidator{Path: "data['app.ini']", Decode: "ini", SubPath: "database.port"}
+	pass, diff = v.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{manifest},
+	})
+	assert.False(t, pass)
+	assert.Equal(t, []string{"DocumentIndex:\t0", "Path:\tdata['app.ini'] expected to exists"}, diff)
+}
@@ -0,0 +1,23 @@
+package validators
+
+// GreaterThanValidator validate whether the value of Path is greater than Value
+type GreaterThanValidator struct {
+	Path    string
+	Value   interface{}
+	Decode  interface{}
+	SubPath string
+}
+
+// Validate implement Validatable
+func (g GreaterThanValidator) Validate(context *ValidateContext) (bool, []string) {
+	operatorValidator := operatorValidator{
+		Path:           g.Path,
+		Decode:         g.Decode,
+		SubPath:        g.SubPath,
+		Value:          g.Value,
+		ComparisonType: "greater",
+		Strict:         true,
+	}
+
+	return operatorValidator.Validate(context)
+}
@@ -0,0 +1,110 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGreaterThanValidatorOk(t *testing.T) {
+	tests := []struct {
+		name, doc, path string
+		value           interface{}
+	}{
+		{name: "test case 1: int values", doc: "spec: 4", path: "spec", value: 3},
+		{name: "test case 2: int and float64 values", doc: "spec: 4", path: "spec", value: 3.9},
+		{name: "test case 3: quantity values", doc: "memory: 1Gi", path: "memory", value: "1000Mi"},
+		{name: "test case 4: duration values", doc: "timeout: 1h", path: "timeout", value: "59m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := GreaterThanValidator{
+				Path:  tt.path,
+				Value: tt.value,
+			}
+			pass, diff := v.Validate(&ValidateContext{
+				Docs: []common.K8sManifest{makeManifest(tt.doc)},
+			})
+
+			assert.True(t, pass)
+			assert.Equal(t, []string{}, diff)
+		})
+	}
+}
+
+func TestGreaterThanValidatorWhenEqualFail(t *testing.T) {
+	v := GreaterThanValidator{
+		Path:  "cpu",
+		Value: 1,
+	}
+	pass, diff := v.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest("cpu: 1000m")},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"ValuesIndex:\t0",
+		"Path:\tcpu",
+		"Expected to be greater than, got:",
+		"\tthe actual '1000m' is not greater than the expected '1'",
+	}, diff)
+}
+
+func TestGreaterThanValidatorWhenNegativeFail(t *testing.T) {
+	v := GreaterThanValidator{
+		Path:  "replicas",
+		Value: 2,
+	}
+	pass, diff := v.Validate(&ValidateContext{
+		Docs:     []common.K8sManifest{makeManifest("replicas: 3")},
+		Negative: true,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"ValuesIndex:\t0",
+		"Path:\treplicas",
+		"Expected NOT to be greater than, got:",
+		"\tthe actual '3' is greater than the expected '2'",
+	}, diff)
+}
+
+func TestGreaterThanValidatorWhenTypesDoNotMatch(t *testing.T) {
+	v := GreaterThanValidator{
+		Path:  "value",
+		Value: 1,
+	}
+	pass, diff := v.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest("value: abc")},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"ValuesIndex:\t0",
+		"Error:",
+		"\tactual 'string' and expected 'int' types do not match",
+	}, diff)
+}
+
+func TestGreaterThanValidatorWithDecodeWhenOk(t *testing.T) {
+	manifest := makeManifest(`
+data:
+  config.toml: |
+    [server]
+    workers = 8
+`)
+
+	validator := GreaterThanValidator{Path: "data['config.toml']", Value: 4, Decode: "toml", SubPath: "server.workers"}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{manifest},
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
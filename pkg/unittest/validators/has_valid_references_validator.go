@@ -0,0 +1,455 @@
+package validators
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	log "github.com/sirupsen/logrus"
+)
+
+// HasValidReferencesValidator validate the references between the documents of the rendered release resolve,
+// like the selector of a Service matching the pod template of a workload or the ConfigMap of a volume being rendered.
+type HasValidReferencesValidator struct {
+	// Checks are the references to check, all of them by default
+	Checks []string
+	// Ignore are the referenced objects which are not rendered by the chart, like Secret/registry-credentials,
+	// and the Services selecting pods which are not rendered by the chart, like Service/external-db
+	Ignore []string
+}
+
+// The references checked by HasValidReferencesValidator
+const (
+	referenceServiceSelectors = "serviceSelectors"
+	referenceConfigMaps       = "configMaps"
+	referenceSecrets          = "secrets"
+	referenceIngressBackends  = "ingressBackends"
+	referenceScaleTargets     = "scaleTargets"
+	referenceServiceAccounts  = "serviceAccounts"
+)
+
+var referenceChecks = map[string]func(r *releaseObjects, object releaseObject) []danglingReference{
+	referenceServiceSelectors: checkServiceSelector,
+	referenceConfigMaps:       checkConfigMapReferences,
+	referenceSecrets:          checkSecretReferences,
+	referenceIngressBackends:  checkIngressBackends,
+	referenceScaleTargets:     checkScaleTarget,
+	referenceServiceAccounts:  checkServiceAccountReferences,
+}
+
+// defaultServiceAccount is created by Kubernetes in each namespace, it is never rendered by charts
+const defaultServiceAccount = "default"
+
+// ValidatesRelease implement ReleaseValidatable
+func (v HasValidReferencesValidator) ValidatesRelease() {}
+
+func (v HasValidReferencesValidator) failInfo(dangling []string, not bool) []string {
+	info := "all references resolve"
+	if len(dangling) > 0 {
+		info = strings.Join(dangling, "\n")
+	}
+	log.WithField("validator", "has_valid_references").Debugln("dangling references:", info)
+
+	return splitInfof(
+		setFailFormat(not, false, false, false, " to have valid references, got"),
+		-1,
+		-1,
+		info,
+	)
+}
+
+// danglingReference is a reference of an object to the target, given as Kind/name, which is not rendered,
+// the target of a Service selector is the Service
+type danglingReference struct {
+	object      releaseObject
+	target      string
+	description string
+}
+
+func (d danglingReference) String() string {
+	return d.object.identity() + ": " + d.description
+}
+
+// releaseObject is a rendered document of the release with the template it is rendered from
+type releaseObject struct {
+	template  string
+	manifest  common.K8sManifest
+	kind      string
+	namespace string
+	name      string
+}
+
+// releaseObjects are the rendered documents of the release, to resolve the references between them
+type releaseObjects struct {
+	objects []releaseObject
+}
+
+// newReleaseObjects returns the objects of the documents of the templates, in the order of the templates.
+// Objects without namespace are in the namespace of the release.
+func newReleaseObjects(templates map[string][]common.K8sManifest, releaseNamespace string) *releaseObjects {
+	r := &releaseObjects{}
+	for _, template := range slices.Sorted(maps.Keys(templates)) {
+		for _, manifest := range templates[template] {
+			if _, ok := manifest[common.RAW]; ok {
+				continue
+			}
+			metadata := asMap(manifest["metadata"])
+			object := releaseObject{template: template, manifest: manifest}
+			object.kind, _ = manifest["kind"].(string)
+			object.name, _ = metadata["name"].(string)
+			object.namespace, _ = metadata["namespace"].(string)
+			if object.namespace == "" {
+				object.namespace = releaseNamespace
+			}
+			r.objects = append(r.objects, object)
+		}
+	}
+	return r
+}
+
+// find returns the object of the kind and name in the namespace
+func (r *releaseObjects) find(kind, namespace, name string) (releaseObject, bool) {
+	for _, object := range r.objects {
+		if object.kind == kind && object.namespace == namespace && object.name == name {
+			return object, true
+		}
+	}
+	return releaseObject{}, false
+}
+
+// identity returns the template, kind, namespace and name of the object to show
+func (o releaseObject) identity() string {
+	if identity := documentIdentity(o.template, o.manifest); identity != "" {
+		return identity
+	}
+	return o.template + " " + o.kind
+}
+
+// podTemplate returns the labels and the spec of the pods of a workload, or of the pod itself
+func podTemplate(object releaseObject) (map[string]interface{}, map[string]interface{}, bool) {
+	spec := asMap(object.manifest["spec"])
+	var template map[string]interface{}
+	switch object.kind {
+	case "Pod":
+		return asMap(asMap(object.manifest["metadata"])["labels"]), spec, true
+	case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet", "ReplicationController", "Job":
+		template = asMap(spec["template"])
+	case "CronJob":
+		template = asMap(asMap(asMap(spec["jobTemplate"])["spec"])["template"])
+	default:
+		return nil, nil, false
+	}
+	return asMap(asMap(template["metadata"])["labels"]), asMap(template["spec"]), template != nil
+}
+
+// containers returns the containers, init containers and ephemeral containers of the pod spec
+func containers(podSpec map[string]interface{}) []map[string]interface{} {
+	var all []map[string]interface{}
+	for _, key := range []string{"initContainers", "containers", "ephemeralContainers"} {
+		all = append(all, asMaps(podSpec[key])...)
+	}
+	return all
+}
+
+// asMaps returns the maps of a list of a manifest
+func asMaps(value interface{}) []map[string]interface{} {
+	items, _ := value.([]interface{})
+	result := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		if m := asMap(item); m != nil {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// podReference is a reference of a pod spec to an object, by the field describing it
+type podReference struct {
+	name     string
+	field    string
+	optional bool
+}
+
+// podReferences returns the references of the pod spec to objects of the kind, ConfigMap or Secret
+func podReferences(podSpec map[string]interface{}, kind string) []podReference {
+	var references []podReference
+	add := func(source map[string]interface{}, nameKey, field string) {
+		if source == nil {
+			return
+		}
+		name, _ := source[nameKey].(string)
+		optional, _ := source["optional"].(bool)
+		references = append(references, podReference{name: name, field: field, optional: optional})
+	}
+
+	volumeSource, volumeNameKey := "configMap", "name"
+	keyRef, envFromRef := "configMapKeyRef", "configMapRef"
+	if kind == "Secret" {
+		volumeSource, volumeNameKey = "secret", "secretName"
+		keyRef, envFromRef = "secretKeyRef", "secretRef"
+		for _, pullSecret := range asMaps(podSpec["imagePullSecrets"]) {
+			add(pullSecret, "name", "imagePullSecrets")
+		}
+	}
+
+	for _, volume := range asMaps(podSpec["volumes"]) {
+		field := fmt.Sprintf("volume '%v'", volume["name"])
+		add(asMap(volume[volumeSource]), volumeNameKey, field)
+		for _, source := range asMaps(asMap(volume["projected"])["sources"]) {
+			add(asMap(source[volumeSource]), "name", field)
+		}
+	}
+	for _, container := range containers(podSpec) {
+		for _, env := range asMaps(container["env"]) {
+			add(asMap(asMap(env["valueFrom"])[keyRef]), "name", fmt.Sprintf("env '%v' of container '%v'", env["name"], container["name"]))
+		}
+		for _, envFrom := range asMaps(container["envFrom"]) {
+			add(asMap(envFrom[envFromRef]), "name", fmt.Sprintf("envFrom of container '%v'", container["name"]))
+		}
+	}
+	return references
+}
+
+// checkPodReferences returns the dangling references of the pod spec of the object to objects of the kind
+func checkPodReferences(r *releaseObjects, object releaseObject, kind string) []danglingReference {
+	_, podSpec, ok := podTemplate(object)
+	if !ok {
+		return nil
+	}
+
+	var dangling []danglingReference
+	for _, reference := range podReferences(podSpec, kind) {
+		if reference.optional || reference.name == "" {
+			continue
+		}
+		if _, found := r.find(kind, object.namespace, reference.name); !found {
+			target := kind + "/" + reference.name
+			dangling = append(dangling, danglingReference{object, target, fmt.Sprintf("%s of %s is not rendered", target, reference.field)})
+		}
+	}
+	return dangling
+}
+
+func checkConfigMapReferences(r *releaseObjects, object releaseObject) []danglingReference {
+	return checkPodReferences(r, object, "ConfigMap")
+}
+
+func checkSecretReferences(r *releaseObjects, object releaseObject) []danglingReference {
+	return checkPodReferences(r, object, "Secret")
+}
+
+// checkServiceSelector returns the selector of the Service when it matches the pods of no workload
+func checkServiceSelector(r *releaseObjects, object releaseObject) []danglingReference {
+	if object.kind != "Service" {
+		return nil
+	}
+	selector := asMap(asMap(object.manifest["spec"])["selector"])
+	if len(selector) == 0 {
+		return nil
+	}
+
+	for _, workload := range r.objects {
+		labels, _, ok := podTemplate(workload)
+		if ok && workload.namespace == object.namespace && matchesLabels(selector, labels) {
+			return nil
+		}
+	}
+	// The pods of the selector are not named, ignoring the Service itself silences its selector
+	return []danglingReference{{object, "Service/" + object.name, fmt.Sprintf("selector %s matches no pod template", formatLabels(selector))}}
+}
+
+// matchesLabels returns whether the labels contain all labels of the selector
+func matchesLabels(selector, labels map[string]interface{}) bool {
+	for key, value := range selector {
+		label, ok := labels[key]
+		if !ok || fmt.Sprintf("%v", label) != fmt.Sprintf("%v", value) {
+			return false
+		}
+	}
+	return true
+}
+
+// formatLabels returns the labels like a label selector, key=value separated by commas
+func formatLabels(labels map[string]interface{}) string {
+	pairs := make([]string, 0, len(labels))
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", key, labels[key]))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// ingressBackend is the Service and port an Ingress routes to
+type ingressBackend struct {
+	service string
+	port    interface{}
+}
+
+// ingressBackends returns the Service backends of the Ingress, of networking.k8s.io/v1 and of the deprecated
+// extensions/v1beta1 and networking.k8s.io/v1beta1, with their serviceName and servicePort
+func ingressBackends(spec map[string]interface{}) []ingressBackend {
+	backends := []map[string]interface{}{}
+	for _, key := range []string{"defaultBackend", "backend"} {
+		if backend := asMap(spec[key]); backend != nil {
+			backends = append(backends, backend)
+		}
+	}
+	for _, rule := range asMaps(spec["rules"]) {
+		for _, path := range asMaps(asMap(rule["http"])["paths"]) {
+			if backend := asMap(path["backend"]); backend != nil {
+				backends = append(backends, backend)
+			}
+		}
+	}
+
+	var services []ingressBackend
+	for _, backend := range backends {
+		if service := asMap(backend["service"]); service != nil {
+			port := asMap(service["port"])
+			name, _ := service["name"].(string)
+			if number, ok := port["number"]; ok {
+				services = append(services, ingressBackend{name, number})
+			} else {
+				services = append(services, ingressBackend{name, port["name"]})
+			}
+		} else if name, ok := backend["serviceName"].(string); ok {
+			services = append(services, ingressBackend{name, backend["servicePort"]})
+		}
+	}
+	return services
+}
+
+// hasServicePort returns whether the Service has the port, by number or by name
+func hasServicePort(service releaseObject, port interface{}) bool {
+	if port == nil {
+		return true
+	}
+	wanted := fmt.Sprintf("%v", port)
+	_, isNumber := strconv.Atoi(wanted)
+	for _, servicePort := range asMaps(asMap(service.manifest["spec"])["ports"]) {
+		if isNumber == nil && fmt.Sprintf("%v", servicePort["port"]) == wanted {
+			return true
+		}
+		if isNumber != nil && servicePort["name"] == wanted {
+			return true
+		}
+	}
+	return false
+}
+
+// checkIngressBackends returns the backends of the Ingress which are no rendered Service or port of the Service
+func checkIngressBackends(r *releaseObjects, object releaseObject) []danglingReference {
+	if object.kind != "Ingress" {
+		return nil
+	}
+
+	var dangling []danglingReference
+	for _, backend := range ingressBackends(asMap(object.manifest["spec"])) {
+		target := "Service/" + backend.service
+		service, found := r.find("Service", object.namespace, backend.service)
+		switch {
+		case !found:
+			dangling = append(dangling, danglingReference{object, target, fmt.Sprintf("backend %s is not rendered", target)})
+		case !hasServicePort(service, backend.port):
+			dangling = append(dangling, danglingReference{object, target, fmt.Sprintf("backend %s has no port %v", target, backend.port)})
+		}
+	}
+	return dangling
+}
+
+// checkScaleTarget returns the scale target of the HorizontalPodAutoscaler when it is not rendered
+func checkScaleTarget(r *releaseObjects, object releaseObject) []danglingReference {
+	if object.kind != "HorizontalPodAutoscaler" {
+		return nil
+	}
+	target := asMap(asMap(object.manifest["spec"])["scaleTargetRef"])
+	kind, _ := target["kind"].(string)
+	name, _ := target["name"].(string)
+
+	if _, found := r.find(kind, object.namespace, name); !found {
+		target := kind + "/" + name
+		return []danglingReference{{object, target, fmt.Sprintf("scaleTargetRef %s is not rendered", target)}}
+	}
+	return nil
+}
+
+// checkServiceAccountReferences returns the ServiceAccounts of pod specs and of the subjects of role bindings
+// which are not rendered, the default ServiceAccount of the namespace is never rendered
+func checkServiceAccountReferences(r *releaseObjects, object releaseObject) []danglingReference {
+	type serviceAccount struct {
+		namespace string
+		name      string
+		field     string
+	}
+	var references []serviceAccount
+
+	if _, podSpec, ok := podTemplate(object); ok {
+		name, _ := podSpec["serviceAccountName"].(string)
+		if name == "" {
+			name, _ = podSpec["serviceAccount"].(string)
+		}
+		references = append(references, serviceAccount{object.namespace, name, "serviceAccountName"})
+	}
+	if object.kind == "RoleBinding" || object.kind == "ClusterRoleBinding" {
+		for _, subject := range asMaps(object.manifest["subjects"]) {
+			if subject["kind"] != "ServiceAccount" {
+				continue
+			}
+			name, _ := subject["name"].(string)
+			namespace, _ := subject["namespace"].(string)
+			if namespace == "" {
+				namespace = object.namespace
+			}
+			references = append(references, serviceAccount{namespace, name, "subject"})
+		}
+	}
+
+	var dangling []danglingReference
+	for _, reference := range references {
+		if reference.name == "" || reference.name == defaultServiceAccount {
+			continue
+		}
+		if _, found := r.find("ServiceAccount", reference.namespace, reference.name); !found {
+			target := "ServiceAccount/" + reference.name
+			dangling = append(dangling, danglingReference{object, target, fmt.Sprintf("%s of %s is not rendered", target, reference.field)})
+		}
+	}
+	return dangling
+}
+
+// Validate implement Validatable
+func (v HasValidReferencesValidator) Validate(context *ValidateContext) (bool, []string) {
+	checks := v.Checks
+	if len(checks) == 0 {
+		checks = slices.Sorted(maps.Keys(referenceChecks))
+	}
+	for _, check := range checks {
+		if _, ok := referenceChecks[check]; !ok {
+			return false, splitInfof(errorFormat, -1, -1, fmt.Sprintf("unknown reference check '%s', expected one of %s", check, strings.Join(slices.Sorted(maps.Keys(referenceChecks)), ", ")))
+		}
+	}
+
+	releaseNamespace := ""
+	if release, ok := context.RenderValues["Release"].(map[string]interface{}); ok {
+		releaseNamespace, _ = release["Namespace"].(string)
+	}
+	objects := newReleaseObjects(context.Release, releaseNamespace)
+
+	dangling := make([]string, 0)
+	for _, object := range objects.objects {
+		for _, check := range checks {
+			for _, reference := range referenceChecks[check](objects, object) {
+				if !slices.Contains(v.Ignore, reference.target) {
+					dangling = append(dangling, reference.String())
+				}
+			}
+		}
+	}
+
+	if (len(dangling) == 0) == context.Negative {
+		return false, v.failInfo(dangling, context.Negative)
+	}
+	return true, []string{}
+}
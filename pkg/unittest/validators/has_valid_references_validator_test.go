@@ -0,0 +1,196 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+const referencesWorkloadDoc = `
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      serviceAccountName: web
+      imagePullSecrets:
+        - name: registry
+      volumes:
+        - name: config
+          configMap:
+            name: web-config
+        - name: certs
+          secret:
+            secretName: web-certs
+            optional: true
+      containers:
+        - name: web
+          env:
+            - name: PASSWORD
+              valueFrom:
+                secretKeyRef:
+                  name: web-secret
+                  key: password
+          envFrom:
+            - configMapRef:
+                name: web-env
+`
+
+const referencesIngressDoc = `
+kind: Ingress
+metadata:
+  name: web
+spec:
+  rules:
+    - http:
+        paths:
+          - path: /
+            backend:
+              service:
+                name: web
+                port:
+                  name: http
+`
+
+func makeReferencesRelease(docs ...string) map[string][]common.K8sManifest {
+	manifests := make([]common.K8sManifest, 0, len(docs))
+	for _, doc := range docs {
+		manifests = append(manifests, makeManifest(doc))
+	}
+	return map[string][]common.K8sManifest{"basic/templates/web.yaml": manifests}
+}
+
+func TestHasValidReferencesValidatorWhenOk(t *testing.T) {
+	release := makeReferencesRelease(
+		referencesWorkloadDoc,
+		referencesIngressDoc,
+		"kind: Service\nmetadata:\n  name: web\nspec:\n  selector:\n    app: web\n  ports:\n    - name: http\n      port: 80\n",
+		"kind: ConfigMap\nmetadata:\n  name: web-config\n",
+		"kind: ConfigMap\nmetadata:\n  name: web-env\n",
+		"kind: Secret\nmetadata:\n  name: web-secret\n",
+		"kind: ServiceAccount\nmetadata:\n  name: web\n",
+		"kind: HorizontalPodAutoscaler\nmetadata:\n  name: web\nspec:\n  scaleTargetRef:\n    kind: Deployment\n    name: web\n",
+	)
+
+	validator := HasValidReferencesValidator{Ignore: []string{"Secret/registry"}}
+	pass, diff := validator.Validate(&ValidateContext{Release: release})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
+
+func TestHasValidReferencesValidatorWhenDangling(t *testing.T) {
+	release := makeReferencesRelease(
+		referencesWorkloadDoc,
+		referencesIngressDoc,
+		"kind: Service\nmetadata:\n  name: web\nspec:\n  selector:\n    app: api\n  ports:\n    - name: grpc\n      port: 9090\n",
+		"kind: HorizontalPodAutoscaler\nmetadata:\n  name: web\nspec:\n  scaleTargetRef:\n    kind: StatefulSet\n    name: web\n",
+	)
+
+	validator := HasValidReferencesValidator{}
+	pass, diff := validator.Validate(&ValidateContext{Release: release})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Expected to have valid references, got:",
+		"\tbasic/templates/web.yaml Deployment/web: ConfigMap/web-config of volume 'config' is not rendered",
+		"\tbasic/templates/web.yaml Deployment/web: ConfigMap/web-env of envFrom of container 'web' is not rendered",
+		"\tbasic/templates/web.yaml Deployment/web: Secret/registry of imagePullSecrets is not rendered",
+		"\tbasic/templates/web.yaml Deployment/web: Secret/web-secret of env 'PASSWORD' of container 'web' is not rendered",
+		"\tbasic/templates/web.yaml Deployment/web: ServiceAccount/web of serviceAccountName is not rendered",
+		"\tbasic/templates/web.yaml Ingress/web: backend Service/web has no port http",
+		"\tbasic/templates/web.yaml Service/web: selector app=api matches no pod template",
+		"\tbasic/templates/web.yaml HorizontalPodAutoscaler/web: scaleTargetRef StatefulSet/web is not rendered",
+	}, diff)
+}
+
+func TestHasValidReferencesValidatorIgnoresServiceSelector(t *testing.T) {
+	release := makeReferencesRelease(
+		"kind: Service\nmetadata:\n  name: external-db\nspec:\n  selector:\n    app: db\n",
+		"kind: Service\nmetadata:\n  name: api\nspec:\n  selector:\n    app: api\n",
+	)
+
+	validator := HasValidReferencesValidator{Ignore: []string{"Service/external-db"}}
+	pass, diff := validator.Validate(&ValidateContext{Release: release})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Expected to have valid references, got:",
+		"\tbasic/templates/web.yaml Service/api: selector app=api matches no pod template",
+	}, diff)
+}
+
+func TestHasValidReferencesValidatorWithChecks(t *testing.T) {
+	release := makeReferencesRelease(
+		"kind: Ingress\nmetadata:\n  name: web\nspec:\n  backend:\n    serviceName: api\n    servicePort: 80\n",
+		"kind: Pod\nmetadata:\n  name: web\nspec:\n  serviceAccountName: default\n  volumes:\n    - name: config\n      configMap:\n        name: web-config\n",
+	)
+
+	validator := HasValidReferencesValidator{Checks: []string{"ingressBackends", "serviceAccounts"}}
+	pass, diff := validator.Validate(&ValidateContext{Release: release})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Expected to have valid references, got:",
+		"\tbasic/templates/web.yaml Ingress/web: backend Service/api is not rendered",
+	}, diff)
+}
+
+func TestHasValidReferencesValidatorResolvesInNamespace(t *testing.T) {
+	release := makeReferencesRelease(
+		"kind: RoleBinding\nmetadata:\n  name: web\nsubjects:\n  - kind: ServiceAccount\n    name: web\n",
+		"kind: ServiceAccount\nmetadata:\n  name: web\n  namespace: other\n",
+	)
+
+	validator := HasValidReferencesValidator{}
+	pass, diff := validator.Validate(&ValidateContext{
+		Release:      release,
+		RenderValues: map[string]interface{}{"Release": map[string]interface{}{"Namespace": "default"}},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Expected to have valid references, got:",
+		"\tbasic/templates/web.yaml RoleBinding/web: ServiceAccount/web of subject is not rendered",
+	}, diff)
+}
+
+func TestHasValidReferencesValidatorWhenNegativeAndDangling(t *testing.T) {
+	release := makeReferencesRelease("kind: Service\nmetadata:\n  name: web\nspec:\n  selector:\n    app: web\n")
+
+	validator := HasValidReferencesValidator{}
+	pass, diff := validator.Validate(&ValidateContext{Release: release, Negative: true})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
+
+func TestHasValidReferencesValidatorWhenNegativeAndResolved(t *testing.T) {
+	release := makeReferencesRelease("kind: Service\nmetadata:\n  name: web\n")
+
+	validator := HasValidReferencesValidator{}
+	pass, diff := validator.Validate(&ValidateContext{Release: release, Negative: true})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Expected NOT to have valid references, got:",
+		"\tall references resolve",
+	}, diff)
+}
+
+func TestHasValidReferencesValidatorWithUnknownCheck(t *testing.T) {
+	validator := HasValidReferencesValidator{Checks: []string{"pvcs"}}
+	pass, diff := validator.Validate(&ValidateContext{Release: makeReferencesRelease()})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Error:",
+		"\tunknown reference check 'pvcs', expected one of configMaps, ingressBackends, scaleTargets, secrets, serviceAccounts, serviceSelectors",
+	}, diff)
+}
@@ -0,0 +1,210 @@
+package validators
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
+	log "github.com/sirupsen/logrus"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+// ImageValidator validate the images of the containers, init containers and ephemeral containers of pods and workloads,
+// parsed into registry, repository, tag and digest, against the image policy.
+type ImageValidator struct {
+	// Containers are the names of the containers to validate, all of them by default
+	Containers []string
+	// Registries are the allowed registries, like docker.io or ghcr.io
+	Registries []string
+	// Repository is the expected repository, like bitnami/nginx
+	Repository string
+	// Tag is the expected tag
+	Tag string
+	// TagFromValues is the path of the expected tag in the values, like image.tag
+	TagFromValues string
+	// NoLatest forbids the latest tag, images without tag and digest are latest
+	NoLatest bool
+	// RequireDigest requires the images to be pinned by digest
+	RequireDigest bool
+}
+
+// defaultRegistry is the registry of images without registry, like nginx:1.25.3
+const defaultRegistry = "docker.io"
+
+// officialRepositoryPrefix is the namespace of the official images of the default registry, like library/nginx
+const officialRepositoryPrefix = "library/"
+
+// imageReference is a container image reference, like ghcr.io/org/app:1.0.0@sha256:...
+type imageReference struct {
+	registry   string
+	repository string
+	tag        string
+	digest     string
+}
+
+// parseImageReference parses the image reference the way container runtimes do: the first component is the registry
+// when it has a dot, a port or is localhost, the default registry otherwise. The official images of the default
+// registry are in the library namespace, so nginx and docker.io/library/nginx are the same repository.
+func parseImageReference(reference string) imageReference {
+	image := imageReference{registry: defaultRegistry}
+
+	name, digest, _ := strings.Cut(reference, "@")
+	image.digest = digest
+
+	if slash := strings.LastIndex(name, "/"); strings.Contains(name[slash+1:], ":") {
+		colon := strings.LastIndex(name, ":")
+		name, image.tag = name[:colon], name[colon+1:]
+	}
+
+	image.repository = name
+	if first, rest, found := strings.Cut(name, "/"); found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		image.registry, image.repository = first, rest
+	}
+	if image.registry == "index.docker.io" {
+		image.registry = defaultRegistry
+	}
+	image.repository = normalizeRepository(image.registry, image.repository)
+	return image
+}
+
+// normalizeRepository returns the repository of the registry with the library namespace of the official images
+func normalizeRepository(registry, repository string) string {
+	if registry == defaultRegistry && repository != "" && !strings.Contains(repository, "/") {
+		return officialRepositoryPrefix + repository
+	}
+	return repository
+}
+
+// isLatest returns whether the image is the latest tag, explicitly or by having neither tag nor digest
+func (i imageReference) isLatest() bool {
+	return i.tag == "latest" || (i.tag == "" && i.digest == "")
+}
+
+func (v ImageValidator) failInfo(msg string, manifestIndex, actualIndex int, not bool) []string {
+	log.WithField("validator", "image").Debugln("actual content:", msg)
+
+	return splitInfof(
+		setFailFormat(not, false, false, false, " to match the image policy, got"),
+		manifestIndex,
+		actualIndex,
+		msg,
+	)
+}
+
+// expectedTag returns the expected tag, of the values when TagFromValues is given
+func (v ImageValidator) expectedTag(context *ValidateContext) (string, error) {
+	if v.TagFromValues == "" {
+		return v.Tag, nil
+	}
+
+	var values map[string]interface{}
+	switch renderValues := context.RenderValues["Values"].(type) {
+	case chartutil.Values:
+		values = renderValues
+	case map[string]interface{}:
+		values = renderValues
+	}
+	tags, err := valueutils.GetValueOfSetPath(common.K8sManifest(values), v.TagFromValues)
+	if err != nil {
+		return "", err
+	}
+	if len(tags) == 0 || tags[0] == nil {
+		return "", fmt.Errorf("unknown path '%s' of the values", v.TagFromValues)
+	}
+	return fmt.Sprintf("%v", tags[0]), nil
+}
+
+// violations returns how the image breaks the image policy
+func (v ImageValidator) violations(image imageReference, expectedTag string) []string {
+	var violations []string
+	if len(v.Registries) > 0 && !slices.Contains(v.Registries, image.registry) {
+		violations = append(violations, fmt.Sprintf("registry '%s' is not one of %s", image.registry, strings.Join(v.Registries, ", ")))
+	}
+	if v.Repository != "" && image.repository != normalizeRepository(image.registry, v.Repository) {
+		violations = append(violations, fmt.Sprintf("repository '%s' is not '%s'", image.repository, v.Repository))
+	}
+	if expectedTag != "" && image.tag != expectedTag {
+		violations = append(violations, fmt.Sprintf("tag '%s' is not '%s'", image.tag, expectedTag))
+	}
+	if v.NoLatest && image.isLatest() {
+		violations = append(violations, "tag is latest")
+	}
+	if v.RequireDigest && image.digest == "" {
+		violations = append(violations, "has no digest")
+	}
+	return violations
+}
+
+func (v ImageValidator) validateManifest(manifest common.K8sManifest, manifestIndex int, expectedTag string, context *ValidateContext) (bool, int, []string) {
+	kind, _ := manifest["kind"].(string)
+	_, podSpec, ok := podTemplate(releaseObject{manifest: manifest, kind: kind})
+	if !ok {
+		return true, 0, []string{}
+	}
+
+	validateManifestSuccess := true
+	validateManifestErrors := make([]string, 0)
+	validated := 0
+
+	for _, container := range containers(podSpec) {
+		name, _ := container["name"].(string)
+		if len(v.Containers) > 0 && !slices.Contains(v.Containers, name) {
+			continue
+		}
+		actualIndex := validated
+		validated++
+
+		reference, _ := container["image"].(string)
+		if reference == "" {
+			validateManifestSuccess = false
+			validateManifestErrors = append(validateManifestErrors, splitInfof(errorFormat, manifestIndex, actualIndex, fmt.Sprintf("container '%s' has no image", name))...)
+		} else if violations := v.violations(parseImageReference(reference), expectedTag); (len(violations) == 0) == context.Negative {
+			msg := fmt.Sprintf("container '%s' image '%s' matches the image policy", name, reference)
+			if len(violations) > 0 {
+				msg = fmt.Sprintf("container '%s' image '%s': %s", name, reference, strings.Join(violations, ", "))
+			}
+			validateManifestSuccess = false
+			validateManifestErrors = append(validateManifestErrors, v.failInfo(msg, manifestIndex, actualIndex, context.Negative)...)
+		}
+
+		if !validateManifestSuccess && context.FailFast {
+			break
+		}
+	}
+
+	return validateManifestSuccess, validated, validateManifestErrors
+}
+
+// Validate implement Validatable
+func (v ImageValidator) Validate(context *ValidateContext) (bool, []string) {
+	expectedTag, err := v.expectedTag(context)
+	if err != nil {
+		return false, splitInfof(errorFormat, -1, -1, err.Error())
+	}
+
+	manifests := context.getManifests()
+
+	validateSuccess := true
+	validateErrors := make([]string, 0)
+	validated := 0
+
+	for manifestIndex, manifest := range manifests {
+		validateManifestSuccess, validatedImages, validateManifestErrors := v.validateManifest(manifest, manifestIndex, expectedTag, context)
+		validateErrors = append(validateErrors, validateManifestErrors...)
+		validateSuccess = validateSuccess && validateManifestSuccess
+		validated += validatedImages
+
+		if !validateSuccess && context.FailFast {
+			break
+		}
+	}
+
+	if validated == 0 && !context.Negative {
+		validateSuccess = false
+		validateErrors = append(validateErrors, v.failInfo("no container images found", -1, -1, context.Negative)...)
+	}
+
+	return validateSuccess, validateErrors
+}
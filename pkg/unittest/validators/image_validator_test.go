@@ -0,0 +1,159 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+const imageDeploymentDoc = `
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      initContainers:
+        - name: migrate
+          image: ghcr.io/acme/migrate:1.2.0@sha256:4f53cda18c2baa0c0354bb5f9a3ecbe5ed12ab4d8e11ba873c2f11161202b945
+      containers:
+        - name: web
+          image: nginx:latest
+        - name: proxy
+          image: registry.acme.io:5000/acme/proxy
+`
+
+func TestImageValidatorWhenOk(t *testing.T) {
+	manifest := makeManifest(imageDeploymentDoc)
+
+	validator := ImageValidator{
+		Containers:    []string{"migrate"},
+		Registries:    []string{"ghcr.io"},
+		Repository:    "acme/migrate",
+		Tag:           "1.2.0",
+		NoLatest:      true,
+		RequireDigest: true,
+	}
+	pass, diff := validator.Validate(&ValidateContext{Docs: []common.K8sManifest{manifest}})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
+
+func TestImageValidatorWhenFail(t *testing.T) {
+	manifest := makeManifest(imageDeploymentDoc)
+
+	validator := ImageValidator{Registries: []string{"ghcr.io", "docker.io"}, NoLatest: true, RequireDigest: true}
+	pass, diff := validator.Validate(&ValidateContext{Docs: []common.K8sManifest{manifest}})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"ValuesIndex:\t1",
+		"Expected to match the image policy, got:",
+		"\tcontainer 'web' image 'nginx:latest': tag is latest, has no digest",
+		"DocumentIndex:\t0",
+		"ValuesIndex:\t2",
+		"Expected to match the image policy, got:",
+		"\tcontainer 'proxy' image 'registry.acme.io:5000/acme/proxy': registry 'registry.acme.io:5000' is not one of ghcr.io, docker.io, tag is latest, has no digest",
+	}, diff)
+}
+
+func TestImageValidatorWithOfficialImages(t *testing.T) {
+	manifest := makeManifest("kind: Pod\nmetadata:\n  name: web\nspec:\n  containers:\n    - name: web\n      image: nginx:1.25.3\n    - name: proxy\n      image: docker.io/library/nginx:1.25.3\n    - name: cache\n      image: index.docker.io/nginx:1.25.3\n")
+
+	for _, repository := range []string{"nginx", "library/nginx"} {
+		validator := ImageValidator{Repository: repository}
+		pass, diff := validator.Validate(&ValidateContext{Docs: []common.K8sManifest{manifest}})
+
+		assert.True(t, pass)
+		assert.Equal(t, []string{}, diff)
+	}
+
+	validator := ImageValidator{Repository: "bitnami/nginx", Containers: []string{"web"}}
+	pass, diff := validator.Validate(&ValidateContext{Docs: []common.K8sManifest{manifest}})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"ValuesIndex:\t0",
+		"Expected to match the image policy, got:",
+		"\tcontainer 'web' image 'nginx:1.25.3': repository 'library/nginx' is not 'bitnami/nginx'",
+	}, diff)
+}
+
+func TestImageValidatorWithTagFromValues(t *testing.T) {
+	manifest := makeManifest("kind: Pod\nmetadata:\n  name: web\nspec:\n  containers:\n    - name: web\n      image: nginx:1.25.3\n")
+	renderValues := map[string]interface{}{"Values": map[string]interface{}{"image": map[string]interface{}{"tag": "1.25.4"}}}
+
+	validator := ImageValidator{TagFromValues: "image.tag"}
+	pass, diff := validator.Validate(&ValidateContext{Docs: []common.K8sManifest{manifest}, RenderValues: renderValues})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"ValuesIndex:\t0",
+		"Expected to match the image policy, got:",
+		"\tcontainer 'web' image 'nginx:1.25.3': tag '1.25.3' is not '1.25.4'",
+	}, diff)
+
+	validator = ImageValidator{TagFromValues: "image.version"}
+	pass, diff = validator.Validate(&ValidateContext{Docs: []common.K8sManifest{manifest}, RenderValues: renderValues})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Error:",
+		"\tunknown path 'image.version' of the values",
+	}, diff)
+}
+
+func TestImageValidatorWhenNegative(t *testing.T) {
+	manifest := makeManifest("kind: CronJob\nmetadata:\n  name: backup\nspec:\n  jobTemplate:\n    spec:\n      template:\n        spec:\n          containers:\n            - name: backup\n              image: docker.io/library/busybox:1.36\n")
+
+	validator := ImageValidator{NoLatest: true}
+	pass, diff := validator.Validate(&ValidateContext{Docs: []common.K8sManifest{manifest}, Negative: true})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"ValuesIndex:\t0",
+		"Expected NOT to match the image policy, got:",
+		"\tcontainer 'backup' image 'docker.io/library/busybox:1.36' matches the image policy",
+	}, diff)
+
+	validator = ImageValidator{RequireDigest: true}
+	pass, diff = validator.Validate(&ValidateContext{Docs: []common.K8sManifest{manifest}, Negative: true})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
+
+func TestImageValidatorWhenNoImages(t *testing.T) {
+	manifest := makeManifest("kind: Service\nmetadata:\n  name: web\n")
+
+	validator := ImageValidator{NoLatest: true}
+	pass, diff := validator.Validate(&ValidateContext{Docs: []common.K8sManifest{manifest}})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Expected to match the image policy, got:",
+		"\tno container images found",
+	}, diff)
+}
+
+func TestImageValidatorWhenContainerHasNoImage(t *testing.T) {
+	manifest := makeManifest("kind: Pod\nmetadata:\n  name: web\nspec:\n  containers:\n    - name: web\n      image: \"\"\n")
+
+	validator := ImageValidator{}
+	pass, diff := validator.Validate(&ValidateContext{Docs: []common.K8sManifest{manifest}})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"ValuesIndex:\t0",
+		"Error:",
+		"\tcontainer 'web' has no image",
+	}, diff)
+}
@@ -0,0 +1,100 @@
+package validators
+
+import (
+	"fmt"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
+	log "github.com/sirupsen/logrus"
+)
+
+// MatchInlineSnapshotValidator validate value of Path the same as the Snapshot in the suite file
+type MatchInlineSnapshotValidator struct {
+	Path string
+	// Snapshot is the expected value, it is written to the suite file when missing or when updating
+	Snapshot interface{}
+}
+
+// missingInfo returns the fail info of a missing inline snapshot, which is not written when readOnly
+func (v MatchInlineSnapshotValidator) missingInfo(actual interface{}, readOnly bool) []string {
+	customMessage := " inline snapshot to exist"
+	if readOnly {
+		customMessage += ", inline snapshots are not written in CI mode"
+	}
+	return splitInfof(
+		setFailFormat(false, true, false, false, customMessage),
+		-1,
+		-1,
+		v.Path,
+		common.TrustedMarshalYAML(actual),
+	)
+}
+
+func (v MatchInlineSnapshotValidator) failInfo(actual interface{}, not bool) []string {
+	actualYAML := common.TrustedMarshalYAML(actual)
+	expectedYAML := common.TrustedMarshalYAML(v.Snapshot)
+
+	log.WithField("validator", "inline_snapshot").Debugln("expected content:", expectedYAML)
+	log.WithField("validator", "inline_snapshot").Debugln("actual content:", actualYAML)
+
+	var infoToShow string
+	if not {
+		infoToShow = expectedYAML
+	} else {
+		infoToShow = semanticDiff(v.Snapshot, actual, expectedYAML, actualYAML)
+	}
+	return splitInfof(
+		setFailFormat(not, true, false, false, " to match inline snapshot"),
+		-1,
+		-1,
+		v.Path,
+		infoToShow,
+	)
+}
+
+// actual returns the value of Path in the manifests, or the list of the values when there are several
+func (v MatchInlineSnapshotValidator) actual(manifests []common.K8sManifest) (interface{}, []string) {
+	var values []interface{}
+	for manifestIndex, manifest := range manifests {
+		actual, err := valueutils.GetValueOfSetPath(manifest, v.Path)
+		if err != nil {
+			return nil, splitInfof(errorFormat, manifestIndex, -1, err.Error())
+		}
+		if len(actual) == 0 {
+			return nil, splitInfof(errorFormat, manifestIndex, -1, fmt.Sprintf("unknown path %s", v.Path))
+		}
+		values = append(values, actual...)
+	}
+
+	if len(values) == 1 {
+		return values[0], nil
+	}
+	return values, nil
+}
+
+// ValidatesSingleTemplate implement SingleTemplateValidatable, the suite file holds one snapshot of the assertion
+func (v MatchInlineSnapshotValidator) ValidatesSingleTemplate() {}
+
+// Validate implement Validatable
+func (v MatchInlineSnapshotValidator) Validate(context *ValidateContext) (bool, []string) {
+	actual, errorMessage := v.actual(context.getManifests())
+	if errorMessage != nil {
+		return false, errorMessage
+	}
+
+	if v.Snapshot == nil {
+		if !context.Negative && context.updateInlineSnapshot(actual, true) {
+			return true, []string{}
+		}
+		return false, v.missingInfo(actual, context.inlineSnapshotsReadOnly())
+	}
+
+	matched := common.TrustedMarshalYAML(v.Snapshot) == common.TrustedMarshalYAML(actual)
+	if matched != context.Negative {
+		return true, []string{}
+	}
+	if !context.Negative && context.updateInlineSnapshot(actual, false) {
+		return true, []string{}
+	}
+	return false, v.failInfo(actual, context.Negative)
+}
@@ -0,0 +1,182 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var inlineSnapshotDoc = `
+kind: Service
+spec:
+  type: ClusterIP
+  selector:
+    app: web
+`
+
+func TestInlineSnapshotValidatorWhenOk(t *testing.T) {
+	validator := MatchInlineSnapshotValidator{
+		Path:     "spec.selector",
+		Snapshot: map[string]interface{}{"app": "web"},
+	}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(inlineSnapshotDoc)},
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
+
+func TestInlineSnapshotValidatorWhenMultipleDocuments(t *testing.T) {
+	validator := MatchInlineSnapshotValidator{
+		Path:     "spec.type",
+		Snapshot: []interface{}{"ClusterIP", "ClusterIP"},
+	}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(inlineSnapshotDoc), makeManifest(inlineSnapshotDoc)},
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
+
+func TestInlineSnapshotValidatorWhenMissing(t *testing.T) {
+	validator := MatchInlineSnapshotValidator{Path: "spec.type"}
+
+	updater := new(mockInlineSnapshotUpdater)
+	updater.On("UpdateInlineSnapshot", "ClusterIP", true).Return(true)
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:                  []common.K8sManifest{makeManifest(inlineSnapshotDoc)},
+		InlineSnapshotUpdater: updater,
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+	updater.AssertExpectations(t)
+}
+
+func TestInlineSnapshotValidatorWhenMissingAndNotWritten(t *testing.T) {
+	validator := MatchInlineSnapshotValidator{Path: "spec.type"}
+
+	updater := new(mockInlineSnapshotUpdater)
+	updater.On("UpdateInlineSnapshot", "ClusterIP", true).Return(false)
+	updater.On("IsReadOnly").Return(true)
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:                  []common.K8sManifest{makeManifest(inlineSnapshotDoc)},
+		InlineSnapshotUpdater: updater,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Path:\tspec.type",
+		"Expected inline snapshot to exist, inline snapshots are not written in CI mode:",
+		"\tClusterIP",
+	}, diff)
+}
+
+func TestInlineSnapshotValidatorWhenMissingAndNegative(t *testing.T) {
+	validator := MatchInlineSnapshotValidator{Path: "spec.type"}
+
+	updater := new(mockInlineSnapshotUpdater)
+	updater.On("IsReadOnly").Return(false)
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:                  []common.K8sManifest{makeManifest(inlineSnapshotDoc)},
+		InlineSnapshotUpdater: updater,
+		Negative:              true,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Path:\tspec.type",
+		"Expected inline snapshot to exist:",
+		"\tClusterIP",
+	}, diff)
+	updater.AssertNotCalled(t, "UpdateInlineSnapshot", mock.Anything, mock.Anything)
+}
+
+func TestInlineSnapshotValidatorWhenFail(t *testing.T) {
+	validator := MatchInlineSnapshotValidator{
+		Path:     "spec.selector",
+		Snapshot: map[string]interface{}{"app": "api"},
+	}
+
+	updater := new(mockInlineSnapshotUpdater)
+	updater.On("UpdateInlineSnapshot", map[string]interface{}{"app": "web"}, false).Return(false)
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:                  []common.K8sManifest{makeManifest(inlineSnapshotDoc)},
+		InlineSnapshotUpdater: updater,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Path:\tspec.selector",
+		"Expected to match inline snapshot:",
+		"\t~ app: api → web",
+	}, diff)
+	updater.AssertExpectations(t)
+}
+
+func TestInlineSnapshotValidatorWhenUpdated(t *testing.T) {
+	validator := MatchInlineSnapshotValidator{
+		Path:     "spec.type",
+		Snapshot: "NodePort",
+	}
+
+	updater := new(mockInlineSnapshotUpdater)
+	updater.On("UpdateInlineSnapshot", "ClusterIP", false).Return(true)
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:                  []common.K8sManifest{makeManifest(inlineSnapshotDoc)},
+		InlineSnapshotUpdater: updater,
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+	updater.AssertExpectations(t)
+}
+
+func TestInlineSnapshotValidatorWhenNegativeAndFail(t *testing.T) {
+	validator := MatchInlineSnapshotValidator{
+		Path:     "spec.type",
+		Snapshot: "ClusterIP",
+	}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:     []common.K8sManifest{makeManifest(inlineSnapshotDoc)},
+		Negative: true,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Path:\tspec.type",
+		"Expected NOT to match inline snapshot:",
+		"\tClusterIP",
+	}, diff)
+}
+
+func TestInlineSnapshotValidatorWhenUnknownPath(t *testing.T) {
+	validator := MatchInlineSnapshotValidator{
+		Path:     "spec.unknown",
+		Snapshot: "ClusterIP",
+	}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(inlineSnapshotDoc)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Error:",
+		"\tunknown path spec.unknown",
+	}, diff)
+}
@@ -7,12 +7,13 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/helm-unittest/helm-unittest/internal/common"
-	"github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
 )
 
 // IsNullOrEmptyValidator validate value of Path is empty
 type IsNullOrEmptyValidator struct {
-	Path string
+	Path    string
+	Decode  interface{}
+	SubPath string
 }
 
 func (v IsNullOrEmptyValidator) failInfo(actual interface{}, manifestIndex, actualIndex int, not bool) []string {
@@ -30,7 +31,7 @@ func (v IsNullOrEmptyValidator) failInfo(actual interface{}, manifestIndex, actu
 }
 
 func (v IsNullOrEmptyValidator) validateManifest(manifest common.K8sManifest, manifestIndex int, context *ValidateContext) (bool, []string) {
-	actual, err := valueutils.GetValueOfSetPath(manifest, v.Path)
+	actual, err := valuesOfPath(manifest, v.Path, v.Decode, v.SubPath)
 	if err != nil {
 		return false, splitInfof(errorFormat, manifestIndex, -1, err.Error())
 	}
@@ -30,7 +30,7 @@ func TestIsNullOrEmptyValidatorWhenOk(t *testing.T) {
 	manifest := makeManifest(docWithEmptyElements)
 
 	for key := range manifest {
-		validator := IsNullOrEmptyValidator{key}
+		validator := IsNullOrEmptyValidator{Path: key}
 		pass, diff := validator.Validate(&ValidateContext{
 			Docs: []common.K8sManifest{manifest},
 		})
@@ -44,7 +44,7 @@ func TestIsNullOrEmptyValidatorWhenNegativeAndOk(t *testing.T) {
 	manifest := makeManifest(docWithNonEmptyElement)
 
 	for key := range manifest {
-		validator := IsNullOrEmptyValidator{key}
+		validator := IsNullOrEmptyValidator{Path: key}
 		pass, diff := validator.Validate(&ValidateContext{
 			Docs:     []common.K8sManifest{manifest},
 			Negative: true,
@@ -61,7 +61,7 @@ func TestIsNullOrEmptyValidatorWhenFail(t *testing.T) {
 	log.SetLevel(log.DebugLevel)
 
 	for key, value := range manifest {
-		validator := IsNullOrEmptyValidator{key}
+		validator := IsNullOrEmptyValidator{Path: key}
 		valueYAML := common.TrustedMarshalYAML(value)
 		pass, diff := validator.Validate(&ValidateContext{
 			Docs: []common.K8sManifest{manifest},
@@ -81,7 +81,7 @@ func TestIsNullOrEmptyValidatorWhenNegativeAndFail(t *testing.T) {
 	manifest := makeManifest(docWithEmptyElements)
 
 	for key, value := range manifest {
-		validator := IsNullOrEmptyValidator{key}
+		validator := IsNullOrEmptyValidator{Path: key}
 		pass, diff := validator.Validate(&ValidateContext{
 			Docs:     []common.K8sManifest{manifest},
 			Negative: true,
@@ -103,7 +103,7 @@ func TestIsNullOrEmptyValidatorWhenNegativeAndFail(t *testing.T) {
 func TestIsNullOrEmptyValidatorWhenInvalidPath(t *testing.T) {
 	manifest := makeManifest(docWithEmptyElements)
 
-	validator := IsNullOrEmptyValidator{"x.a"}
+	validator := IsNullOrEmptyValidator{Path: "x.a"}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
 	})
@@ -119,7 +119,7 @@ func TestIsNullOrEmptyValidatorWhenInvalidPath(t *testing.T) {
 func TestIsNullOrEmptyValidatorWhenInvalidPathNegative(t *testing.T) {
 	manifest := makeManifest(docWithEmptyElements)
 
-	validator := IsNullOrEmptyValidator{"x.a"}
+	validator := IsNullOrEmptyValidator{Path: "x.a"}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs:     []common.K8sManifest{manifest},
 		Negative: true,
@@ -132,7 +132,7 @@ func TestIsNullOrEmptyValidatorWhenInvalidPathNegative(t *testing.T) {
 func TestIsNullOrEmptyValidatorWhenInvalidPathFailFast(t *testing.T) {
 	manifest := makeManifest(docWithEmptyElements)
 
-	validator := IsNullOrEmptyValidator{"x.a"}
+	validator := IsNullOrEmptyValidator{Path: "x.a"}
 	pass, diff := validator.Validate(&ValidateContext{
 		FailFast: true,
 		Docs:     []common.K8sManifest{manifest, manifest},
@@ -152,7 +152,7 @@ func TestIsNullOrEmptyValidatorWhenFailFast(t *testing.T) {
 	log.SetLevel(log.DebugLevel)
 
 	for key, value := range manifest {
-		validator := IsNullOrEmptyValidator{key}
+		validator := IsNullOrEmptyValidator{Path: key}
 		valueYAML := common.TrustedMarshalYAML(value)
 		pass, diff := validator.Validate(&ValidateContext{
 			FailFast: true,
@@ -172,7 +172,7 @@ func TestIsNullOrEmptyValidatorWhenFailFast(t *testing.T) {
 func TestFailWhenInvalidJsonPath(t *testing.T) {
 	manifest := makeManifest(docWithEmptyElements)
 
-	validator := IsNullOrEmptyValidator{"x[b]"}
+	validator := IsNullOrEmptyValidator{Path: "x[b]"}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest, manifest},
 	})
@@ -191,7 +191,7 @@ func TestFailWhenInvalidJsonPath(t *testing.T) {
 func TestFailWhenInvalidJsonPathFailFast(t *testing.T) {
 	manifest := makeManifest(docWithEmptyElements)
 
-	validator := IsNullOrEmptyValidator{"x[b]"}
+	validator := IsNullOrEmptyValidator{Path: "x[b]"}
 	pass, diff := validator.Validate(&ValidateContext{
 		FailFast: true,
 		Docs:     []common.K8sManifest{manifest, manifest},
@@ -206,7 +206,7 @@ func TestFailWhenInvalidJsonPathFailFast(t *testing.T) {
 }
 
 func TestIsNullOrEmptyValidatorWhenNoManifestFail(t *testing.T) {
-	validator := IsNullOrEmptyValidator{"key"}
+	validator := IsNullOrEmptyValidator{Path: "key"}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{},
 	})
@@ -219,7 +219,7 @@ func TestIsNullOrEmptyValidatorWhenNoManifestFail(t *testing.T) {
 }
 
 func TestIsNullOrEmptyValidatorWhenNoManifestNegativeOk(t *testing.T) {
-	validator := IsNullOrEmptyValidator{"key"}
+	validator := IsNullOrEmptyValidator{Path: "key"}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs:     []common.K8sManifest{},
 		Negative: true,
@@ -0,0 +1,123 @@
+package validators
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
+	log "github.com/sirupsen/logrus"
+)
+
+// IsSemverValidator validate whether the value of Path is a semantic version, with an optional v prefix.
+// The version is parsed strictly, so partial versions like 1.2 are not semantic versions.
+// For image references, like nginx:1.25.3, the tag of the image is validated.
+type IsSemverValidator struct {
+	Path string
+}
+
+func (v IsSemverValidator) failInfo(msg string, manifestIndex, actualIndex int, not bool) []string {
+	log.WithField("validator", "is_semver").Debugln("actual content:", msg)
+
+	return splitInfof(
+		setFailFormat(not, true, false, false, " to be a semantic version, got"),
+		manifestIndex,
+		actualIndex,
+		v.Path,
+		msg,
+	)
+}
+
+// parseSemver parses a strict semantic version with an optional v prefix, the same rule for isSemver and semverSatisfies
+func parseSemver(version string) (*semver.Version, error) {
+	return semver.StrictNewVersion(strings.TrimPrefix(version, "v"))
+}
+
+// semverOf returns the version of the value and the description of the value to show,
+// the version of an image reference is its tag
+func semverOf(actual interface{}) (string, string, error) {
+	value := fmt.Sprintf("%v", actual)
+	if _, err := parseSemver(value); err == nil || !strings.Contains(value, ":") {
+		return value, fmt.Sprintf("the actual '%s'", value), nil
+	}
+
+	tag := parseImageReference(value).tag
+	if tag == "" {
+		return "", "", fmt.Errorf("the image '%s' has no tag", value)
+	}
+	return tag, fmt.Sprintf("the tag '%s' of the image '%s'", tag, value), nil
+}
+
+// forEachValue validates each value of the path of the manifest, with the index of the value
+func forEachValue(manifest common.K8sManifest, manifestIndex int, path string, context *ValidateContext, validate func(actual interface{}, actualIndex int) (bool, []string)) (bool, []string) {
+	actuals, err := valueutils.GetValueOfSetPath(manifest, path)
+	if err != nil {
+		return false, splitInfof(errorFormat, manifestIndex, -1, err.Error())
+	}
+
+	if len(actuals) == 0 && !context.Negative {
+		return false, splitInfof(errorFormat, manifestIndex, -1, fmt.Sprintf("unknown path '%s'", path))
+	}
+
+	validateManifestSuccess := (len(actuals) == 0 && context.Negative)
+	var validateManifestErrors []string
+
+	for actualIndex, actual := range actuals {
+		validateSingleSuccess, validateSingleErrors := validate(actual, actualIndex)
+		validateManifestErrors = append(validateManifestErrors, validateSingleErrors...)
+		validateManifestSuccess = determineSuccess(actualIndex, validateManifestSuccess, validateSingleSuccess)
+
+		if !validateManifestSuccess && context.FailFast {
+			break
+		}
+	}
+
+	return validateManifestSuccess, validateManifestErrors
+}
+
+func (v IsSemverValidator) validateSingle(actual interface{}, manifestIndex, actualIndex int, context *ValidateContext) (bool, []string) {
+	version, description, err := semverOf(actual)
+	if err != nil {
+		return false, splitInfof(errorFormat, manifestIndex, actualIndex, err.Error())
+	}
+
+	_, err = parseSemver(version)
+	if (err == nil) == context.Negative {
+		msg := fmt.Sprintf("%s is a semantic version", description)
+		if err != nil {
+			msg = fmt.Sprintf("%s is not a semantic version", description)
+		}
+		return false, v.failInfo(msg, manifestIndex, actualIndex, context.Negative)
+	}
+
+	return true, []string{}
+}
+
+// Validate implement Validatable
+func (v IsSemverValidator) Validate(context *ValidateContext) (bool, []string) {
+	manifests := context.getManifests()
+
+	validateSuccess := false
+	validateErrors := make([]string, 0)
+
+	for manifestIndex, manifest := range manifests {
+		validateManifestSuccess, validateManifestErrors := forEachValue(manifest, manifestIndex, v.Path, context, func(actual interface{}, actualIndex int) (bool, []string) {
+			return v.validateSingle(actual, manifestIndex, actualIndex, context)
+		})
+		validateErrors = append(validateErrors, validateManifestErrors...)
+		validateSuccess = determineSuccess(manifestIndex, validateSuccess, validateManifestSuccess)
+
+		if !validateSuccess && context.FailFast {
+			break
+		}
+	}
+
+	if len(manifests) == 0 && !context.Negative {
+		validateErrors = append(validateErrors, v.failInfo("no manifests found", -1, -1, context.Negative)...)
+	} else if len(manifests) == 0 && context.Negative {
+		validateSuccess = true
+	}
+
+	return validateSuccess, validateErrors
+}
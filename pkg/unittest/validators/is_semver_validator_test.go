@@ -0,0 +1,111 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+var semverDoc = `
+metadata:
+  labels:
+    appVersion: v1.4.2
+  annotations:
+    version: latest
+spec:
+  containers:
+    - image: registry.example.com:5000/web:1.25.3@sha256:abc
+    - image: nginx:stable
+    - image: nginx
+`
+
+func TestIsSemverValidatorWhenOk(t *testing.T) {
+	tests := []struct {
+		name, path string
+	}{
+		{name: "plain value with v prefix", path: "metadata.labels.appVersion"},
+		{name: "tag of image reference", path: "spec.containers[0].image"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := IsSemverValidator{Path: tt.path}
+			pass, diff := v.Validate(&ValidateContext{
+				Docs: []common.K8sManifest{makeManifest(semverDoc)},
+			})
+
+			assert.True(t, pass)
+			assert.Equal(t, []string{}, diff)
+		})
+	}
+}
+
+func TestIsSemverValidatorWhenFail(t *testing.T) {
+	v := IsSemverValidator{Path: "metadata.annotations.version"}
+	pass, diff := v.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(semverDoc)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"ValuesIndex:\t0",
+		"Path:\tmetadata.annotations.version",
+		"Expected to be a semantic version, got:",
+		"\tthe actual 'latest' is not a semantic version",
+	}, diff)
+}
+
+func TestIsSemverValidatorWhenImageTagFail(t *testing.T) {
+	v := IsSemverValidator{Path: "spec.containers[*].image"}
+	pass, diff := v.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(semverDoc)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"ValuesIndex:\t1",
+		"Path:\tspec.containers[*].image",
+		"Expected to be a semantic version, got:",
+		"\tthe tag 'stable' of the image 'nginx:stable' is not a semantic version",
+		"DocumentIndex:\t0",
+		"ValuesIndex:\t2",
+		"Path:\tspec.containers[*].image",
+		"Expected to be a semantic version, got:",
+		"\tthe actual 'nginx' is not a semantic version",
+	}, diff)
+}
+
+func TestIsSemverValidatorWhenNegativeAndFail(t *testing.T) {
+	v := IsSemverValidator{Path: "metadata.labels.appVersion"}
+	pass, diff := v.Validate(&ValidateContext{
+		Docs:     []common.K8sManifest{makeManifest(semverDoc)},
+		Negative: true,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"ValuesIndex:\t0",
+		"Path:\tmetadata.labels.appVersion",
+		"Expected NOT to be a semantic version, got:",
+		"\tthe actual 'v1.4.2' is a semantic version",
+	}, diff)
+}
+
+func TestIsSemverValidatorWhenUnknownPath(t *testing.T) {
+	v := IsSemverValidator{Path: "metadata.labels.version"}
+	pass, diff := v.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(semverDoc)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Error:",
+		"\tunknown path 'metadata.labels.version'",
+	}, diff)
+}
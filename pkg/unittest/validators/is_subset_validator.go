@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/helm-unittest/helm-unittest/internal/common"
-	"github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -12,6 +11,8 @@ import (
 type IsSubsetValidator struct {
 	Path    string
 	Content interface{}
+	Decode  interface{}
+	SubPath string
 }
 
 func (v IsSubsetValidator) failInfo(actual interface{}, manifestIndex, valueIndex int, not bool) []string {
@@ -21,18 +22,40 @@ func (v IsSubsetValidator) failInfo(actual interface{}, manifestIndex, valueInde
 	log.WithField("validator", "is_subset").Debugln("expected content:", expectedYAML)
 	log.WithField("validator", "is_subset").Debugln("actual content:", actualYAML)
 
+	actualMap, actualOk := actual.(map[string]interface{})
+	contentMap, contentOk := v.Content.(map[string]interface{})
+	if not || !actualOk || !contentOk {
+		return splitInfof(
+			setFailFormat(not, true, true, false, " to contain"),
+			manifestIndex,
+			valueIndex,
+			v.Path,
+			expectedYAML,
+			actualYAML,
+		)
+	}
+
+	// Only the keys of the content are compared, the other keys of the actual value are no changes
+	actualSubset := make(map[string]interface{}, len(contentMap))
+	for key := range contentMap {
+		if value, ok := actualMap[key]; ok {
+			actualSubset[key] = value
+		}
+	}
+
 	return splitInfof(
-		setFailFormat(not, true, true, false, " to contain"),
+		setFailFormat(not, true, true, true, " to contain"),
 		manifestIndex,
 		valueIndex,
 		v.Path,
 		expectedYAML,
 		actualYAML,
+		semanticDiff(v.Content, actualSubset, expectedYAML, common.TrustedMarshalYAML(actualSubset)),
 	)
 }
 
 func (v IsSubsetValidator) validateManifest(manifest common.K8sManifest, manifestIndex int, context *ValidateContext) (bool, []string) {
-	actual, err := valueutils.GetValueOfSetPath(manifest, v.Path)
+	actual, err := valuesOfPath(manifest, v.Path, v.Decode, v.SubPath)
 	if err != nil {
 		return false, splitInfof(errorFormat, manifestIndex, -1, err.Error())
 	}
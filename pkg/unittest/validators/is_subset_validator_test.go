@@ -21,8 +21,8 @@ func TestIsSubsetValidatorWhenOk(t *testing.T) {
 	manifest := makeManifest(docToTestIsSubset)
 
 	validator := IsSubsetValidator{
-		"a.b",
-		map[string]interface{}{"d": "foo bar", "x": "baz"}}
+		Path:    "a.b",
+		Content: map[string]interface{}{"d": "foo bar", "x": "baz"}}
 
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
@@ -36,8 +36,8 @@ func TestIsSubsetValidatorWhenNegativeAndOk(t *testing.T) {
 	manifest := makeManifest(docToTestIsSubset)
 
 	validator := IsSubsetValidator{
-		"a.b",
-		map[string]interface{}{"d": "hello bar", "c": "hello world"}}
+		Path:    "a.b",
+		Content: map[string]interface{}{"d": "hello bar", "c": "hello world"}}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs:     []common.K8sManifest{manifest},
 		Negative: true,
@@ -53,8 +53,8 @@ func TestIsSubsetValidatorWhenFail(t *testing.T) {
 	log.SetLevel(log.DebugLevel)
 
 	validator := IsSubsetValidator{
-		"a.b",
-		map[string]interface{}{"e": "bar bar"},
+		Path:    "a.b",
+		Content: map[string]interface{}{"e": "bar bar"},
 	}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
@@ -71,6 +71,8 @@ func TestIsSubsetValidatorWhenFail(t *testing.T) {
 		"	c: hello world",
 		"	d: foo bar",
 		"	x: baz",
+		"Diff:",
+		"	- e: removed bar bar",
 	}, diff)
 }
 
@@ -85,8 +87,8 @@ a:
 	manifests := []common.K8sManifest{manifest1, manifest2}
 
 	validator := IsSubsetValidator{
-		"a.b",
-		map[string]interface{}{"d": "foo bar"},
+		Path:    "a.b",
+		Content: map[string]interface{}{"d": "foo bar"},
 	}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: manifests,
@@ -101,6 +103,8 @@ a:
 		"	d: foo bar",
 		"Actual:",
 		"	c: hello world",
+		"Diff:",
+		"	- d: removed foo bar",
 	}, diff)
 }
 
@@ -109,8 +113,8 @@ func TestIsSubsetValidatorMultiManifestWhenBothFail(t *testing.T) {
 	manifests := []common.K8sManifest{manifest1, manifest1}
 
 	validator := IsSubsetValidator{
-		"a.b",
-		map[string]interface{}{"e": "foo bar"},
+		Path:    "a.b",
+		Content: map[string]interface{}{"e": "foo bar"},
 	}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: manifests,
@@ -127,6 +131,8 @@ func TestIsSubsetValidatorMultiManifestWhenBothFail(t *testing.T) {
 		"	c: hello world",
 		"	d: foo bar",
 		"	x: baz",
+		"Diff:",
+		"	- e: removed foo bar",
 		"DocumentIndex:	1",
 		"ValuesIndex:	0",
 		"Path:	a.b",
@@ -136,6 +142,8 @@ func TestIsSubsetValidatorMultiManifestWhenBothFail(t *testing.T) {
 		"	c: hello world",
 		"	d: foo bar",
 		"	x: baz",
+		"Diff:",
+		"	- e: removed foo bar",
 	}, diff)
 }
 
@@ -143,8 +151,8 @@ func TestIsSubsetValidatorWhenNegativeAndFail(t *testing.T) {
 	manifest := makeManifest(docToTestIsSubset)
 
 	validator := IsSubsetValidator{
-		"a.b",
-		map[string]interface{}{"d": "foo bar"},
+		Path:    "a.b",
+		Content: map[string]interface{}{"d": "foo bar"},
 	}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs:     []common.K8sManifest{manifest},
@@ -174,7 +182,7 @@ a:
 `
 	manifest := makeManifest(manifestDocNotObject)
 
-	validator := IsSubsetValidator{"a.b.c", common.K8sManifest{"d": "foo bar"}}
+	validator := IsSubsetValidator{Path: "a.b.c", Content: common.K8sManifest{"d": "foo bar"}}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
 	})
@@ -198,7 +206,7 @@ a:
 `
 	manifest := makeManifest(manifestDocNotObject)
 
-	validator := IsSubsetValidator{"a.b.c", common.K8sManifest{"d": "foo bar"}}
+	validator := IsSubsetValidator{Path: "a.b.c", Content: common.K8sManifest{"d": "foo bar"}}
 	pass, diff := validator.Validate(&ValidateContext{
 		FailFast: true,
 		Docs:     []common.K8sManifest{manifest, manifest},
@@ -217,7 +225,7 @@ a:
 func TestIsSubsetValidatorWhenInvalidPath(t *testing.T) {
 	manifest := makeManifest("a: error")
 
-	validator := IsSubsetValidator{"a[b]", common.K8sManifest{"d": "foo bar"}}
+	validator := IsSubsetValidator{Path: "a[b]", Content: common.K8sManifest{"d": "foo bar"}}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
 	})
@@ -233,7 +241,7 @@ func TestIsSubsetValidatorWhenInvalidPath(t *testing.T) {
 func TestIsSubsetValidatorWhenUnknownPath(t *testing.T) {
 	manifest := makeManifest("a: error")
 
-	validator := IsSubsetValidator{"a[5]", common.K8sManifest{"d": "foo bar"}}
+	validator := IsSubsetValidator{Path: "a[5]", Content: common.K8sManifest{"d": "foo bar"}}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
 	})
@@ -249,7 +257,7 @@ func TestIsSubsetValidatorWhenUnknownPath(t *testing.T) {
 func TestIsSubsetValidatorWhenUnknownPathNegative(t *testing.T) {
 	manifest := makeManifest("a: error")
 
-	validator := IsSubsetValidator{"a[5]", common.K8sManifest{"d": "foo bar"}}
+	validator := IsSubsetValidator{Path: "a[5]", Content: common.K8sManifest{"d": "foo bar"}}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs:     []common.K8sManifest{manifest},
 		Negative: true,
@@ -262,7 +270,7 @@ func TestIsSubsetValidatorWhenUnknownPathNegative(t *testing.T) {
 func TestIsSubsetValidatorWhenUnknownPathFailFast(t *testing.T) {
 	manifest := makeManifest("a: error")
 
-	validator := IsSubsetValidator{"a[5]", common.K8sManifest{"d": "foo bar"}}
+	validator := IsSubsetValidator{Path: "a[5]", Content: common.K8sManifest{"d": "foo bar"}}
 	pass, diff := validator.Validate(&ValidateContext{
 		FailFast: true,
 		Docs:     []common.K8sManifest{manifest, manifest},
@@ -279,7 +287,7 @@ func TestIsSubsetValidatorWhenUnknownPathFailFast(t *testing.T) {
 func TestIsSubsetValidatorWhenInvalidPathFailFast(t *testing.T) {
 	manifest := makeManifest("a: error")
 
-	validator := IsSubsetValidator{"a[b]", common.K8sManifest{"d": "foo bar"}}
+	validator := IsSubsetValidator{Path: "a[b]", Content: common.K8sManifest{"d": "foo bar"}}
 	pass, diff := validator.Validate(&ValidateContext{
 		FailFast: true,
 		Docs:     []common.K8sManifest{manifest, manifest},
@@ -299,8 +307,8 @@ func TestIsSubsetValidatorWhenFailFast(t *testing.T) {
 	log.SetLevel(log.DebugLevel)
 
 	validator := IsSubsetValidator{
-		"a.b",
-		map[string]interface{}{"e": "bar bar"},
+		Path:    "a.b",
+		Content: map[string]interface{}{"e": "bar bar"},
 	}
 	pass, diff := validator.Validate(&ValidateContext{
 		FailFast: true,
@@ -318,13 +326,15 @@ func TestIsSubsetValidatorWhenFailFast(t *testing.T) {
 		"	c: hello world",
 		"	d: foo bar",
 		"	x: baz",
+		"Diff:",
+		"	- e: removed bar bar",
 	}, diff)
 }
 
 func TestIsSubsetValidatorWhenNoManifestFail(t *testing.T) {
 	validator := IsSubsetValidator{
-		"a.b",
-		map[string]interface{}{"e": "bar bar"},
+		Path:    "a.b",
+		Content: map[string]interface{}{"e": "bar bar"},
 	}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{},
@@ -342,8 +352,8 @@ func TestIsSubsetValidatorWhenNoManifestFail(t *testing.T) {
 
 func TestIsSubsetValidatorWhenNoManifestNegativeOk(t *testing.T) {
 	validator := IsSubsetValidator{
-		"a.b",
-		map[string]interface{}{"e": "bar bar"},
+		Path:    "a.b",
+		Content: map[string]interface{}{"e": "bar bar"},
 	}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs:     []common.K8sManifest{},
@@ -7,13 +7,14 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/helm-unittest/helm-unittest/internal/common"
-	"github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
 )
 
 // IsTypeValidator validate the type of the value at Path
 type IsTypeValidator struct {
-	Path string
-	Type string
+	Path    string
+	Type    string
+	Decode  interface{}
+	SubPath string
 }
 
 func (t IsTypeValidator) failInfo(actual string, manifestIndex, valueIndex int, not bool) []string {
@@ -33,7 +34,7 @@ func (t IsTypeValidator) failInfo(actual string, manifestIndex, valueIndex int,
 }
 
 func (t IsTypeValidator) validateManifest(manifest common.K8sManifest, manifestIndex int, context *ValidateContext) (bool, []string) {
-	actuals, err := valueutils.GetValueOfSetPath(manifest, t.Path)
+	actuals, err := valuesOfPath(manifest, t.Path, t.Decode, t.SubPath)
 	if err != nil {
 		return false, splitInfof(errorFormat, manifestIndex, -1, err.Error())
 	}
@@ -20,7 +20,7 @@ a:
 
 func TestTypeValidatorWhenOk(t *testing.T) {
 	manifest := makeManifest(docToTestType)
-	validator := IsTypeValidator{"a.b[0].c", "int"}
+	validator := IsTypeValidator{Path: "a.b[0].c", Type: "int"}
 
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
@@ -32,7 +32,7 @@ func TestTypeValidatorWhenOk(t *testing.T) {
 
 func TestTypeValidatorMultiLineWhenOk(t *testing.T) {
 	manifest := makeManifest(docToTestType)
-	validator := IsTypeValidator{"a.e", "string"}
+	validator := IsTypeValidator{Path: "a.e", Type: "string"}
 
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
@@ -45,7 +45,7 @@ func TestTypeValidatorMultiLineWhenOk(t *testing.T) {
 func TestTypeValidatorWhenNegativeAndOk(t *testing.T) {
 	manifest := makeManifest(docToTestType)
 
-	validator := IsTypeValidator{"a.b[0].c", "string"}
+	validator := IsTypeValidator{Path: "a.b[0].c", Type: "string"}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs:     []common.K8sManifest{manifest},
 		Negative: true,
@@ -60,7 +60,7 @@ func TestTypeValidatorWhenFail(t *testing.T) {
 
 	log.SetLevel(log.DebugLevel)
 
-	validator := IsTypeValidator{"a.b[0]", "int"}
+	validator := IsTypeValidator{Path: "a.b[0]", Type: "int"}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
 	})
@@ -82,7 +82,7 @@ func TestTypeValidatorWhenFailFast(t *testing.T) {
 
 	log.SetLevel(log.DebugLevel)
 
-	validator := IsTypeValidator{"a.b[0]", "int"}
+	validator := IsTypeValidator{Path: "a.b[0]", Type: "int"}
 	pass, diff := validator.Validate(&ValidateContext{
 		FailFast: true,
 		Docs:     []common.K8sManifest{manifest, manifest},
@@ -109,7 +109,7 @@ a:
 	manifest1 := makeManifest(correctDoc)
 	manifest2 := makeManifest(docToTestType)
 
-	validator := IsTypeValidator{"a.b[0].c", "string"}
+	validator := IsTypeValidator{Path: "a.b[0].c", Type: "string"}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest1, manifest2},
 	})
@@ -129,7 +129,7 @@ a:
 func TestTypeValidatorMultiManifestWhenBothFail(t *testing.T) {
 	manifest := makeManifest(docToTestType)
 
-	validator := IsTypeValidator{"a.e", "int"}
+	validator := IsTypeValidator{Path: "a.e", Type: "int"}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest, manifest},
 	})
@@ -156,7 +156,7 @@ func TestTypeValidatorMultiManifestWhenBothFail(t *testing.T) {
 func TestTypeValidatorWhenNegativeAndFail(t *testing.T) {
 	manifest := makeManifest(docToTestType)
 
-	v := IsTypeValidator{"a.e", "string"}
+	v := IsTypeValidator{Path: "a.e", Type: "string"}
 	pass, diff := v.Validate(&ValidateContext{
 		Docs:     []common.K8sManifest{manifest},
 		Negative: true,
@@ -177,7 +177,7 @@ func TestTypeValidatorWhenNegativeAndFail(t *testing.T) {
 func TestTypeValidatorWhenWrongPath(t *testing.T) {
 	manifest := makeManifest(docToTestType)
 
-	v := IsTypeValidator{"a.b[e]", "int"}
+	v := IsTypeValidator{Path: "a.b[e]", Type: "int"}
 	pass, diff := v.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
 	})
@@ -193,7 +193,7 @@ func TestTypeValidatorWhenWrongPath(t *testing.T) {
 func TestTypeValidatorWhenWrongPathFailFast(t *testing.T) {
 	manifest := makeManifest(docToTestType)
 
-	v := IsTypeValidator{"a.b[e]", "int"}
+	v := IsTypeValidator{Path: "a.b[e]", Type: "int"}
 	pass, diff := v.Validate(&ValidateContext{
 		FailFast: true,
 		Docs:     []common.K8sManifest{manifest, manifest},
@@ -210,7 +210,7 @@ func TestTypeValidatorWhenWrongPathFailFast(t *testing.T) {
 func TestTypeValidatorWhenUnkownPath(t *testing.T) {
 	manifest := makeManifest(docToTestType)
 
-	v := IsTypeValidator{"a.b[5]", "string"}
+	v := IsTypeValidator{Path: "a.b[5]", Type: "string"}
 	pass, diff := v.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
 	})
@@ -226,7 +226,7 @@ func TestTypeValidatorWhenUnkownPath(t *testing.T) {
 func TestTypeValidatorWhenUnkownPathNegative(t *testing.T) {
 	manifest := makeManifest(docToTestType)
 
-	v := IsTypeValidator{"a.b[5]", "string"}
+	v := IsTypeValidator{Path: "a.b[5]", Type: "string"}
 	pass, diff := v.Validate(&ValidateContext{
 		Docs:     []common.K8sManifest{manifest},
 		Negative: true,
@@ -239,7 +239,7 @@ func TestTypeValidatorWhenUnkownPathNegative(t *testing.T) {
 func TestTypeValidatorWhenUnkownPathFailFast(t *testing.T) {
 	manifest := makeManifest(docToTestType)
 
-	v := IsTypeValidator{"a.b[5]", "string"}
+	v := IsTypeValidator{Path: "a.b[5]", Type: "string"}
 	pass, diff := v.Validate(&ValidateContext{
 		FailFast: true,
 		Docs:     []common.K8sManifest{manifest, manifest},
@@ -254,7 +254,7 @@ func TestTypeValidatorWhenUnkownPathFailFast(t *testing.T) {
 }
 
 func TestTypeValidatorWhenNoManifestFail(t *testing.T) {
-	validator := IsTypeValidator{"a.b[0]", "int"}
+	validator := IsTypeValidator{Path: "a.b[0]", Type: "int"}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{},
 	})
@@ -270,7 +270,7 @@ func TestTypeValidatorWhenNoManifestFail(t *testing.T) {
 }
 
 func TestTypeValidatorWhenNoManifestNegativeOk(t *testing.T) {
-	validator := IsTypeValidator{"a.b[0]", "int"}
+	validator := IsTypeValidator{Path: "a.b[0]", Type: "int"}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs:     []common.K8sManifest{},
 		Negative: true,
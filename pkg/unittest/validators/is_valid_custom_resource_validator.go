@@ -0,0 +1,335 @@
+package validators
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/yamldiff"
+	log "github.com/sirupsen/logrus"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	openapierrors "k8s.io/kube-openapi/pkg/validation/errors"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+	"sigs.k8s.io/yaml"
+)
+
+// IsValidCustomResourceValidator validate the custom resources against the openAPIV3Schema of their
+// CustomResourceDefinition, which is read from the CRDs of the chart and from the files in CrdDirs.
+// Documents without a matching CustomResourceDefinition are not validated.
+type IsValidCustomResourceValidator struct {
+	// CrdDirs are the directories of CustomResourceDefinitions which are not part of the chart,
+	// like the definitions of third-party operators, relative to the test suite
+	CrdDirs []string
+}
+
+// crdKind is the kind of a CustomResourceDefinition
+const crdKind = "CustomResourceDefinition"
+
+// Extensions of the openAPIV3Schema of Kubernetes
+const (
+	intOrStringExtension           = "x-kubernetes-int-or-string"
+	preserveUnknownFieldsExtension = "x-kubernetes-preserve-unknown-fields"
+	embeddedResourceExtension      = "x-kubernetes-embedded-resource"
+)
+
+// resourceMetaFields are the fields of every resource, which are not part of the schema of a custom resource
+var resourceMetaFields = []string{"apiVersion", "kind", "metadata"}
+
+func (v IsValidCustomResourceValidator) failInfo(info string, manifestIndex int, not bool) []string {
+	log.WithField("validator", "is_valid_custom_resource").Debugln("resource errors:", info)
+
+	return splitInfof(
+		setFailFormat(not, false, false, false, " to be a valid custom resource"),
+		manifestIndex,
+		-1,
+		info,
+	)
+}
+
+// CustomResourceSchemas caches the schemas of the custom resources for the tests of a suite,
+// so the CustomResourceDefinitions of the chart and of each directory are parsed once
+type CustomResourceSchemas struct {
+	chart *crdSchemas
+	dirs  map[string]*crdSchemas
+}
+
+// crdSchemas are the schemas parsed from CustomResourceDefinitions, or the error parsing them
+type crdSchemas struct {
+	schemas map[schema.GroupVersionKind]*spec.Schema
+	err     error
+}
+
+// chartSchemas returns the schemas of the CustomResourceDefinitions of the chart, parsed once or every time without cache
+func (c *CustomResourceSchemas) chartSchemas(definitions [][]byte) (map[schema.GroupVersionKind]*spec.Schema, error) {
+	if c != nil && c.chart != nil {
+		return c.chart.schemas, c.chart.err
+	}
+
+	parsed := &crdSchemas{schemas: make(map[schema.GroupVersionKind]*spec.Schema)}
+	for _, content := range definitions {
+		if parsed.err = addCRDSchemas(parsed.schemas, content); parsed.err != nil {
+			break
+		}
+	}
+	if c != nil {
+		c.chart = parsed
+	}
+	return parsed.schemas, parsed.err
+}
+
+// dirSchemas returns the schemas of the CustomResourceDefinitions in the directory, parsed once or every time without cache
+func (c *CustomResourceSchemas) dirSchemas(dir string) (map[schema.GroupVersionKind]*spec.Schema, error) {
+	if c != nil {
+		if parsed, ok := c.dirs[dir]; ok {
+			return parsed.schemas, parsed.err
+		}
+	}
+
+	parsed := &crdSchemas{schemas: make(map[schema.GroupVersionKind]*spec.Schema)}
+	parsed.err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || !slices.Contains([]string{".yaml", ".yml", ".json"}, filepath.Ext(path)) {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := addCRDSchemas(parsed.schemas, content); err != nil {
+			return fmt.Errorf("failed to parse %s: %s", path, err)
+		}
+		return nil
+	})
+	if c != nil {
+		if c.dirs == nil {
+			c.dirs = make(map[string]*crdSchemas)
+		}
+		c.dirs[dir] = parsed
+	}
+	return parsed.schemas, parsed.err
+}
+
+// loadSchemas returns the schemas of the custom resources by group, version and kind,
+// of the CustomResourceDefinitions of the chart and of the directories
+func (v IsValidCustomResourceValidator) loadSchemas(context *ValidateContext) (map[schema.GroupVersionKind]*spec.Schema, error) {
+	chartSchemas, err := context.CustomResourceSchemas.chartSchemas(context.CustomResourceDefinitions)
+	if err != nil {
+		return nil, err
+	}
+	schemas := maps.Clone(chartSchemas)
+
+	for _, dir := range v.CrdDirs {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(context.BaseDirectory, dir)
+		}
+		dirSchemas, err := context.CustomResourceSchemas.dirSchemas(dir)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(schemas, dirSchemas)
+	}
+	return schemas, nil
+}
+
+// addCRDSchemas adds the schemas of the versions of the CustomResourceDefinitions in the content, other documents are ignored
+func addCRDSchemas(schemas map[schema.GroupVersionKind]*spec.Schema, content []byte) error {
+	for _, document := range releaseutil.SplitManifests(string(content)) {
+		var typeMeta metav1.TypeMeta
+		if err := yaml.Unmarshal([]byte(document), &typeMeta); err != nil {
+			return err
+		}
+		if typeMeta.Kind != crdKind || typeMeta.APIVersion != apiextensionsv1.SchemeGroupVersion.String() {
+			continue
+		}
+
+		var crd apiextensionsv1.CustomResourceDefinition
+		if err := yaml.UnmarshalStrict([]byte(document), &crd); err != nil {
+			return err
+		}
+		for _, version := range crd.Spec.Versions {
+			if version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+				continue
+			}
+			openAPISchema, err := newOpenAPISchema(version.Schema.OpenAPIV3Schema)
+			if err != nil {
+				return fmt.Errorf("invalid schema of %s: %s", crd.Name, err)
+			}
+			gvk := schema.GroupVersionKind{Group: crd.Spec.Group, Version: version.Name, Kind: crd.Spec.Names.Kind}
+			schemas[gvk] = openAPISchema
+		}
+	}
+	return nil
+}
+
+// newOpenAPISchema returns the openAPIV3Schema of a CustomResourceDefinition as OpenAPI schema,
+// with int-or-string values allowing both integers and strings like Kubernetes does
+func newOpenAPISchema(props *apiextensionsv1.JSONSchemaProps) (*spec.Schema, error) {
+	content, err := json.Marshal(props)
+	if err != nil {
+		return nil, err
+	}
+	var openAPISchema spec.Schema
+	if err := json.Unmarshal(content, &openAPISchema); err != nil {
+		return nil, err
+	}
+	allowIntOrString(&openAPISchema)
+	return &openAPISchema, nil
+}
+
+// allowIntOrString allows both integers and strings for the int-or-string values of the schema
+func allowIntOrString(s *spec.Schema) {
+	if isIntOrString, _ := s.Extensions.GetBool(intOrStringExtension); isIntOrString && len(s.Type) == 0 {
+		s.Type = spec.StringOrArray{"integer", "string"}
+	}
+
+	for key, property := range s.Properties {
+		allowIntOrString(&property)
+		s.Properties[key] = property
+	}
+	if s.Items != nil && s.Items.Schema != nil {
+		allowIntOrString(s.Items.Schema)
+	}
+	if s.Items != nil {
+		allowIntOrStringOfAll(s.Items.Schemas)
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		allowIntOrString(s.AdditionalProperties.Schema)
+	}
+	allowIntOrStringOfAll(s.AllOf)
+	allowIntOrStringOfAll(s.AnyOf)
+	allowIntOrStringOfAll(s.OneOf)
+	if s.Not != nil {
+		allowIntOrString(s.Not)
+	}
+}
+
+// allowIntOrStringOfAll allows both integers and strings for the int-or-string values of the schemas
+func allowIntOrStringOfAll(schemas []spec.Schema) {
+	for idx := range schemas {
+		allowIntOrString(&schemas[idx])
+	}
+}
+
+// resourceErrors returns the errors of the manifest against the schema, with their path
+func resourceErrors(manifest common.K8sManifest, openAPISchema *spec.Schema) []string {
+	// The manifest is decoded like the api server decodes it, with plain maps and integers as int64
+	content, err := json.Marshal(manifest)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	var resource map[string]interface{}
+	if err := utiljson.Unmarshal(content, &resource); err != nil {
+		return []string{err.Error()}
+	}
+
+	var errs []string
+	result := validate.NewSchemaValidator(openAPISchema, nil, "", strfmt.Default).Validate(resource)
+	for _, err := range result.Errors {
+		errs = append(errs, schemaError(err))
+	}
+	errs = append(errs, unknownFields(resource, openAPISchema, "", true)...)
+
+	slices.Sort(errs)
+	return slices.Compact(errs)
+}
+
+// schemaError returns the error of the schema validation, with the path of the value first
+func schemaError(err error) string {
+	var validationError *openapierrors.Validation
+	if errors.As(err, &validationError) && validationError.Name != "" {
+		prefix := validationError.Name + " in body "
+		if message, found := strings.CutPrefix(validationError.Error(), prefix); found {
+			return validationError.Name + ": " + message
+		}
+	}
+	return err.Error()
+}
+
+// unknownFields returns the paths of the fields which are not in the schema, the fields Kubernetes prunes
+func unknownFields(value interface{}, s *spec.Schema, path string, isResource bool) []string {
+	if s == nil {
+		return nil
+	}
+
+	var errs []string
+	switch content := value.(type) {
+	case map[string]interface{}:
+		preserveUnknown, _ := s.Extensions.GetBool(preserveUnknownFieldsExtension)
+		embeddedResource, _ := s.Extensions.GetBool(embeddedResourceExtension)
+		for _, key := range slices.Sorted(maps.Keys(content)) {
+			keyPath := yamldiff.JoinKey(path, key)
+			if (isResource || embeddedResource) && slices.Contains(resourceMetaFields, key) {
+				continue
+			} else if property, ok := s.Properties[key]; ok {
+				errs = append(errs, unknownFields(content[key], &property, keyPath, false)...)
+			} else if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+				errs = append(errs, unknownFields(content[key], s.AdditionalProperties.Schema, keyPath, false)...)
+			} else if !preserveUnknown && (s.AdditionalProperties == nil || !s.AdditionalProperties.Allows) {
+				errs = append(errs, fmt.Sprintf("%s: unknown field", keyPath))
+			}
+		}
+	case []interface{}:
+		if s.Items != nil && s.Items.Schema != nil {
+			for idx, item := range content {
+				errs = append(errs, unknownFields(item, s.Items.Schema, yamldiff.JoinIndex(path, idx), false)...)
+			}
+		}
+	}
+	return errs
+}
+
+// Validate implement Validatable
+func (v IsValidCustomResourceValidator) Validate(context *ValidateContext) (bool, []string) {
+	schemas, err := v.loadSchemas(context)
+	if err != nil {
+		return false, splitInfof(errorFormat, -1, -1, err.Error())
+	}
+
+	manifests := context.getManifests()
+
+	validateSuccess := false
+	validateErrors := make([]string, 0)
+
+	for idx, manifest := range manifests {
+		apiVersion, _ := manifest["apiVersion"].(string)
+		kind, _ := manifest["kind"].(string)
+
+		var errs []string
+		if openAPISchema, ok := schemas[schema.FromAPIVersionAndKind(apiVersion, kind)]; ok {
+			errs = resourceErrors(manifest, openAPISchema)
+		}
+
+		manifestSuccess := (len(errs) == 0) != context.Negative
+		if !manifestSuccess {
+			info := strings.Join(errs, "\n")
+			if context.Negative {
+				info = fmt.Sprintf("%s %s", apiVersion, kind)
+			}
+			validateErrors = append(validateErrors, v.failInfo(info, idx, context.Negative)...)
+		}
+		validateSuccess = determineSuccess(idx, validateSuccess, manifestSuccess)
+
+		if !validateSuccess && context.FailFast {
+			break
+		}
+	}
+
+	// Templates which render no documents have no invalid custom resources
+	if len(manifests) == 0 {
+		validateSuccess = true
+	}
+
+	return validateSuccess, validateErrors
+}
@@ -0,0 +1,224 @@
+package validators_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+var widgetCRD = []byte(`
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Widget
+    plural: widgets
+  scope: Namespaced
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            metadata:
+              type: object
+            spec:
+              type: object
+              required: [size]
+              properties:
+                size:
+                  type: integer
+                  minimum: 1
+                port:
+                  x-kubernetes-int-or-string: true
+                maxSurge:
+                  anyOf:
+                    - x-kubernetes-int-or-string: true
+                color:
+                  type: string
+                  enum: [red, blue]
+                parts:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      name:
+                        type: string
+                template:
+                  type: object
+                  x-kubernetes-embedded-resource: true
+                  x-kubernetes-preserve-unknown-fields: true
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: not-a-crd
+data:
+  spec: ignored
+`)
+
+var validWidgetDoc = `
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: widget
+  labels:
+    app: widget
+spec:
+  size: 2
+  port: http
+  color: red
+  parts:
+    - name: wheel
+  template:
+    apiVersion: v1
+    kind: Pod
+    anything: goes
+`
+
+func TestIsValidCustomResourceValidatorWhenOk(t *testing.T) {
+	validator := IsValidCustomResourceValidator{}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{
+			makeManifest(validWidgetDoc),
+			makeManifest("apiVersion: example.com/v2\nkind: Widget\nspec:\n  size: none\n"),
+			makeManifest("apiVersion: v1\nkind: Service\nspec:\n  unknown: true\n"),
+		},
+		CustomResourceDefinitions: [][]byte{widgetCRD},
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
+
+func TestIsValidCustomResourceValidatorWhenFail(t *testing.T) {
+	validator := IsValidCustomResourceValidator{}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{
+			makeManifest(validWidgetDoc),
+			makeManifest(`
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: widget
+spec:
+  port: true
+  maxSurge: false
+  color: green
+  parts:
+    - name: 1
+      weight: 2
+  shape: round
+`),
+		},
+		CustomResourceDefinitions: [][]byte{widgetCRD},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t1",
+		"Expected to be a valid custom resource:",
+		"\t\"spec.maxSurge\" must validate at least one schema (anyOf)",
+		"\tspec.color: should be one of [red blue]",
+		"\tspec.maxSurge: must be of type integer,string: \"boolean\"",
+		"\tspec.parts[0].name: must be of type string: \"integer\"",
+		"\tspec.parts[0].weight: unknown field",
+		"\tspec.port: must be of type integer,string: \"boolean\"",
+		"\tspec.shape: unknown field",
+		"\tspec.size: is required",
+	}, diff)
+}
+
+func TestIsValidCustomResourceValidatorWithCrdDirs(t *testing.T) {
+	directory := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(directory, "crds", "example"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(directory, "crds", "example", "widget.yaml"), widgetCRD, 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(directory, "crds", "README.md"), []byte("# CRDs"), 0644))
+	validator := IsValidCustomResourceValidator{CrdDirs: []string{"crds"}}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:          []common.K8sManifest{makeManifest("apiVersion: example.com/v1\nkind: Widget\nspec:\n  size: 0\n")},
+		BaseDirectory: directory,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Expected to be a valid custom resource:",
+		"\tspec.size: should be greater than or equal to 1",
+	}, diff)
+}
+
+func TestIsValidCustomResourceValidatorWithCachedSchemas(t *testing.T) {
+	directory := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(directory, "widget.yaml"), widgetCRD, 0644))
+	validator := IsValidCustomResourceValidator{CrdDirs: []string{directory}}
+	context := &ValidateContext{
+		Docs:                  []common.K8sManifest{makeManifest("apiVersion: example.com/v1\nkind: Widget\nspec:\n  size: 0\n")},
+		CustomResourceSchemas: &CustomResourceSchemas{},
+	}
+
+	pass, _ := validator.Validate(context)
+	assert.False(t, pass)
+
+	// The directory is not parsed again
+	assert.NoError(t, os.Remove(filepath.Join(directory, "widget.yaml")))
+	pass, diff := validator.Validate(context)
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Expected to be a valid custom resource:",
+		"\tspec.size: should be greater than or equal to 1",
+	}, diff)
+}
+
+func TestIsValidCustomResourceValidatorWhenCrdDirMissing(t *testing.T) {
+	validator := IsValidCustomResourceValidator{CrdDirs: []string{"missing"}}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:          []common.K8sManifest{makeManifest(validWidgetDoc)},
+		BaseDirectory: t.TempDir(),
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, "Error:", diff[0])
+	assert.Contains(t, diff[1], "no such file or directory")
+}
+
+func TestIsValidCustomResourceValidatorWhenNegativeAndFail(t *testing.T) {
+	validator := IsValidCustomResourceValidator{}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:                      []common.K8sManifest{makeManifest(validWidgetDoc)},
+		CustomResourceDefinitions: [][]byte{widgetCRD},
+		Negative:                  true,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Expected NOT to be a valid custom resource:",
+		"\texample.com/v1 Widget",
+	}, diff)
+}
+
+func TestIsValidCustomResourceValidatorWhenNoManifest(t *testing.T) {
+	validator := IsValidCustomResourceValidator{}
+
+	pass, diff := validator.Validate(&ValidateContext{CustomResourceDefinitions: [][]byte{widgetCRD}})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
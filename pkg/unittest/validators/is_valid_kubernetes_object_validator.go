@@ -0,0 +1,220 @@
+package validators
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/yamldiff"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// IsValidKubernetesObjectValidator validate the documents strictly decode into the Kubernetes type of their
+// apiVersion and kind, reporting unknown fields and type mismatches. Documents of kinds which are not built
+// into Kubernetes, like custom resources, are not validated.
+type IsValidKubernetesObjectValidator struct{}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+func (v IsValidKubernetesObjectValidator) failInfo(info string, manifestIndex int, not bool) []string {
+	log.WithField("validator", "is_valid_kubernetes_object").Debugln("object errors:", info)
+
+	return splitInfof(
+		setFailFormat(not, false, false, false, " to be a valid Kubernetes object"),
+		manifestIndex,
+		-1,
+		info,
+	)
+}
+
+// objectErrors returns the errors decoding the manifest into the Kubernetes type of its apiVersion and kind,
+// the manifest is not validated when its kind is not built into Kubernetes or when it is no yaml document
+func objectErrors(manifest common.K8sManifest) []string {
+	if _, ok := manifest[common.RAW]; ok {
+		return nil
+	}
+
+	apiVersion, _ := manifest["apiVersion"].(string)
+	kind, _ := manifest["kind"].(string)
+	if apiVersion == "" || kind == "" {
+		return []string{"apiVersion and kind are required"}
+	}
+
+	object, err := scheme.Scheme.New(schema.FromAPIVersionAndKind(apiVersion, kind))
+	if runtime.IsNotRegisteredError(err) {
+		return nil
+	}
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	return decodeStrict(map[string]interface{}(manifest), reflect.TypeOf(object), "")
+}
+
+// decodeStrict returns the unknown fields and type mismatches of the value decoded into the type, with their path
+func decodeStrict(value interface{}, t reflect.Type, path string) []string {
+	if value == nil {
+		return nil
+	}
+	if t.Kind() == reflect.Pointer {
+		return decodeStrict(value, t.Elem(), path)
+	}
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) || t.Kind() == reflect.Interface {
+		return decodeValue(value, t, path)
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		content := asMap(value)
+		if content == nil {
+			return []string{typeMismatch(path, "object", value)}
+		}
+		fields := jsonFields(t)
+		var errs []string
+		for _, key := range slices.Sorted(maps.Keys(content)) {
+			keyPath := yamldiff.JoinKey(path, key)
+			field, ok := fields[key]
+			if !ok {
+				errs = append(errs, fmt.Sprintf("%s: unknown field", keyPath))
+				continue
+			}
+			errs = append(errs, decodeStrict(content[key], field, keyPath)...)
+		}
+		return errs
+	case reflect.Map:
+		content := asMap(value)
+		if content == nil {
+			return []string{typeMismatch(path, "object", value)}
+		}
+		var errs []string
+		for _, key := range slices.Sorted(maps.Keys(content)) {
+			errs = append(errs, decodeStrict(content[key], t.Elem(), yamldiff.JoinKey(path, key))...)
+		}
+		return errs
+	case reflect.Slice:
+		// byte slices are decoded from base64 strings
+		if t.Elem().Kind() == reflect.Uint8 {
+			return decodeValue(value, t, path)
+		}
+		items, ok := value.([]interface{})
+		if !ok {
+			return []string{typeMismatch(path, "array", value)}
+		}
+		var errs []string
+		for idx, item := range items {
+			errs = append(errs, decodeStrict(item, t.Elem(), yamldiff.JoinIndex(path, idx))...)
+		}
+		return errs
+	default:
+		return decodeValue(value, t, path)
+	}
+}
+
+// decodeValue returns the error decoding the value into the type as json, for values which are not walked by field
+func decodeValue(value interface{}, t reflect.Type, path string) []string {
+	content, err := json.Marshal(value)
+	if err == nil {
+		err = json.Unmarshal(content, reflect.New(t).Interface())
+	}
+	if err == nil {
+		return nil
+	}
+
+	var typeError *json.UnmarshalTypeError
+	if errors.As(err, &typeError) {
+		return []string{fmt.Sprintf("%s: expected %s, got %s", pathOrRoot(path), typeError.Type, typeError.Value)}
+	}
+	return []string{fmt.Sprintf("%s: %s", pathOrRoot(path), err)}
+}
+
+// jsonFields returns the types of the fields of the struct by json name, including the fields of inlined structs
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct || strings.Contains(options, "inline") {
+			maps.Copy(fields, jsonFields(fieldType))
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// typeMismatch returns the error of a value which is not of the expected json type
+func typeMismatch(path, expected string, value interface{}) string {
+	actual := "string"
+	switch value.(type) {
+	case map[string]interface{}, common.K8sManifest:
+		actual = "object"
+	case []interface{}:
+		actual = "array"
+	case bool:
+		actual = "bool"
+	case int, int64, uint64, float64:
+		actual = "number"
+	}
+	return fmt.Sprintf("%s: expected %s, got %s", pathOrRoot(path), expected, actual)
+}
+
+// pathOrRoot returns the path to show, which is (root) for the document itself
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
+
+// Validate implement Validatable
+func (v IsValidKubernetesObjectValidator) Validate(context *ValidateContext) (bool, []string) {
+	manifests := context.getManifests()
+
+	validateSuccess := false
+	validateErrors := make([]string, 0)
+
+	for idx, manifest := range manifests {
+		errs := objectErrors(manifest)
+
+		manifestSuccess := (len(errs) == 0) != context.Negative
+		if !manifestSuccess {
+			info := strings.Join(errs, "\n")
+			if context.Negative {
+				info = fmt.Sprintf("%v %v", manifest["apiVersion"], manifest["kind"])
+			}
+			validateErrors = append(validateErrors, v.failInfo(info, idx, context.Negative)...)
+		}
+		validateSuccess = determineSuccess(idx, validateSuccess, manifestSuccess)
+
+		if !validateSuccess && context.FailFast {
+			break
+		}
+	}
+
+	// Templates which render no documents have no invalid objects
+	if len(manifests) == 0 {
+		validateSuccess = true
+	}
+
+	return validateSuccess, validateErrors
+}
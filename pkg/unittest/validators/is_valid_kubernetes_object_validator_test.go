@@ -0,0 +1,153 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+var validDeploymentDoc = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    app: web
+  creationTimestamp: null
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app: web
+  strategy:
+    rollingUpdate:
+      maxSurge: 25%
+      maxUnavailable: 1
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+        - name: web
+          image: nginx:1.27
+          imagePullPolicy: IfNotPresent
+          ports:
+            - containerPort: 80
+          resources:
+            limits:
+              cpu: 500m
+              memory: 128Mi
+`
+
+var invalidDeploymentDoc = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    enabled: true
+spec:
+  replicas: "2"
+  template:
+    spec:
+      imagePullPolicy: IfNotPresent
+      containers:
+        - name: web
+          ports:
+            - containerPort: "80"
+          resources:
+            limits:
+              cpu: half
+      volumes: {}
+`
+
+func TestIsValidKubernetesObjectValidatorWhenOk(t *testing.T) {
+	validator := IsValidKubernetesObjectValidator{}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{
+			makeManifest(validDeploymentDoc),
+			makeManifest("apiVersion: v1\nkind: Secret\nmetadata:\n  name: web\ndata:\n  password: c2VjcmV0\n"),
+		},
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
+
+func TestIsValidKubernetesObjectValidatorWhenFail(t *testing.T) {
+	validator := IsValidKubernetesObjectValidator{}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(validDeploymentDoc), makeManifest(invalidDeploymentDoc)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t1",
+		"Expected to be a valid Kubernetes object:",
+		"\tmetadata.labels.enabled: expected string, got bool",
+		"\tspec.replicas: expected int32, got string",
+		"\tspec.template.spec.containers[0].ports[0].containerPort: expected int32, got string",
+		"\tspec.template.spec.containers[0].resources.limits.cpu: quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'",
+		"\tspec.template.spec.imagePullPolicy: unknown field",
+		"\tspec.template.spec.volumes: expected array, got object",
+	}, diff)
+}
+
+func TestIsValidKubernetesObjectValidatorWhenKindMissing(t *testing.T) {
+	validator := IsValidKubernetesObjectValidator{}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest("metadata:\n  name: web\n")},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Expected to be a valid Kubernetes object:",
+		"\tapiVersion and kind are required",
+	}, diff)
+}
+
+func TestIsValidKubernetesObjectValidatorWhenCustomResource(t *testing.T) {
+	validator := IsValidKubernetesObjectValidator{}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{
+			makeManifest("apiVersion: monitoring.coreos.com/v1\nkind: ServiceMonitor\nspec:\n  anything: true\n"),
+			{common.RAW: "Thanks for installing"},
+		},
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
+
+func TestIsValidKubernetesObjectValidatorWhenNegativeAndFail(t *testing.T) {
+	validator := IsValidKubernetesObjectValidator{}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:     []common.K8sManifest{makeManifest(validDeploymentDoc)},
+		Negative: true,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Expected NOT to be a valid Kubernetes object:",
+		"\tapps/v1 Deployment",
+	}, diff)
+}
+
+func TestIsValidKubernetesObjectValidatorWhenNoManifest(t *testing.T) {
+	validator := IsValidKubernetesObjectValidator{}
+
+	pass, diff := validator.Validate(&ValidateContext{})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
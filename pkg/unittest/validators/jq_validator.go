@@ -0,0 +1,193 @@
+package validators
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	"github.com/itchyny/gojq"
+	log "github.com/sirupsen/logrus"
+)
+
+// JqValidator validate the outputs of the jq Query over the documents equal to Value,
+// or are truthy, not false nor null, when no Value is given.
+type JqValidator struct {
+	Query string
+	Value interface{}
+	// hasValue is whether Value is given, even as null
+	hasValue bool
+}
+
+// WithUnsetFields implement UnsetFieldsReceiver
+func (v *JqValidator) WithUnsetFields(fields []string) {
+	v.hasValue = !slices.Contains(fields, "Value")
+}
+
+// isTruthy returns whether the outputs are checked to be truthy instead of equal to Value
+func (v JqValidator) isTruthy() bool {
+	return v.Value == nil && !v.hasValue
+}
+
+func (v JqValidator) failInfo(actual interface{}, manifestIndex, outputIndex int, not bool) []string {
+	actualYAML := common.TrustedMarshalYAML(actual)
+
+	log.WithField("validator", "jq").Debugln("query:", v.Query)
+	log.WithField("validator", "jq").Debugln("actual content:", actualYAML)
+
+	if v.isTruthy() {
+		return splitInfof(
+			setFailFormat(not, true, false, false, " to be truthy, got"),
+			manifestIndex,
+			outputIndex,
+			v.Query,
+			actualYAML,
+		)
+	}
+
+	expectedYAML := common.TrustedMarshalYAML(v.Value)
+	if not {
+		return splitInfof(
+			setFailFormat(not, true, false, false, " to equal"),
+			manifestIndex,
+			outputIndex,
+			v.Query,
+			expectedYAML,
+		)
+	}
+	return splitInfof(
+		setFailFormat(not, true, true, true, " to equal"),
+		manifestIndex,
+		outputIndex,
+		v.Query,
+		expectedYAML,
+		actualYAML,
+		semanticDiff(v.Value, actual, expectedYAML, actualYAML),
+	)
+}
+
+// jsonValue returns the value with the plain maps, lists and float64 numbers of a json document,
+// as the jq input and to compare the outputs with the expected value
+func jsonValue(value interface{}) (interface{}, error) {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var plain interface{}
+	if err := json.Unmarshal(content, &plain); err != nil {
+		return nil, err
+	}
+	return plain, nil
+}
+
+// outputs returns all outputs of the query over the manifest
+func outputs(code *gojq.Code, manifest common.K8sManifest) ([]interface{}, error) {
+	input, err := jsonValue(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []interface{}
+	iter := code.Run(input)
+	for {
+		result, ok := iter.Next()
+		if !ok {
+			return results, nil
+		}
+		if err, isError := result.(error); isError {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+}
+
+// matches returns whether the output equals the expected value or is truthy
+func (v JqValidator) matches(output interface{}) (bool, error) {
+	if v.isTruthy() {
+		return output != nil && output != false, nil
+	}
+
+	expected, err := jsonValue(v.Value)
+	if err != nil {
+		return false, err
+	}
+	actual, err := jsonValue(output)
+	if err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(expected, actual), nil
+}
+
+func (v JqValidator) validateManifest(manifest common.K8sManifest, manifestIndex int, code *gojq.Code, context *ValidateContext) (bool, []string) {
+	results, err := outputs(code, manifest)
+	if err != nil {
+		return false, splitInfof(errorFormat, manifestIndex, -1, err.Error())
+	}
+	if len(results) == 0 && !context.Negative {
+		return false, splitInfof(errorFormat, manifestIndex, -1, fmt.Sprintf("no output of query %s", v.Query))
+	}
+
+	manifestSuccess := len(results) == 0 && context.Negative
+	var manifestErrors []string
+
+	for outputIndex, output := range results {
+		// Only show the index of the output when the query has several outputs
+		valueIndex := -1
+		if len(results) > 1 {
+			valueIndex = outputIndex
+		}
+
+		matched, err := v.matches(output)
+		if err != nil {
+			return false, splitInfof(errorFormat, manifestIndex, valueIndex, err.Error())
+		}
+
+		singleSuccess := matched != context.Negative
+		if !singleSuccess {
+			manifestErrors = append(manifestErrors, v.failInfo(output, manifestIndex, valueIndex, context.Negative)...)
+		}
+		manifestSuccess = determineSuccess(outputIndex, manifestSuccess, singleSuccess)
+
+		if !manifestSuccess && context.FailFast {
+			break
+		}
+	}
+
+	return manifestSuccess, manifestErrors
+}
+
+// Validate implement Validatable
+func (v JqValidator) Validate(context *ValidateContext) (bool, []string) {
+	query, err := gojq.Parse(v.Query)
+	if err != nil {
+		return false, splitInfof(errorFormat, -1, -1, fmt.Sprintf("invalid jq query %s: %s", v.Query, err))
+	}
+	code, err := gojq.Compile(query)
+	if err != nil {
+		return false, splitInfof(errorFormat, -1, -1, fmt.Sprintf("invalid jq query %s: %s", v.Query, err))
+	}
+
+	manifests := context.getManifests()
+
+	validateSuccess := false
+	validateErrors := make([]string, 0)
+
+	for idx, manifest := range manifests {
+		manifestSuccess, manifestErrors := v.validateManifest(manifest, idx, code, context)
+		validateErrors = append(validateErrors, manifestErrors...)
+		validateSuccess = determineSuccess(idx, validateSuccess, manifestSuccess)
+
+		if !validateSuccess && context.FailFast {
+			break
+		}
+	}
+
+	if len(manifests) == 0 && !context.Negative {
+		validateErrors = append(validateErrors, splitInfof(errorFormat, -1, -1, "no manifest found")...)
+	} else if len(manifests) == 0 && context.Negative {
+		validateSuccess = true
+	}
+
+	return validateSuccess, validateErrors
+}
@@ -0,0 +1,222 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+var jqDoc = `
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+        - name: web
+          image: nginx
+        - name: metrics
+          image: exporter
+        - name: auth
+          image: proxy
+`
+
+func TestJqValidatorWhenOk(t *testing.T) {
+	validator := JqValidator{
+		Query: "[.spec.template.spec.containers[].name] | sort",
+		Value: []interface{}{"auth", "metrics", "web"},
+	}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(jqDoc)},
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
+
+func TestJqValidatorWhenNumberOk(t *testing.T) {
+	validator := JqValidator{
+		Query: ".spec.template.spec.containers | length",
+		Value: 3,
+	}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(jqDoc)},
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
+
+func TestJqValidatorWhenFail(t *testing.T) {
+	validator := JqValidator{
+		Query: "[.spec.template.spec.containers[].name]",
+		Value: []interface{}{"web", "metrics"},
+	}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(jqDoc)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Path:\t[.spec.template.spec.containers[].name]",
+		"Expected to equal:",
+		"\t- web",
+		"\t- metrics",
+		"Actual:",
+		"\t- web",
+		"\t- metrics",
+		"\t- auth",
+		"Diff:",
+		"\t+ [2]: added auth",
+	}, diff)
+}
+
+func TestJqValidatorWhenTruthyOk(t *testing.T) {
+	validator := JqValidator{Query: ".spec.replicas > 1"}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(jqDoc)},
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
+
+func TestJqValidatorWhenTruthyFailWithSeveralOutputs(t *testing.T) {
+	validator := JqValidator{Query: ".spec.template.spec.containers[].image | startswith(\"n\")"}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(jqDoc)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"ValuesIndex:\t1",
+		"Path:\t.spec.template.spec.containers[].image | startswith(\"n\")",
+		"Expected to be truthy, got:",
+		"\tfalse",
+		"DocumentIndex:\t0",
+		"ValuesIndex:\t2",
+		"Path:\t.spec.template.spec.containers[].image | startswith(\"n\")",
+		"Expected to be truthy, got:",
+		"\tfalse",
+	}, diff)
+}
+
+func TestJqValidatorWhenNullValue(t *testing.T) {
+	validator := JqValidator{Query: ".spec.template.spec.containers[0].resources"}
+	validator.WithUnsetFields([]string{})
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(jqDoc)},
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+
+	validator = JqValidator{Query: ".metadata.name"}
+	validator.WithUnsetFields([]string{})
+
+	pass, diff = validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(jqDoc)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Path:\t.metadata.name",
+		"Expected to equal:",
+		"\tnull",
+		"Actual:",
+		"\tweb",
+		"Diff:",
+		"\t--- Expected",
+		"\t+++ Actual",
+		"\t@@ -1,2 +1,2 @@",
+		"\t-null",
+		"\t+web",
+	}, diff)
+}
+
+func TestJqValidatorWhenNegativeAndFail(t *testing.T) {
+	validator := JqValidator{
+		Query: ".metadata.name",
+		Value: "web",
+	}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:     []common.K8sManifest{makeManifest(jqDoc)},
+		Negative: true,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Path:\t.metadata.name",
+		"Expected NOT to equal:",
+		"\tweb",
+	}, diff)
+}
+
+func TestJqValidatorWhenNoOutput(t *testing.T) {
+	validator := JqValidator{Query: ".spec.template.spec.containers[] | select(.name == \"db\")"}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(jqDoc)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Error:",
+		"\tno output of query .spec.template.spec.containers[] | select(.name == \"db\")",
+	}, diff)
+}
+
+func TestJqValidatorWhenRuntimeError(t *testing.T) {
+	validator := JqValidator{Query: ".metadata.name | keys"}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(jqDoc)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Error:",
+		"\tkeys cannot be applied to: string (\"web\")",
+	}, diff)
+}
+
+func TestJqValidatorWhenNoManifest(t *testing.T) {
+	validator := JqValidator{Query: ".metadata.name"}
+
+	pass, diff := validator.Validate(&ValidateContext{})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Error:",
+		"\tno manifest found",
+	}, diff)
+}
+
+func TestJqValidatorWhenInvalidQuery(t *testing.T) {
+	validator := JqValidator{Query: ".spec | map("}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(jqDoc)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, "Error:", diff[0])
+	assert.Contains(t, diff[1], "invalid jq query .spec | map(")
+}
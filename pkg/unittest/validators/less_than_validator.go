@@ -0,0 +1,23 @@
+package validators
+
+// LessThanValidator validate whether the value of Path is less than Value
+type LessThanValidator struct {
+	Path    string
+	Value   interface{}
+	Decode  interface{}
+	SubPath string
+}
+
+// Validate implement Validatable
+func (l LessThanValidator) Validate(context *ValidateContext) (bool, []string) {
+	operatorValidator := operatorValidator{
+		Path:           l.Path,
+		Decode:         l.Decode,
+		SubPath:        l.SubPath,
+		Value:          l.Value,
+		ComparisonType: "less",
+		Strict:         true,
+	}
+
+	return operatorValidator.Validate(context)
+}
@@ -0,0 +1,72 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLessThanValidatorOk(t *testing.T) {
+	tests := []struct {
+		name, doc, path string
+		value           interface{}
+	}{
+		{name: "test case 1: int values", doc: "spec: 3", path: "spec", value: 4},
+		{name: "test case 2: float64 and int values", doc: "spec: 3.5", path: "spec", value: 4},
+		{name: "test case 3: quantity and int values", doc: "cpu: 500m", path: "cpu", value: 1},
+		{name: "test case 4: duration values", doc: "interval: 30s", path: "interval", value: "1m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := LessThanValidator{
+				Path:  tt.path,
+				Value: tt.value,
+			}
+			pass, diff := v.Validate(&ValidateContext{
+				Docs: []common.K8sManifest{makeManifest(tt.doc)},
+			})
+
+			assert.True(t, pass)
+			assert.Equal(t, []string{}, diff)
+		})
+	}
+}
+
+func TestLessThanValidatorWhenEqualFail(t *testing.T) {
+	v := LessThanValidator{
+		Path:  "memory",
+		Value: "1Gi",
+	}
+	pass, diff := v.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest("memory: 1024Mi")},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"ValuesIndex:\t0",
+		"Path:\tmemory",
+		"Expected to be less than, got:",
+		"\tthe actual '1024Mi' is not less than the expected '1Gi'",
+	}, diff)
+}
+
+func TestLessThanValidatorWhenNoManifestFail(t *testing.T) {
+	v := LessThanValidator{
+		Path:  "a",
+		Value: 2,
+	}
+	pass, diff := v.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Path:\ta",
+		"Expected to be less than, got:",
+		"\tno manifests found",
+	}, diff)
+}
@@ -0,0 +1,212 @@
+package validators
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
+	log "github.com/sirupsen/logrus"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// MatchJsonSchemaValidator validate the value of Path, or the whole document when Path is empty, against a JSON schema.
+// The schema is given inline with Schema, or by SchemaFile relative to the test suite.
+type MatchJsonSchemaValidator struct {
+	Path       string
+	Schema     interface{}
+	SchemaFile string
+	FromJson   bool // When true, decodes the string value as JSON before validating
+	FromYaml   bool // When true, decodes the string value as YAML before validating
+}
+
+// jsonSchemaContextDelimiter separates the fields of the context of a schema violation, which can not be part of a key
+const jsonSchemaContextDelimiter = "\x00"
+
+func (v MatchJsonSchemaValidator) failInfo(info string, manifestIndex, valueIndex int, not bool) []string {
+	customMessage := " to match JSON schema"
+	if v.SchemaFile != "" {
+		customMessage += " " + v.SchemaFile
+	}
+
+	log.WithField("validator", "json_schema").Debugln("violations:", info)
+
+	if v.Path == "" {
+		return splitInfof(
+			setFailFormat(not, false, false, false, customMessage),
+			manifestIndex,
+			valueIndex,
+			info,
+		)
+	}
+	return splitInfof(
+		setFailFormat(not, true, false, false, customMessage),
+		manifestIndex,
+		valueIndex,
+		v.Path,
+		info,
+	)
+}
+
+// loadSchema returns the schema given inline or by the file relative to the directory of the test suite
+func (v MatchJsonSchemaValidator) loadSchema(baseDirectory string) (*gojsonschema.Schema, error) {
+	if (v.Schema == nil) == (v.SchemaFile == "") {
+		return nil, errors.New("either schema or schemaFile must be given")
+	}
+
+	schema := v.Schema
+	if v.SchemaFile != "" {
+		schemaFile := v.SchemaFile
+		if !filepath.IsAbs(schemaFile) {
+			schemaFile = filepath.Join(baseDirectory, schemaFile)
+		}
+		content, err := os.ReadFile(schemaFile)
+		if err != nil {
+			return nil, err
+		}
+		// A JSON schema is valid yaml as well, so schemas can be written in both formats
+		if err := common.YmlUnmarshal(string(content), &schema); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %s", v.SchemaFile, err)
+		}
+	}
+
+	loaded, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(schema))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %s", err)
+	}
+	return loaded, nil
+}
+
+// decode returns the value to validate, decoding a string value with FromJson or FromYaml
+func (v MatchJsonSchemaValidator) decode(actual interface{}) (interface{}, error) {
+	content, ok := actual.(string)
+	if !ok || !(v.FromJson || v.FromYaml) {
+		return actual, nil
+	}
+
+	var decoded interface{}
+	if v.FromJson {
+		if err := json.Unmarshal([]byte(content), &decoded); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON from '%s': %s", v.Path, err)
+		}
+		return decoded, nil
+	}
+	if err := common.YmlUnmarshal(content, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML from '%s': %s", v.Path, err)
+	}
+	return decoded, nil
+}
+
+// violations returns the violations of the value against the schema, each with the JSON pointer of the violating value
+func violations(schema *gojsonschema.Schema, actual interface{}) ([]string, error) {
+	result, err := schema.Validate(gojsonschema.NewGoLoader(actual))
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []string
+	for _, resultError := range result.Errors() {
+		violations = append(violations, fmt.Sprintf("%s: %s", jsonPointer(resultError.Context()), resultError.Description()))
+	}
+	return violations, nil
+}
+
+// jsonPointer returns the JSON pointer of the context of a schema violation, the root is shown as (root)
+func jsonPointer(context *gojsonschema.JsonContext) string {
+	fields := strings.Split(context.String(jsonSchemaContextDelimiter), jsonSchemaContextDelimiter)
+	if len(fields) <= 1 {
+		return "(root)"
+	}
+
+	escaper := strings.NewReplacer("~", "~0", "/", "~1")
+	var pointer strings.Builder
+	for _, field := range fields[1:] {
+		pointer.WriteString("/" + escaper.Replace(field))
+	}
+	return pointer.String()
+}
+
+func (v MatchJsonSchemaValidator) validateManifest(manifest common.K8sManifest, manifestIndex int, schema *gojsonschema.Schema, context *ValidateContext) (bool, []string) {
+	actuals := []interface{}{manifest}
+	if v.Path != "" {
+		var err error
+		actuals, err = valueutils.GetValueOfSetPath(manifest, v.Path)
+		if err != nil {
+			return false, splitInfof(errorFormat, manifestIndex, -1, err.Error())
+		}
+		if len(actuals) == 0 {
+			return false, splitInfof(errorFormat, manifestIndex, -1, fmt.Sprintf("unknown path %s", v.Path))
+		}
+	}
+
+	manifestSuccess := false
+	var manifestErrors []string
+
+	for actualIndex, actual := range actuals {
+		// Only show the index of the value when several values are validated
+		valueIndex := -1
+		if len(actuals) > 1 {
+			valueIndex = actualIndex
+		}
+
+		decoded, err := v.decode(actual)
+		if err != nil {
+			return false, splitInfof(errorFormat, manifestIndex, valueIndex, err.Error())
+		}
+		found, err := violations(schema, decoded)
+		if err != nil {
+			return false, splitInfof(errorFormat, manifestIndex, valueIndex, err.Error())
+		}
+
+		singleSuccess := (len(found) == 0) != context.Negative
+		if !singleSuccess {
+			info := strings.Join(found, "\n")
+			if context.Negative {
+				info = common.TrustedMarshalYAML(decoded)
+			}
+			manifestErrors = append(manifestErrors, v.failInfo(info, manifestIndex, valueIndex, context.Negative)...)
+		}
+		manifestSuccess = determineSuccess(actualIndex, manifestSuccess, singleSuccess)
+
+		if !manifestSuccess && context.FailFast {
+			break
+		}
+	}
+
+	return manifestSuccess, manifestErrors
+}
+
+// Validate implement Validatable
+func (v MatchJsonSchemaValidator) Validate(context *ValidateContext) (bool, []string) {
+	schema, err := v.loadSchema(context.BaseDirectory)
+	if err != nil {
+		return false, splitInfof(errorFormat, -1, -1, err.Error())
+	}
+
+	manifests := context.getManifests()
+
+	validateSuccess := false
+	validateErrors := make([]string, 0)
+
+	for idx, manifest := range manifests {
+		manifestSuccess, manifestErrors := v.validateManifest(manifest, idx, schema, context)
+		validateErrors = append(validateErrors, manifestErrors...)
+		validateSuccess = determineSuccess(idx, validateSuccess, manifestSuccess)
+
+		if !validateSuccess && context.FailFast {
+			break
+		}
+	}
+
+	if len(manifests) == 0 && !context.Negative {
+		validateErrors = append(validateErrors, v.failInfo("no manifest found", -1, -1, context.Negative)...)
+	} else if len(manifests) == 0 && context.Negative {
+		validateSuccess = true
+	}
+
+	return validateSuccess, validateErrors
+}
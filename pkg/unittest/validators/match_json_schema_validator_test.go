@@ -0,0 +1,205 @@
+package validators_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+var jsonSchemaDoc = `
+kind: ConfigMap
+metadata:
+  name: web
+  annotations:
+    checksum/config: abc
+data:
+  config.json: '{"replicas": 0, "name": "web"}'
+spec:
+  replicas: 2
+  containers:
+    - name: web
+      port: 80
+    - name: sidecar
+      port: "9090"
+`
+
+var replicasSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"replicas": map[string]interface{}{"type": "integer", "minimum": 1},
+	},
+	"required": []interface{}{"replicas"},
+}
+
+func TestMatchJsonSchemaValidatorWhenOk(t *testing.T) {
+	validator := MatchJsonSchemaValidator{
+		Path:   "spec",
+		Schema: replicasSchema,
+	}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(jsonSchemaDoc)},
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
+
+func TestMatchJsonSchemaValidatorWhenWholeDocumentFail(t *testing.T) {
+	validator := MatchJsonSchemaValidator{
+		Schema: map[string]interface{}{
+			"type":     "object",
+			"required": []interface{}{"apiVersion"},
+			"properties": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"properties": map[string]interface{}{
+						"annotations": map[string]interface{}{
+							"additionalProperties": map[string]interface{}{"type": "integer"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(jsonSchemaDoc)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Expected to match JSON schema:",
+		"\t(root): apiVersion is required",
+		"\t/metadata/annotations/checksum~1config: Invalid type. Expected: integer, given: string",
+	}, diff)
+}
+
+func TestMatchJsonSchemaValidatorWhenSeveralValuesFail(t *testing.T) {
+	validator := MatchJsonSchemaValidator{
+		Path: "spec.containers[*]",
+		Schema: map[string]interface{}{
+			"properties": map[string]interface{}{
+				"port": map[string]interface{}{"type": "integer"},
+			},
+		},
+	}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(jsonSchemaDoc)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"ValuesIndex:\t1",
+		"Path:\tspec.containers[*]",
+		"Expected to match JSON schema:",
+		"\t/port: Invalid type. Expected: integer, given: string",
+	}, diff)
+}
+
+func TestMatchJsonSchemaValidatorWhenFromJsonFail(t *testing.T) {
+	validator := MatchJsonSchemaValidator{
+		Path:     `data["config.json"]`,
+		Schema:   replicasSchema,
+		FromJson: true,
+	}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(jsonSchemaDoc)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Path:\tdata[\"config.json\"]",
+		"Expected to match JSON schema:",
+		"\t/replicas: Must be greater than or equal to 1",
+	}, diff)
+}
+
+func TestMatchJsonSchemaValidatorWhenNegativeAndFail(t *testing.T) {
+	validator := MatchJsonSchemaValidator{
+		Path:   "spec",
+		Schema: replicasSchema,
+	}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:     []common.K8sManifest{makeManifest(jsonSchemaDoc)},
+		Negative: true,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, "Expected NOT to match JSON schema:", diff[2])
+}
+
+func TestMatchJsonSchemaValidatorWithSchemaFile(t *testing.T) {
+	directory := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(directory, "replicas.json"), []byte(`{"properties": {"replicas": {"maximum": 1}}}`), 0644))
+	validator := MatchJsonSchemaValidator{
+		Path:       "spec",
+		SchemaFile: "replicas.json",
+	}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:          []common.K8sManifest{makeManifest(jsonSchemaDoc)},
+		BaseDirectory: directory,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Path:\tspec",
+		"Expected to match JSON schema replicas.json:",
+		"\t/replicas: Must be less than or equal to 1",
+	}, diff)
+}
+
+func TestMatchJsonSchemaValidatorWhenSchemaMissing(t *testing.T) {
+	validator := MatchJsonSchemaValidator{Path: "spec"}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(jsonSchemaDoc)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Error:",
+		"\teither schema or schemaFile must be given",
+	}, diff)
+}
+
+func TestMatchJsonSchemaValidatorWhenUnknownPath(t *testing.T) {
+	validator := MatchJsonSchemaValidator{
+		Path:   "spec.unknown",
+		Schema: replicasSchema,
+	}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(jsonSchemaDoc)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Error:",
+		"\tunknown path spec.unknown",
+	}, diff)
+}
+
+func TestMatchJsonSchemaValidatorWhenNoManifest(t *testing.T) {
+	validator := MatchJsonSchemaValidator{Schema: replicasSchema}
+
+	pass, diff := validator.Validate(&ValidateContext{})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Expected to match JSON schema:",
+		"\tno manifest found",
+	}, diff)
+}
@@ -6,7 +6,6 @@ import (
 	"regexp"
 
 	"github.com/helm-unittest/helm-unittest/internal/common"
-	"github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -15,6 +14,8 @@ type MatchRegexValidator struct {
 	Path         string
 	Pattern      string
 	DecodeBase64 bool
+	Decode       interface{}
+	SubPath      string
 }
 
 func (v MatchRegexValidator) failInfo(actual string, manifestIndex, actualIndex int, not bool) []string {
@@ -57,7 +58,7 @@ func (v MatchRegexValidator) validateSingle(actual interface{}, pattern *regexp.
 }
 
 func (v MatchRegexValidator) validateManifest(manifest common.K8sManifest, manifestIndex int, context *ValidateContext) (bool, []string) {
-	actuals, err := valueutils.GetValueOfSetPath(manifest, v.Path)
+	actuals, err := valuesOfPath(manifest, v.Path, v.Decode, v.SubPath)
 	if err != nil {
 		return false, splitInfof(errorFormat, manifestIndex, -1, err.Error())
 	}
@@ -26,7 +26,7 @@ b: YWFhCmJiYgo=
 func TestMatchRegexValidatorWhenOk(t *testing.T) {
 	manifest := makeManifest(docToTestMatchRegex)
 
-	validator := MatchRegexValidator{"a.b[0].c", "^hello", false}
+	validator := MatchRegexValidator{Path: "a.b[0].c", Pattern: "^hello", DecodeBase64: false}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
 	})
@@ -38,7 +38,7 @@ func TestMatchRegexValidatorWhenOk(t *testing.T) {
 func TestMatchRegexValidatorWhenMultiLineOk(t *testing.T) {
 	manifest := makeManifest(docToTestMatchRegex)
 
-	validator := MatchRegexValidator{"e", "bbb", false}
+	validator := MatchRegexValidator{Path: "e", Pattern: "bbb", DecodeBase64: false}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
 	})
@@ -50,7 +50,7 @@ func TestMatchRegexValidatorWhenMultiLineOk(t *testing.T) {
 func TestMatchRegexValidatorWithBase64WhenNOk(t *testing.T) {
 	manifest := makeManifest(docToTestMatchRegex)
 
-	validator := MatchRegexValidator{"a.b[0].c", "^hello", true}
+	validator := MatchRegexValidator{Path: "a.b[0].c", Pattern: "^hello", DecodeBase64: true}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
 	})
@@ -62,7 +62,7 @@ func TestMatchRegexValidatorWithBase64WhenNOk(t *testing.T) {
 func TestMatchRegexValidatorWithBase64WhenOk(t *testing.T) {
 	manifest := makeManifest(docToTestMatchRegexWithBase64)
 
-	validator := MatchRegexValidator{"a", "^hello", true}
+	validator := MatchRegexValidator{Path: "a", Pattern: "^hello", DecodeBase64: true}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
 	})
@@ -74,7 +74,7 @@ func TestMatchRegexValidatorWithBase64WhenOk(t *testing.T) {
 func TestMatchRegexValidatorWhenMultiLineWithBase64Ok(t *testing.T) {
 	manifest := makeManifest(docToTestMatchRegexWithBase64)
 
-	validator := MatchRegexValidator{"b", "bbb", true}
+	validator := MatchRegexValidator{Path: "b", Pattern: "bbb", DecodeBase64: true}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
 	})
@@ -86,7 +86,7 @@ func TestMatchRegexValidatorWhenMultiLineWithBase64Ok(t *testing.T) {
 func TestMatchRegexValidatorWhenNegativeAndOk(t *testing.T) {
 	manifest := makeManifest(docToTestMatchRegex)
 
-	validator := MatchRegexValidator{"a.b[0].c", "^foo", false}
+	validator := MatchRegexValidator{Path: "a.b[0].c", Pattern: "^foo", DecodeBase64: false}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs:     []common.K8sManifest{manifest},
 		Negative: true,
@@ -98,7 +98,7 @@ func TestMatchRegexValidatorWhenNegativeAndOk(t *testing.T) {
 func TestMatchRegexValidatorWhenRegexCompileFail(t *testing.T) {
 	manifest := common.K8sManifest{"a": "A"}
 
-	validator := MatchRegexValidator{"a", "+", false}
+	validator := MatchRegexValidator{Path: "a", Pattern: "+", DecodeBase64: false}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
 	})
@@ -112,7 +112,7 @@ func TestMatchRegexValidatorWhenRegexCompileFail(t *testing.T) {
 func TestMatchRegexValidatorWhenNotString(t *testing.T) {
 	manifest := common.K8sManifest{"a": 123.456}
 
-	validator := MatchRegexValidator{"a", "^foo", false}
+	validator := MatchRegexValidator{Path: "a", Pattern: "^foo", DecodeBase64: false}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
 	})
@@ -132,7 +132,7 @@ func TestMatchRegexValidatorWhenMatchFail(t *testing.T) {
 
 	log.SetLevel(log.DebugLevel)
 
-	validator := MatchRegexValidator{"a.b[0].c", "^foo", false}
+	validator := MatchRegexValidator{Path: "a.b[0].c", Pattern: "^foo", DecodeBase64: false}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
 	})
@@ -153,7 +153,7 @@ func TestMatchRegexValidatorWhenMatchFailFailFast(t *testing.T) {
 
 	log.SetLevel(log.DebugLevel)
 
-	validator := MatchRegexValidator{"a.b[0].c", "^foo", false}
+	validator := MatchRegexValidator{Path: "a.b[0].c", Pattern: "^foo", DecodeBase64: false}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs:     []common.K8sManifest{manifest},
 		FailFast: true,
@@ -173,7 +173,7 @@ func TestMatchRegexValidatorWhenMatchFailFailFast(t *testing.T) {
 func TestMatchRegexValidatorWhenNegativeAndMatchFail(t *testing.T) {
 	manifest := makeManifest(docToTestMatchRegex)
 
-	validator := MatchRegexValidator{"a.b[0].c", "^hello", false}
+	validator := MatchRegexValidator{Path: "a.b[0].c", Pattern: "^hello", DecodeBase64: false}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs:     []common.K8sManifest{manifest},
 		Negative: true,
@@ -194,7 +194,7 @@ func TestMatchRegexValidatorWhenNegativeAndMatchFail(t *testing.T) {
 func TestMatchRegexValidatorWhenNoPattern(t *testing.T) {
 	manifest := makeManifest(docToTestMatchRegex)
 
-	validator := MatchRegexValidator{"a.b[0].c", "", false}
+	validator := MatchRegexValidator{Path: "a.b[0].c", Pattern: "", DecodeBase64: false}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
 	})
@@ -209,7 +209,7 @@ func TestMatchRegexValidatorWhenNoPattern(t *testing.T) {
 func TestMatchRegexValidatorWhenErrorGetValueOfSetPath(t *testing.T) {
 	manifest := makeManifest("a.b.d: error")
 
-	validator := MatchRegexValidator{"a.[b]", "^hello", false}
+	validator := MatchRegexValidator{Path: "a.[b]", Pattern: "^hello", DecodeBase64: false}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{manifest},
 	})
@@ -225,7 +225,7 @@ func TestMatchRegexValidatorWhenErrorGetValueOfSetPath(t *testing.T) {
 func TestMatchRegexValidatorWhenUnknownPathFailFast(t *testing.T) {
 	manifest := makeManifest("a.b.d: error")
 
-	validator := MatchRegexValidator{"a[2]", "^hello", false}
+	validator := MatchRegexValidator{Path: "a[2]", Pattern: "^hello", DecodeBase64: false}
 	pass, diff := validator.Validate(&ValidateContext{
 		FailFast: true,
 		Docs:     []common.K8sManifest{manifest},
@@ -242,7 +242,7 @@ func TestMatchRegexValidatorWhenUnknownPathFailFast(t *testing.T) {
 func TestMatchRegexValidatorWhenUnknownPathNegative(t *testing.T) {
 	manifest := makeManifest("a.b.d: error")
 
-	validator := MatchRegexValidator{"a[2]", "^hello", false}
+	validator := MatchRegexValidator{Path: "a[2]", Pattern: "^hello", DecodeBase64: false}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs:     []common.K8sManifest{manifest},
 		Negative: true,
@@ -253,7 +253,7 @@ func TestMatchRegexValidatorWhenUnknownPathNegative(t *testing.T) {
 }
 
 func TestMatchRegexValidatorWhenNoManifestFail(t *testing.T) {
-	validator := MatchRegexValidator{"a.b[0].c", "^hello", false}
+	validator := MatchRegexValidator{Path: "a.b[0].c", Pattern: "^hello", DecodeBase64: false}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs: []common.K8sManifest{},
 	})
@@ -269,7 +269,7 @@ func TestMatchRegexValidatorWhenNoManifestFail(t *testing.T) {
 }
 
 func TestMatchRegexValidatorWhenNoManifestNegativeOk(t *testing.T) {
-	validator := MatchRegexValidator{"a.b[0].c", "^hello", false}
+	validator := MatchRegexValidator{Path: "a.b[0].c", Pattern: "^hello", DecodeBase64: false}
 	pass, diff := validator.Validate(&ValidateContext{
 		Docs:     []common.K8sManifest{},
 		Negative: true,
@@ -278,3 +278,20 @@ func TestMatchRegexValidatorWhenNoManifestNegativeOk(t *testing.T) {
 	assert.True(t, pass)
 	assert.Equal(t, []string{}, diff)
 }
+
+func TestMatchRegexValidatorWithDecodeWhenOk(t *testing.T) {
+	doc := `
+data:
+  app.properties: |
+    database.url=jdbc:postgresql://db:5432/app
+`
+	manifest := makeManifest(doc)
+
+	validator := MatchRegexValidator{Path: "data['app.properties']", Pattern: "^jdbc:postgresql://", Decode: "properties", SubPath: "['database.url']"}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{manifest},
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
@@ -1,12 +1,16 @@
 package validators
 
 import (
+	"cmp"
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/helm-unittest/helm-unittest/internal/common"
-	"github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
 	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // operatorValidator validate whether the value of Path is according to the behaviour of operatorgreater to Value
@@ -15,10 +19,19 @@ type operatorValidator struct {
 	Path           string
 	Value          interface{}
 	ComparisonType string
+	// Strict compares without equality, like greater than and less than
+	Strict bool
+	// Decode decodes the value of Path by a format, or a list of formats in order, like base64 and then json
+	Decode interface{}
+	// SubPath is the path to assert in the decoded value
+	SubPath string
 }
 
 func (o operatorValidator) failInfo(msg, comparisonType string, manifestIndex, actualIndex int, not bool) []string {
 	customMsg := fmt.Sprintf(" to be %s then or equal to, got", comparisonType)
+	if o.Strict {
+		customMsg = fmt.Sprintf(" to be %s than, got", comparisonType)
+	}
 	return splitInfof(
 		setFailFormat(not, true, false, false, customMsg),
 		manifestIndex,
@@ -28,57 +41,122 @@ func (o operatorValidator) failInfo(msg, comparisonType string, manifestIndex, a
 	)
 }
 
+// relation returns the relation of the comparison to show in the failure message
+func (o operatorValidator) relation() string {
+	if o.Strict {
+		return o.ComparisonType + " than"
+	}
+	return o.ComparisonType + " or equal to"
+}
+
 // compareValues performs a validation of a Kubernetes manifest against an expected value.
 // It compares the actual value retrieved from the manifest with the expected value,
-// ensuring that they are of compatible types and that the actual value is greater, less than or equal to the expected value.
-// If successful, it returns true along with a nil error slice. If unsuccessful, it returns false
-// along with an error slice containing information about the validation failure.
-func (o operatorValidator) compareValues(expected, actual interface{}, comparisonType string, negative bool) (bool, []string) {
-	expStr := fmt.Sprintf("%v", expected)
-	actStr := fmt.Sprintf("%v", actual)
-	result := false
-
-	switch exp := expected.(type) {
-	case string:
-		result = o.compareStringValues(exp, actual.(string), comparisonType, negative)
-	case int:
-		result = o.compareIntValues(exp, actual.(int), comparisonType, negative)
-	case float64:
-		result = o.compareFloatValues(exp, actual.(float64), comparisonType, negative)
-	default:
-		return false, []string{fmt.Sprintf("unsupported type '%T'", expected)}
+// coercing numbers, resource quantities and durations, and ensures that the actual value is
+// greater or less than, or equal to, the expected value.
+// It returns whether the comparison matches the negative expectation, the message of the failure
+// when it does not, and an error when the values can not be compared.
+func (o operatorValidator) compareValues(expected, actual interface{}, negative bool) (bool, string, error) {
+	order, err := compareOrdered(actual, expected)
+	if err != nil {
+		return false, "", err
 	}
 
-	if !result {
-		return false, []string{fmt.Sprintf("the actual '%s' is not %s or equal to the expected '%s'", actStr, comparisonType, expStr)}
+	var result bool
+	switch {
+	case o.ComparisonType == "greater" && o.Strict:
+		result = order > 0
+	case o.ComparisonType == "greater":
+		result = order >= 0
+	case o.ComparisonType == "less" && o.Strict:
+		result = order < 0
+	case o.ComparisonType == "less":
+		result = order <= 0
 	}
 
-	return true, nil
+	if result == negative {
+		notAnnotation := " not"
+		if negative {
+			notAnnotation = ""
+		}
+		return false, fmt.Sprintf("the actual '%v' is%s %s the expected '%v'", actual, notAnnotation, o.relation(), expected), nil
+	}
+	return true, "", nil
 }
 
-func (o operatorValidator) compareStringValues(expected, actual string, comparisonType string, negative bool) bool {
-	if (comparisonType == "greater" && actual >= expected) || (comparisonType == "less" && actual <= expected) == negative {
-		return true
+// compareOrdered returns -1, 0 or 1 when the actual value is less than, equal to or greater than the expected value.
+// Numbers of any type are compared by value, resource quantities like 500m or 1Gi with each other and with numbers,
+// durations like 30s or 1h with each other and other strings lexically.
+func compareOrdered(actual, expected interface{}) (int, error) {
+	actualNumber, actualIsNumber := toFloat(actual)
+	expectedNumber, expectedIsNumber := toFloat(expected)
+	if actualIsNumber && expectedIsNumber {
+		return cmp.Compare(actualNumber, expectedNumber), nil
+	}
+
+	if actualQuantity, ok := toQuantity(actual); ok {
+		if expectedQuantity, ok := toQuantity(expected); ok {
+			return actualQuantity.Cmp(expectedQuantity), nil
+		}
+	}
+
+	actualString, actualIsString := actual.(string)
+	expectedString, expectedIsString := expected.(string)
+	if actualIsString && expectedIsString {
+		actualDuration, actualErr := time.ParseDuration(actualString)
+		expectedDuration, expectedErr := time.ParseDuration(expectedString)
+		if actualErr == nil && expectedErr == nil {
+			return cmp.Compare(actualDuration, expectedDuration), nil
+		}
+		return strings.Compare(actualString, expectedString), nil
+	}
+
+	if reflect.TypeOf(actual) == reflect.TypeOf(expected) {
+		return 0, fmt.Errorf("unsupported type '%T'", expected)
 	}
-	return false
+	return 0, fmt.Errorf("actual '%T' and expected '%T' types do not match", actual, expected)
 }
 
-func (o operatorValidator) compareIntValues(expected, actual int, comparisonType string, negative bool) bool {
-	if (comparisonType == "greater" && actual >= expected) || (comparisonType == "less" && actual <= expected) == negative {
-		return true
+// toFloat returns the value of a number of any type as float64
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
 	}
-	return false
+	return 0, false
 }
 
-func (o operatorValidator) compareFloatValues(expected, actual float64, comparisonType string, negative bool) bool {
-	if (comparisonType == "greater" && actual >= expected) || (comparisonType == "less" && actual <= expected) == negative {
-		return true
+// toQuantity returns the value as resource quantity, for numbers and strings like 500m or 1Gi
+func toQuantity(value interface{}) (resource.Quantity, bool) {
+	text, isString := value.(string)
+	if number, isNumber := toFloat(value); isNumber {
+		text = strconv.FormatFloat(number, 'f', -1, 64)
+	} else if !isString {
+		return resource.Quantity{}, false
 	}
-	return false
+
+	quantity, err := resource.ParseQuantity(text)
+	if err != nil {
+		return resource.Quantity{}, false
+	}
+	return quantity, true
 }
 
 func (o operatorValidator) validateManifest(manifest common.K8sManifest, manifestIndex int, context *ValidateContext) (bool, []string) {
-	actuals, err := valueutils.GetValueOfSetPath(manifest, o.Path)
+	actuals, err := valuesOfPath(manifest, o.Path, o.Decode, o.SubPath)
 	if err != nil {
 		return false, splitInfof(errorFormat, manifestIndex, -1, err.Error())
 	}
@@ -91,25 +169,13 @@ func (o operatorValidator) validateManifest(manifest common.K8sManifest, manifes
 	var validateManifestErrors []string
 
 	for actualIndex, actual := range actuals {
-		actType := reflect.TypeOf(actual)
-		expType := reflect.TypeOf(o.Value)
-
-		validateSingleSuccess := false
-		var validateSingleErrors []string
-
-		if actType != expType {
-			errorMessage := splitInfof(errorFormat, manifestIndex, actualIndex, fmt.Sprintf("actual '%s' and expected '%s' types do not match", actType, expType))
-			validateManifestErrors = append(validateManifestErrors, errorMessage...)
-			continue
-		}
-
-		validateSingleSuccess, errors := o.compareValues(o.Value, actual, o.ComparisonType, !context.Negative)
-		if errors != nil {
-			errorMessage := o.failInfo(errors[0], o.ComparisonType, manifestIndex, actualIndex, context.Negative)
-			validateSingleErrors = append(validateSingleErrors, errorMessage...)
+		validateSingleSuccess, failure, err := o.compareValues(o.Value, actual, context.Negative)
+		if err != nil {
+			validateManifestErrors = append(validateManifestErrors, splitInfof(errorFormat, manifestIndex, actualIndex, err.Error())...)
+		} else if !validateSingleSuccess {
+			validateManifestErrors = append(validateManifestErrors, o.failInfo(failure, o.ComparisonType, manifestIndex, actualIndex, context.Negative)...)
 		}
 
-		validateManifestErrors = append(validateManifestErrors, validateSingleErrors...)
 		validateManifestSuccess = determineSuccess(actualIndex, validateManifestSuccess, validateSingleSuccess)
 
 		if !validateManifestSuccess && context.FailFast {
@@ -0,0 +1,174 @@
+package validators
+
+import (
+	"maps"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	log "github.com/sirupsen/logrus"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
+)
+
+// MatchReleaseSnapshotValidator validate snapshot of the whole rendered release the same as cached.
+// The resources are ordered the way Helm installs them, the hooks are separated and the notes are included.
+type MatchReleaseSnapshotValidator struct {
+	// Name keys the snapshot by name instead of by order
+	Name string
+}
+
+// releaseSnapshotIdentity keys the release snapshot when the snapshots are keyed by identity
+const releaseSnapshotIdentity = "release"
+
+// notesFile is the name of the notes template, only the notes of the chart itself are part of the release
+const notesFile = "NOTES.txt"
+
+// releaseSnapshot is the content of a release snapshot
+type releaseSnapshot struct {
+	Resources []releaseResource `yaml:"resources"`
+	Hooks     []releaseResource `yaml:"hooks,omitempty"`
+	Notes     string            `yaml:"notes,omitempty"`
+}
+
+// releaseResource is a rendered document of the release with the template it is rendered from
+type releaseResource struct {
+	Source   string             `yaml:"source"`
+	Manifest common.K8sManifest `yaml:"manifest"`
+	kind     string
+	name     string
+	weight   int
+}
+
+// ValidatesRelease implement ReleaseValidatable
+func (v MatchReleaseSnapshotValidator) ValidatesRelease() {}
+
+func (v MatchReleaseSnapshotValidator) failInfo(compared *snapshot.CompareResult, not bool) []string {
+	if compared.Missing {
+		return splitInfof(
+			setFailFormat(false, false, false, false, missingSnapshotMessage(compared)),
+			-1,
+			-1,
+			compared.NewSnapshot,
+		)
+	}
+
+	customMessage := " to match release snapshot " + snapshotName(compared)
+
+	log.WithField("validator", "release_snapshot").Debugln("expected content:", compared.CachedSnapshot)
+	log.WithField("validator", "release_snapshot").Debugln("actual content:", compared.NewSnapshot)
+
+	var infoToShow string
+	if not {
+		infoToShow = compared.CachedSnapshot
+	} else {
+		infoToShow = semanticDiffYAML(compared.CachedSnapshot, compared.NewSnapshot)
+	}
+	return splitInfof(
+		setFailFormat(not, false, false, false, customMessage),
+		-1,
+		-1,
+		infoToShow,
+	)
+}
+
+// Validate implement Validatable
+func (v MatchReleaseSnapshotValidator) Validate(context *ValidateContext) (bool, []string) {
+	result := context.compareToSnapshot(
+		SnapshotKey{Name: v.Name, Identity: releaseSnapshotIdentity},
+		newReleaseSnapshot(context.Release),
+	)
+
+	if result.Missing || result.Passed == context.Negative {
+		return false, v.failInfo(result, context.Negative)
+	}
+	return true, []string{}
+}
+
+// newReleaseSnapshot returns the release of the documents of the templates. The resources are sorted by kind in the
+// install order of Helm, the hooks by weight, kind and name.
+func newReleaseSnapshot(templates map[string][]common.K8sManifest) releaseSnapshot {
+	content := releaseSnapshot{Resources: []releaseResource{}}
+	for _, source := range slices.Sorted(maps.Keys(templates)) {
+		if path.Base(source) == notesFile {
+			if isChartNotes(source) && len(templates[source]) > 0 {
+				content.Notes, _ = templates[source][0][common.RAW].(string)
+			}
+			continue
+		}
+
+		for _, manifest := range templates[source] {
+			if _, ok := manifest[common.RAW]; ok {
+				continue
+			}
+			resource, isHook := newReleaseResource(source, manifest)
+			if isHook {
+				content.Hooks = append(content.Hooks, resource)
+			} else {
+				content.Resources = append(content.Resources, resource)
+			}
+		}
+	}
+
+	sortByKind(content.Resources)
+	sort.SliceStable(content.Hooks, func(i, j int) bool {
+		first, second := content.Hooks[i], content.Hooks[j]
+		if first.weight != second.weight {
+			return first.weight < second.weight
+		}
+		if order := compareKinds(first.kind, second.kind); order != 0 {
+			return order < 0
+		}
+		return first.name < second.name
+	})
+	return content
+}
+
+// newReleaseResource returns the resource of the manifest and whether it is a hook
+func newReleaseResource(source string, manifest common.K8sManifest) (releaseResource, bool) {
+	metadata := asMap(manifest["metadata"])
+	annotations := asMap(metadata["annotations"])
+
+	resource := releaseResource{Source: source, Manifest: manifest}
+	resource.kind, _ = manifest["kind"].(string)
+	resource.name, _ = metadata["name"].(string)
+
+	hook, _ := annotations[release.HookAnnotation].(string)
+	if weight, ok := annotations[release.HookWeightAnnotation].(string); ok {
+		resource.weight, _ = strconv.Atoi(strings.TrimSpace(weight))
+	}
+	return resource, strings.TrimSpace(hook) != ""
+}
+
+// isChartNotes check if the notes template belongs to the chart, the notes of subcharts are not rendered by Helm
+func isChartNotes(source string) bool {
+	parts := strings.Split(source, "/")
+	return len(parts) == 3 && parts[1] == "templates"
+}
+
+// sortByKind sorts the resources by kind in the install order of Helm
+func sortByKind(resources []releaseResource) {
+	sort.SliceStable(resources, func(i, j int) bool {
+		return compareKinds(resources[i].kind, resources[j].kind) < 0
+	})
+}
+
+// compareKinds compares the kinds by the install order of Helm, unknown kinds last in alphabetical order
+func compareKinds(first, second string) int {
+	firstOrder := slices.Index(releaseutil.InstallOrder, first)
+	secondOrder := slices.Index(releaseutil.InstallOrder, second)
+	switch {
+	case firstOrder >= 0 && secondOrder >= 0:
+		return firstOrder - secondOrder
+	case firstOrder >= 0:
+		return -1
+	case secondOrder >= 0:
+		return 1
+	default:
+		return strings.Compare(first, second)
+	}
+}
@@ -0,0 +1,231 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func makeRelease() map[string][]common.K8sManifest {
+	return map[string][]common.K8sManifest{
+		"basic/templates/service.yaml": {
+			makeManifest("kind: Service\nmetadata:\n  name: web\n"),
+		},
+		"basic/templates/deployment.yaml": {
+			makeManifest("kind: Deployment\nmetadata:\n  name: web\n"),
+			makeManifest("kind: ConfigMap\nmetadata:\n  name: web\n"),
+		},
+		"basic/templates/jobs.yaml": {
+			makeManifest("kind: Job\nmetadata:\n  name: migrate\n  annotations:\n    helm.sh/hook: pre-upgrade\n    helm.sh/hook-weight: \"5\"\n"),
+			makeManifest("kind: Job\nmetadata:\n  name: init\n  annotations:\n    helm.sh/hook: pre-install\n    helm.sh/hook-weight: \"-1\"\n"),
+		},
+		"basic/templates/custom.yaml": {
+			makeManifest("kind: MyResource\nmetadata:\n  name: web\n"),
+		},
+		"basic/templates/_helpers.tpl": {},
+		"basic/templates/NOTES.txt": {
+			{common.RAW: "Thanks for installing"},
+		},
+		"basic/charts/child/templates/NOTES.txt": {
+			{common.RAW: "Child notes"},
+		},
+	}
+}
+
+func TestReleaseSnapshotValidatorWhenOk(t *testing.T) {
+	validator := MatchReleaseSnapshotValidator{}
+
+	mockComparer := new(mockSnapshotComparer)
+	mockComparer.On("CompareToSnapshot", mock.Anything).Return(&snapshot.CompareResult{
+		Passed: true,
+	})
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Release:          makeRelease(),
+		SnapshotComparer: mockComparer,
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+
+	mockComparer.AssertExpectations(t)
+	assert.Equal(t, `resources:
+  - source: basic/templates/deployment.yaml
+    manifest:
+      kind: ConfigMap
+      metadata:
+        name: web
+  - source: basic/templates/service.yaml
+    manifest:
+      kind: Service
+      metadata:
+        name: web
+  - source: basic/templates/deployment.yaml
+    manifest:
+      kind: Deployment
+      metadata:
+        name: web
+  - source: basic/templates/custom.yaml
+    manifest:
+      kind: MyResource
+      metadata:
+        name: web
+hooks:
+  - source: basic/templates/jobs.yaml
+    manifest:
+      kind: Job
+      metadata:
+        annotations:
+          helm.sh/hook: pre-install
+          helm.sh/hook-weight: "-1"
+        name: init
+  - source: basic/templates/jobs.yaml
+    manifest:
+      kind: Job
+      metadata:
+        annotations:
+          helm.sh/hook: pre-upgrade
+          helm.sh/hook-weight: "5"
+        name: migrate
+notes: Thanks for installing
+`, common.TrustedMarshalYAML(mockComparer.Calls[0].Arguments.Get(0)))
+}
+
+func TestReleaseSnapshotValidatorOrdersHooksOfSameWeightByKind(t *testing.T) {
+	validator := MatchReleaseSnapshotValidator{}
+
+	mockComparer := new(mockSnapshotComparer)
+	mockComparer.On("CompareToSnapshot", mock.Anything).Return(&snapshot.CompareResult{
+		Passed: true,
+	})
+
+	pass, _ := validator.Validate(&ValidateContext{
+		Release: map[string][]common.K8sManifest{
+			"basic/templates/hooks.yaml": {
+				makeManifest("kind: Job\nmetadata:\n  name: a-job\n  annotations:\n    helm.sh/hook: pre-install\n"),
+				makeManifest("kind: ServiceAccount\nmetadata:\n  name: z-account\n  annotations:\n    helm.sh/hook: pre-install\n"),
+				makeManifest("kind: Job\nmetadata:\n  name: b-job\n  annotations:\n    helm.sh/hook: pre-install\n    helm.sh/hook-weight: \"-1\"\n"),
+			},
+		},
+		SnapshotComparer: mockComparer,
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, `resources: []
+hooks:
+  - source: basic/templates/hooks.yaml
+    manifest:
+      kind: Job
+      metadata:
+        annotations:
+          helm.sh/hook: pre-install
+          helm.sh/hook-weight: "-1"
+        name: b-job
+  - source: basic/templates/hooks.yaml
+    manifest:
+      kind: ServiceAccount
+      metadata:
+        annotations:
+          helm.sh/hook: pre-install
+        name: z-account
+  - source: basic/templates/hooks.yaml
+    manifest:
+      kind: Job
+      metadata:
+        annotations:
+          helm.sh/hook: pre-install
+        name: a-job
+`, common.TrustedMarshalYAML(mockComparer.Calls[0].Arguments.Get(0)))
+}
+
+func TestReleaseSnapshotValidatorWhenEmpty(t *testing.T) {
+	validator := MatchReleaseSnapshotValidator{}
+
+	mockComparer := new(mockSnapshotComparer)
+	mockComparer.On("CompareToSnapshot", mock.Anything).Return(&snapshot.CompareResult{
+		Passed: true,
+	})
+
+	pass, _ := validator.Validate(&ValidateContext{
+		SnapshotComparer: mockComparer,
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, "resources: []\n", common.TrustedMarshalYAML(mockComparer.Calls[0].Arguments.Get(0)))
+}
+
+func TestReleaseSnapshotValidatorWhenFail(t *testing.T) {
+	log.SetLevel(log.DebugLevel)
+
+	validator := MatchReleaseSnapshotValidator{}
+
+	mockComparer := new(mockSnapshotComparer)
+	mockComparer.On("CompareToSnapshot", mock.Anything).Return(&snapshot.CompareResult{
+		Passed:         false,
+		Index:          1,
+		CachedSnapshot: "resources:\n  - source: a.yaml\n    manifest:\n      kind: Service\n",
+		NewSnapshot:    "resources:\n  - source: a.yaml\n    manifest:\n      kind: Secret\n",
+	})
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Release:          makeRelease(),
+		SnapshotComparer: mockComparer,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Expected to match release snapshot 1:",
+		"	~ resources[0].manifest.kind: Service → Secret",
+	}, diff)
+
+	mockComparer.AssertExpectations(t)
+}
+
+func TestReleaseSnapshotValidatorWhenNegativeAndFail(t *testing.T) {
+	validator := MatchReleaseSnapshotValidator{}
+
+	mockComparer := new(mockSnapshotComparer)
+	mockComparer.On("CompareToSnapshot", mock.Anything).Return(&snapshot.CompareResult{
+		Passed:         true,
+		Index:          1,
+		CachedSnapshot: "resources: []\n",
+		NewSnapshot:    "resources: []\n",
+	})
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Release:          makeRelease(),
+		Negative:         true,
+		SnapshotComparer: mockComparer,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Expected NOT to match release snapshot 1:",
+		"	resources: []",
+	}, diff)
+}
+
+func TestReleaseSnapshotValidatorWhenNamed(t *testing.T) {
+	validator := MatchReleaseSnapshotValidator{Name: "full"}
+
+	mockComparer := new(mockKeyedSnapshotComparer)
+	mockComparer.On("CompareToKeyedSnapshot", SnapshotKey{Name: "full", Identity: "release"}, mock.Anything).Return(&snapshot.CompareResult{
+		Passed: true,
+		Key:    "full",
+	})
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Release:          makeRelease(),
+		SnapshotComparer: mockComparer,
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+
+	mockComparer.AssertExpectations(t)
+}
@@ -0,0 +1,265 @@
+package validators
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/ast"
+	"github.com/google/cel-go/common/operators"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"github.com/google/cel-go/ext"
+	"github.com/google/cel-go/interpreter"
+	"github.com/google/cel-go/parser"
+	log "github.com/sirupsen/logrus"
+	"helm.sh/helm/v3/pkg/chartutil"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+)
+
+// SatisfiesValidator validate the documents satisfy the CEL expression, which has the variables
+// self for the document, release, values and capabilities of the rendering.
+type SatisfiesValidator struct {
+	Expression string
+}
+
+// Variables of the CEL expression
+const (
+	celSelfVariable         = "self"
+	celReleaseVariable      = "release"
+	celValuesVariable       = "values"
+	celCapabilitiesVariable = "capabilities"
+)
+
+func (v SatisfiesValidator) failInfo(subValues string, manifestIndex int, not bool) []string {
+	log.WithField("validator", "satisfies").Debugln("expression:", v.Expression, "sub values:", subValues)
+
+	return splitInfof(
+		setFailFormat(not, false, true, false, " to satisfy"),
+		manifestIndex,
+		-1,
+		v.Expression,
+		subValues,
+	)
+}
+
+// compile returns the program of the expression, which must evaluate to a bool
+func (v SatisfiesValidator) compile() (cel.Program, *ast.AST, error) {
+	if strings.TrimSpace(v.Expression) == "" {
+		return nil, nil, fmt.Errorf("expression must be given")
+	}
+
+	env, err := cel.NewEnv(
+		cel.Variable(celSelfVariable, cel.DynType),
+		cel.Variable(celReleaseVariable, cel.DynType),
+		cel.Variable(celValuesVariable, cel.DynType),
+		cel.Variable(celCapabilitiesVariable, cel.DynType),
+		cel.CrossTypeNumericComparisons(true),
+		ext.Strings(),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	checked, issues := env.Compile(v.Expression)
+	if issues.Err() != nil {
+		return nil, nil, fmt.Errorf("invalid expression: %s", issues.Err())
+	}
+	if outputType := checked.OutputType(); outputType != cel.BoolType && outputType != cel.DynType {
+		return nil, nil, fmt.Errorf("expression must evaluate to a bool, got %s", outputType)
+	}
+
+	program, err := env.Program(checked, cel.EvalOptions(cel.OptTrackState))
+	if err != nil {
+		return nil, nil, err
+	}
+	return program, checked.NativeRep(), nil
+}
+
+// celVariables returns the variables of the expression besides self, from the values the chart is rendered with
+func celVariables(renderValues map[string]interface{}) map[string]interface{} {
+	release := map[string]interface{}{}
+	if releaseValues, ok := renderValues["Release"].(map[string]interface{}); ok {
+		for key, value := range releaseValues {
+			release[strings.ToLower(key[:1])+key[1:]] = value
+		}
+	}
+
+	capabilities := map[string]interface{}{}
+	if caps, ok := renderValues["Capabilities"].(*chartutil.Capabilities); ok && caps != nil {
+		capabilities["kubeVersion"] = map[string]interface{}{
+			"version": caps.KubeVersion.Version,
+			"major":   caps.KubeVersion.Major,
+			"minor":   caps.KubeVersion.Minor,
+		}
+		capabilities["apiVersions"] = []string(caps.APIVersions)
+	}
+
+	values := renderValues["Values"]
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		celReleaseVariable:      celValue(release),
+		celValuesVariable:       celValue(values),
+		celCapabilitiesVariable: celValue(capabilities),
+	}
+}
+
+// celValue returns the value with plain maps, lists and int64 integers, like the values of json documents in CEL
+func celValue(value interface{}) interface{} {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var plain interface{}
+	if err := utiljson.Unmarshal(content, &plain); err != nil {
+		return value
+	}
+	return plain
+}
+
+// subValues returns the evaluated values of the sub-expressions, like the fields and function calls,
+// in the order of the expression
+func subValues(expr ast.Expr, info *ast.SourceInfo, state interpreter.EvalState) []string {
+	var lines []string
+	var visit func(e ast.Expr)
+	show := func(e ast.Expr) {
+		value, found := state.Value(e.ID())
+		if !found {
+			return
+		}
+		text, err := parser.Unparse(e, info)
+		if err != nil {
+			return
+		}
+		line := fmt.Sprintf("%s = %s", text, formatCelValue(value))
+		if !slices.Contains(lines, line) {
+			lines = append(lines, line)
+		}
+	}
+
+	visit = func(e ast.Expr) {
+		switch e.Kind() {
+		case ast.SelectKind:
+			// The presence tests of has() are shown by the value of the macro
+			if !e.AsSelect().IsTestOnly() {
+				show(e)
+			}
+		case ast.CallKind:
+			call := e.AsCall()
+			switch call.FunctionName() {
+			case operators.Index, operators.OptIndex, operators.OptSelect:
+				show(e)
+				for _, arg := range call.Args()[1:] {
+					visit(arg)
+				}
+				return
+			}
+			if _, isOperator := operators.FindReverse(call.FunctionName()); !isOperator {
+				show(e)
+			}
+			if call.IsMemberFunction() {
+				visit(call.Target())
+			}
+			for _, arg := range call.Args() {
+				visit(arg)
+			}
+		case ast.ComprehensionKind:
+			// The variables of the loop only hold the value of their last iteration
+			visit(e.AsComprehension().IterRange())
+		case ast.ListKind:
+			for _, element := range e.AsList().Elements() {
+				visit(element)
+			}
+		case ast.MapKind:
+			for _, entry := range e.AsMap().Entries() {
+				visit(entry.AsMapEntry().Value())
+			}
+		}
+	}
+	visit(expr)
+	return lines
+}
+
+// formatCelValue returns the value as compact json
+func formatCelValue(value ref.Val) string {
+	content, err := json.Marshal(nativeCelValue(value))
+	if err != nil {
+		return fmt.Sprintf("%v", value.Value())
+	}
+	return string(content)
+}
+
+// nativeCelValue returns the go value of the CEL value
+func nativeCelValue(value ref.Val) interface{} {
+	switch v := value.(type) {
+	case types.Null:
+		return nil
+	case *types.Err:
+		return v.Error()
+	case traits.Mapper:
+		native := map[string]interface{}{}
+		for it := v.Iterator(); it.HasNext() == types.True; {
+			key := it.Next()
+			native[fmt.Sprintf("%v", key.Value())] = nativeCelValue(v.Get(key))
+		}
+		return native
+	case traits.Lister:
+		native := []interface{}{}
+		for it := v.Iterator(); it.HasNext() == types.True; {
+			native = append(native, nativeCelValue(it.Next()))
+		}
+		return native
+	}
+	return value.Value()
+}
+
+// Validate implement Validatable
+func (v SatisfiesValidator) Validate(context *ValidateContext) (bool, []string) {
+	program, expression, err := v.compile()
+	if err != nil {
+		return false, splitInfof(errorFormat, -1, -1, err.Error())
+	}
+	variables := celVariables(context.RenderValues)
+
+	manifests := context.getManifests()
+
+	validateSuccess := false
+	validateErrors := make([]string, 0)
+
+	for idx, manifest := range manifests {
+		variables[celSelfVariable] = celValue(manifest)
+		result, details, err := program.Eval(variables)
+		if err != nil {
+			return false, splitInfof(errorFormat, idx, -1, fmt.Sprintf("failed to evaluate %s: %s", v.Expression, err))
+		}
+		satisfied, ok := result.Value().(bool)
+		if !ok {
+			return false, splitInfof(errorFormat, idx, -1, fmt.Sprintf("expression must evaluate to a bool, got %s", result.Type().TypeName()))
+		}
+
+		manifestSuccess := satisfied != context.Negative
+		if !manifestSuccess {
+			values := subValues(expression.Expr(), expression.SourceInfo(), details.State())
+			validateErrors = append(validateErrors, v.failInfo(strings.Join(values, "\n"), idx, context.Negative)...)
+		}
+		validateSuccess = determineSuccess(idx, validateSuccess, manifestSuccess)
+
+		if !validateSuccess && context.FailFast {
+			break
+		}
+	}
+
+	if len(manifests) == 0 && !context.Negative {
+		validateErrors = append(validateErrors, v.failInfo("no manifest found", -1, context.Negative)...)
+	} else if len(manifests) == 0 && context.Negative {
+		validateSuccess = true
+	}
+
+	return validateSuccess, validateErrors
+}
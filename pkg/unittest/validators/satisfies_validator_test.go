@@ -0,0 +1,152 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+var satisfiesDoc = `
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+        - name: web
+          resources:
+            limits:
+              memory: 128Mi
+        - name: sidecar
+          resources: {}
+`
+
+var satisfiesRenderValues = map[string]interface{}{
+	"Release": map[string]interface{}{
+		"Name":      "my-release",
+		"Namespace": "apps",
+	},
+	"Values": chartutil.Values{
+		"pdb": map[string]interface{}{"minAvailable": 2},
+	},
+	"Capabilities": chartutil.DefaultCapabilities,
+}
+
+func TestSatisfiesValidatorWhenOk(t *testing.T) {
+	validator := SatisfiesValidator{Expression: `self.metadata.name == "web" && release.namespace == "apps" && int(capabilities.kubeVersion.major) >= 1`}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:         []common.K8sManifest{makeManifest(satisfiesDoc)},
+		RenderValues: satisfiesRenderValues,
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
+
+func TestSatisfiesValidatorWhenFail(t *testing.T) {
+	validator := SatisfiesValidator{Expression: "self.spec.replicas >= values.pdb.minAvailable"}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:         []common.K8sManifest{makeManifest(satisfiesDoc)},
+		RenderValues: satisfiesRenderValues,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Expected to satisfy:",
+		"\tself.spec.replicas >= values.pdb.minAvailable",
+		"Actual:",
+		"\tself.spec.replicas = 1",
+		"\tvalues.pdb.minAvailable = 2",
+	}, diff)
+}
+
+func TestSatisfiesValidatorWhenMacroFail(t *testing.T) {
+	validator := SatisfiesValidator{Expression: "self.spec.template.spec.containers.all(c, has(c.resources.limits))"}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(satisfiesDoc)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Expected to satisfy:",
+		"\tself.spec.template.spec.containers.all(c, has(c.resources.limits))",
+		"Actual:",
+		`	self.spec.template.spec.containers = [{"name":"web","resources":{"limits":{"memory":"128Mi"}}},{"name":"sidecar","resources":{}}]`,
+	}, diff)
+}
+
+func TestSatisfiesValidatorWhenNegativeAndFail(t *testing.T) {
+	validator := SatisfiesValidator{Expression: "size(self.spec.template.spec.containers) > 1"}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:     []common.K8sManifest{makeManifest(satisfiesDoc)},
+		Negative: true,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Expected NOT to satisfy:",
+		"\tsize(self.spec.template.spec.containers) > 1",
+		"Actual:",
+		"\tsize(self.spec.template.spec.containers) = 2",
+		`	self.spec.template.spec.containers = [{"name":"web","resources":{"limits":{"memory":"128Mi"}}},{"name":"sidecar","resources":{}}]`,
+	}, diff)
+}
+
+func TestSatisfiesValidatorWhenInvalidExpression(t *testing.T) {
+	validator := SatisfiesValidator{Expression: "self.spec.replicas +"}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(satisfiesDoc)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, "Error:", diff[0])
+	assert.Contains(t, diff[1], "invalid expression")
+}
+
+func TestSatisfiesValidatorWhenNotBool(t *testing.T) {
+	validator := SatisfiesValidator{Expression: "self.metadata.name + 'x'"}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(satisfiesDoc)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{"Error:", "\texpression must evaluate to a bool, got string"}, diff)
+}
+
+func TestSatisfiesValidatorWhenMissingField(t *testing.T) {
+	validator := SatisfiesValidator{Expression: "self.spec.minReadySeconds > 0"}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(satisfiesDoc)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Error:",
+		"\tfailed to evaluate self.spec.minReadySeconds > 0: no such key: minReadySeconds",
+	}, diff)
+}
+
+func TestSatisfiesValidatorWhenNoManifest(t *testing.T) {
+	validator := SatisfiesValidator{Expression: "true"}
+
+	pass, diff := validator.Validate(&ValidateContext{})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{"Expected to satisfy:", "\ttrue", "Actual:", "\tno manifest found"}, diff)
+}
@@ -0,0 +1,89 @@
+package validators
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+// SemverSatisfiesValidator validate whether the semantic version of Path satisfies the Constraint, like >=1.2.0 <2.0.0.
+// The version is parsed as strictly as IsSemverValidator does, so partial versions like 1.2 fail.
+// For image references, like nginx:1.25.3, the tag of the image is validated.
+type SemverSatisfiesValidator struct {
+	Path       string
+	Constraint string
+}
+
+func (v SemverSatisfiesValidator) failInfo(msg string, manifestIndex, actualIndex int, not bool) []string {
+	log.WithField("validator", "semver_satisfies").Debugln("constraint:", v.Constraint, "actual content:", msg)
+
+	return splitInfof(
+		setFailFormat(not, true, false, false, fmt.Sprintf(" to satisfy semver constraint '%s', got", v.Constraint)),
+		manifestIndex,
+		actualIndex,
+		v.Path,
+		msg,
+	)
+}
+
+func (v SemverSatisfiesValidator) validateSingle(actual interface{}, constraints *semver.Constraints, manifestIndex, actualIndex int, context *ValidateContext) (bool, []string) {
+	version, description, err := semverOf(actual)
+	if err != nil {
+		return false, splitInfof(errorFormat, manifestIndex, actualIndex, err.Error())
+	}
+
+	parsed, err := parseSemver(version)
+	if err != nil {
+		return false, splitInfof(errorFormat, manifestIndex, actualIndex, fmt.Sprintf("%s is not a semantic version", description))
+	}
+
+	satisfied, reasons := constraints.Validate(parsed)
+	if satisfied == context.Negative {
+		msg := fmt.Sprintf("%s satisfies the constraint", description)
+		if !satisfied {
+			failed := make([]string, len(reasons))
+			for idx, reason := range reasons {
+				failed[idx] = reason.Error()
+			}
+			msg = fmt.Sprintf("%s does not satisfy the constraint:\n%s", description, strings.Join(failed, "\n"))
+		}
+		return false, v.failInfo(msg, manifestIndex, actualIndex, context.Negative)
+	}
+
+	return true, []string{}
+}
+
+// Validate implement Validatable
+func (v SemverSatisfiesValidator) Validate(context *ValidateContext) (bool, []string) {
+	constraints, err := semver.NewConstraint(v.Constraint)
+	if err != nil {
+		return false, splitInfof(errorFormat, -1, -1, fmt.Sprintf("invalid semver constraint '%s': %s", v.Constraint, err))
+	}
+
+	manifests := context.getManifests()
+
+	validateSuccess := false
+	validateErrors := make([]string, 0)
+
+	for manifestIndex, manifest := range manifests {
+		validateManifestSuccess, validateManifestErrors := forEachValue(manifest, manifestIndex, v.Path, context, func(actual interface{}, actualIndex int) (bool, []string) {
+			return v.validateSingle(actual, constraints, manifestIndex, actualIndex, context)
+		})
+		validateErrors = append(validateErrors, validateManifestErrors...)
+		validateSuccess = determineSuccess(manifestIndex, validateSuccess, validateManifestSuccess)
+
+		if !validateSuccess && context.FailFast {
+			break
+		}
+	}
+
+	if len(manifests) == 0 && !context.Negative {
+		validateErrors = append(validateErrors, v.failInfo("no manifests found", -1, -1, context.Negative)...)
+	} else if len(manifests) == 0 && context.Negative {
+		validateSuccess = true
+	}
+
+	return validateSuccess, validateErrors
+}
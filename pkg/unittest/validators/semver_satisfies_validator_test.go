@@ -0,0 +1,105 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSemverSatisfiesValidatorWhenOk(t *testing.T) {
+	tests := []struct {
+		name, path, constraint string
+	}{
+		{name: "plain value", path: "metadata.labels.appVersion", constraint: ">=1.2.0 <2.0.0"},
+		{name: "tag of image reference", path: "spec.containers[0].image", constraint: "~1.25"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := SemverSatisfiesValidator{Path: tt.path, Constraint: tt.constraint}
+			pass, diff := v.Validate(&ValidateContext{
+				Docs: []common.K8sManifest{makeManifest(semverDoc)},
+			})
+
+			assert.True(t, pass)
+			assert.Equal(t, []string{}, diff)
+		})
+	}
+}
+
+func TestSemverSatisfiesValidatorWhenFail(t *testing.T) {
+	v := SemverSatisfiesValidator{Path: "spec.containers[0].image", Constraint: ">=1.2.0 <1.25.0"}
+	pass, diff := v.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(semverDoc)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"ValuesIndex:\t0",
+		"Path:\tspec.containers[0].image",
+		"Expected to satisfy semver constraint '>=1.2.0 <1.25.0', got:",
+		"\tthe tag '1.25.3' of the image 'registry.example.com:5000/web:1.25.3@sha256:abc' does not satisfy the constraint:",
+		"\t1.25.3 is greater than or equal to 1.25.0",
+	}, diff)
+}
+
+func TestSemverSatisfiesValidatorWhenNegativeAndFail(t *testing.T) {
+	v := SemverSatisfiesValidator{Path: "metadata.labels.appVersion", Constraint: "1.x"}
+	pass, diff := v.Validate(&ValidateContext{
+		Docs:     []common.K8sManifest{makeManifest(semverDoc)},
+		Negative: true,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"ValuesIndex:\t0",
+		"Path:\tmetadata.labels.appVersion",
+		"Expected NOT to satisfy semver constraint '1.x', got:",
+		"\tthe actual 'v1.4.2' satisfies the constraint",
+	}, diff)
+}
+
+func TestSemverSatisfiesValidatorWhenNotSemver(t *testing.T) {
+	v := SemverSatisfiesValidator{Path: "spec.containers[1].image", Constraint: ">=1.0.0"}
+	pass, diff := v.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(semverDoc)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"ValuesIndex:\t0",
+		"Error:",
+		"\tthe tag 'stable' of the image 'nginx:stable' is not a semantic version",
+	}, diff)
+}
+
+func TestSemverSatisfiesValidatorWhenInvalidConstraint(t *testing.T) {
+	v := SemverSatisfiesValidator{Path: "metadata.labels.appVersion", Constraint: "newest"}
+	pass, diff := v.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(semverDoc)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, "Error:", diff[0])
+	assert.Contains(t, diff[1], "invalid semver constraint 'newest'")
+}
+
+func TestSemverSatisfiesValidatorWhenPartialVersion(t *testing.T) {
+	v := SemverSatisfiesValidator{Path: "metadata.labels.appVersion", Constraint: ">=1.0.0"}
+	pass, diff := v.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest("metadata:\n  labels:\n    appVersion: \"1.2\"\n")},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"ValuesIndex:\t0",
+		"Error:",
+		"\tthe actual '1.2' is not a semantic version",
+	}, diff)
+}
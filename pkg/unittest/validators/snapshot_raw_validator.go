@@ -1,18 +1,28 @@
 package validators
 
 import (
-	"strconv"
-
 	"github.com/helm-unittest/helm-unittest/internal/common"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
 	log "github.com/sirupsen/logrus"
 )
 
 // MatchSnapshotRawValidator validate snapshot of value of Path the same as cached
-type MatchSnapshotRawValidator struct{}
+type MatchSnapshotRawValidator struct {
+	// Name keys the snapshot by name instead of by order
+	Name string
+}
 
 func (v MatchSnapshotRawValidator) failInfo(compared *snapshot.CompareResult, not bool) []string {
-	customMessage := " to match snapshot " + strconv.Itoa(int(compared.Index))
+	if compared.Missing {
+		return splitInfof(
+			setFailFormat(false, false, false, false, missingSnapshotMessage(compared)),
+			-1,
+			-1,
+			compared.NewSnapshot,
+		)
+	}
+
+	customMessage := " to match snapshot " + snapshotName(compared)
 
 	log.WithField("validator", "snapshot_raw").Debugln("expected content:", compared.CachedSnapshot)
 	log.WithField("validator", "snapshot_raw").Debugln("actual content:", compared.NewSnapshot)
@@ -43,9 +53,9 @@ func (v MatchSnapshotRawValidator) Validate(context *ValidateContext) (bool, []s
 		var errorMessage []string
 		actual := uniformContent(manifest[common.RAW])
 
-		result := context.CompareToSnapshot(actual)
+		result := context.compareToSnapshot(SnapshotKey{Name: v.Name, Identity: context.Template}, actual)
 
-		if result.Passed == context.Negative {
+		if result.Missing || result.Passed == context.Negative {
 			errorMessage = v.failInfo(result, context.Negative)
 		} else {
 			validateSingleSuccess = true
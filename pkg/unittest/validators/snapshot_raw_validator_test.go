@@ -135,3 +135,50 @@ func TestSnapshotRawValidatorWhenNoManifestNegativeFail(t *testing.T) {
 	assert.False(t, pass)
 	assert.Equal(t, []string{}, diff)
 }
+
+func TestSnapshotRawValidatorWhenNamedOk(t *testing.T) {
+	data := common.K8sManifest{common.RAW: "b"}
+	validator := MatchSnapshotRawValidator{Name: "notes"}
+
+	mockComparer := new(mockKeyedSnapshotComparer)
+	mockComparer.On("CompareToKeyedSnapshot", SnapshotKey{Name: "notes", Identity: "templates/NOTES.txt"}, "b").Return(&snapshot.CompareResult{
+		Passed: true,
+	})
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:             []common.K8sManifest{data},
+		SnapshotComparer: mockComparer,
+		Template:         "templates/NOTES.txt",
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+
+	mockComparer.AssertExpectations(t)
+}
+
+func TestSnapshotRawValidatorWhenMissing(t *testing.T) {
+	data := common.K8sManifest{common.RAW: "b"}
+	validator := MatchSnapshotRawValidator{}
+
+	mockComparer := new(mockSnapshotComparer)
+	mockComparer.On("CompareToSnapshot", "b").Return(&snapshot.CompareResult{
+		Passed:      false,
+		Index:       1,
+		Missing:     true,
+		NewSnapshot: "b\n",
+	})
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:             []common.K8sManifest{data},
+		SnapshotComparer: mockComparer,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Expected snapshot 1 to exist, snapshots are not created in CI mode:",
+		"	b",
+	}, diff)
+
+	mockComparer.AssertExpectations(t)
+}
@@ -2,7 +2,6 @@ package validators
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/helm-unittest/helm-unittest/internal/common"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
@@ -13,10 +12,27 @@ import (
 // MatchSnapshotValidator validate snapshot of value of Path the same as cached
 type MatchSnapshotValidator struct {
 	Path string
+	// Name keys the snapshot by name instead of by order
+	Name string
+	// IgnorePaths are the paths of the document masked before comparing to the snapshot
+	IgnorePaths []string
 }
 
+// ignoredSnapshotValue masks the values of the IgnorePaths in the snapshot
+const ignoredSnapshotValue = "<ignored>"
+
 func (v MatchSnapshotValidator) failInfo(compared *snapshot.CompareResult, manifestIndex, actualIndex int, not bool) []string {
-	customMessage := " to match snapshot " + strconv.Itoa(int(compared.Index))
+	if compared.Missing {
+		return splitInfof(
+			setFailFormat(false, true, false, false, missingSnapshotMessage(compared)),
+			manifestIndex,
+			actualIndex,
+			v.Path,
+			compared.NewSnapshot,
+		)
+	}
+
+	customMessage := " to match snapshot " + snapshotName(compared)
 
 	log.WithField("validator", "snapshot").Debugln("expected content:", compared.CachedSnapshot)
 	log.WithField("validator", "snapshot").Debugln("actual content:", compared.NewSnapshot)
@@ -25,7 +41,7 @@ func (v MatchSnapshotValidator) failInfo(compared *snapshot.CompareResult, manif
 	if not {
 		infoToShow = compared.CachedSnapshot
 	} else {
-		infoToShow = diff(compared.CachedSnapshot, compared.NewSnapshot)
+		infoToShow = semanticDiffYAML(compared.CachedSnapshot, compared.NewSnapshot)
 	}
 	return splitInfof(
 		setFailFormat(not, true, false, false, customMessage),
@@ -37,7 +53,15 @@ func (v MatchSnapshotValidator) failInfo(compared *snapshot.CompareResult, manif
 }
 
 func (v MatchSnapshotValidator) validateManifest(manifest common.K8sManifest, manifestIndex int, context *ValidateContext) (bool, []string) {
-	actual, err := valueutils.GetValueOfSetPath(manifest, v.Path)
+	masked := manifest
+	if len(v.IgnorePaths) > 0 {
+		var err error
+		if masked, err = valueutils.MaskValuesOfSetPaths(manifest, v.IgnorePaths, ignoredSnapshotValue); err != nil {
+			return false, splitInfof(errorFormat, manifestIndex, -1, err.Error())
+		}
+	}
+
+	actual, err := valueutils.GetValueOfSetPath(masked, v.Path)
 	if err != nil {
 		return false, splitInfof(errorFormat, manifestIndex, -1, err.Error())
 	}
@@ -52,9 +76,9 @@ func (v MatchSnapshotValidator) validateManifest(manifest common.K8sManifest, ma
 	for actualIndex, singleActual := range actual {
 		validateSingleSuccess := false
 		var validateSingleErrors []string
-		result := context.CompareToSnapshot(singleActual)
+		result := context.compareToSnapshot(v.snapshotKey(manifest, context, actualIndex, len(actual)), singleActual)
 
-		if result.Passed == context.Negative {
+		if result.Missing || result.Passed == context.Negative {
 			validateSingleErrors = v.failInfo(result, manifestIndex, actualIndex, context.Negative)
 		} else {
 			validateSingleSuccess = true
@@ -71,6 +95,18 @@ func (v MatchSnapshotValidator) validateManifest(manifest common.K8sManifest, ma
 	return validateManifestSuccess, validateManifestErrors
 }
 
+// snapshotKey returns the key of the snapshot of the value at actualIndex of Path in the manifest
+func (v MatchSnapshotValidator) snapshotKey(manifest common.K8sManifest, context *ValidateContext, actualIndex, actualCount int) SnapshotKey {
+	identity := documentIdentity(context.Template, manifest)
+	if identity != "" && v.Path != "" {
+		identity += " " + v.Path
+	}
+	if identity != "" && actualCount > 1 {
+		identity += fmt.Sprintf("[%d]", actualIndex)
+	}
+	return SnapshotKey{Name: v.Name, Identity: identity}
+}
+
 // Validate implement Validatable
 func (v MatchSnapshotValidator) Validate(context *ValidateContext) (bool, []string) {
 	manifests := context.getManifests()
@@ -93,6 +93,35 @@ func TestSnapshotValidatorWhenFail(t *testing.T) {
 		FailFast:         true,
 	})
 
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:	0",
+		"ValuesIndex:	0",
+		"Path:	a",
+		"Expected to match snapshot 0:",
+		"	- a: removed {b: c}",
+		"	+ x: added {\"y\": x}",
+	}, diff)
+
+	mockComparer.AssertExpectations(t)
+}
+
+func TestSnapshotValidatorWhenFailWithScalarSnapshot(t *testing.T) {
+	data := common.K8sManifest{"a": "b"}
+	validator := MatchSnapshotValidator{Path: "a"}
+
+	mockComparer := new(mockSnapshotComparer)
+	mockComparer.On("CompareToSnapshot", "b").Return(&snapshot.CompareResult{
+		Passed:         false,
+		CachedSnapshot: "c\n",
+		NewSnapshot:    "b\n",
+	})
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:             []common.K8sManifest{data},
+		SnapshotComparer: mockComparer,
+	})
+
 	assert.False(t, pass)
 	assert.Equal(t, []string{
 		"DocumentIndex:	0",
@@ -101,11 +130,9 @@ func TestSnapshotValidatorWhenFail(t *testing.T) {
 		"Expected to match snapshot 0:",
 		"	--- Expected",
 		"	+++ Actual",
-		"	@@ -1,3 +1,3 @@",
-		"	-a:",
-		"	-  b: c",
-		"	+x:",
-		"	+  y: x",
+		"	@@ -1,2 +1,2 @@",
+		"	-c",
+		"	+b",
 	}, diff)
 
 	mockComparer.AssertExpectations(t)
@@ -236,3 +263,143 @@ func TestSnapshotValidatorWhenNoManifestNegativeFail(t *testing.T) {
 	assert.False(t, pass)
 	assert.Equal(t, []string{}, diff)
 }
+
+func TestSnapshotValidatorWhenKeyedByIdentity(t *testing.T) {
+	data := makeManifest(`
+kind: Deployment
+metadata:
+  name: my-app
+  namespace: my-namespace
+spec:
+  replicas: 2
+`)
+	validator := MatchSnapshotValidator{Path: "spec"}
+
+	mockComparer := new(mockKeyedSnapshotComparer)
+	mockComparer.On("CompareToKeyedSnapshot", SnapshotKey{
+		Identity: "templates/deployment.yaml Deployment/my-namespace/my-app spec",
+	}, map[string]interface{}{"replicas": 2}).Return(&snapshot.CompareResult{
+		Passed: true,
+	})
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:             []common.K8sManifest{data},
+		SnapshotComparer: mockComparer,
+		Template:         "templates/deployment.yaml",
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+
+	mockComparer.AssertExpectations(t)
+}
+
+func TestSnapshotValidatorWhenNamedFail(t *testing.T) {
+	data := common.K8sManifest{"a": "b"}
+	validator := MatchSnapshotValidator{Path: "a", Name: "my snapshot"}
+
+	mockComparer := new(mockKeyedSnapshotComparer)
+	mockComparer.On("CompareToKeyedSnapshot", SnapshotKey{Name: "my snapshot"}, "b").Return(&snapshot.CompareResult{
+		Passed:         false,
+		Key:            "my snapshot",
+		CachedSnapshot: "c\n",
+		NewSnapshot:    "b\n",
+	})
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:             []common.K8sManifest{data},
+		SnapshotComparer: mockComparer,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:	0",
+		"ValuesIndex:	0",
+		"Path:	a",
+		"Expected to match snapshot my snapshot:",
+		"	--- Expected",
+		"	+++ Actual",
+		"	@@ -1,2 +1,2 @@",
+		"	-c",
+		"	+b",
+	}, diff)
+
+	mockComparer.AssertExpectations(t)
+}
+
+func TestSnapshotValidatorWhenMissing(t *testing.T) {
+	data := common.K8sManifest{"a": "b"}
+	validator := MatchSnapshotValidator{Path: "a"}
+
+	mockComparer := new(mockSnapshotComparer)
+	mockComparer.On("CompareToSnapshot", "b").Return(&snapshot.CompareResult{
+		Passed:      false,
+		Index:       1,
+		Missing:     true,
+		NewSnapshot: "b\n",
+	})
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:             []common.K8sManifest{data},
+		Negative:         true,
+		SnapshotComparer: mockComparer,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:	0",
+		"ValuesIndex:	0",
+		"Path:	a",
+		"Expected snapshot 1 to exist, snapshots are not created in CI mode:",
+		"	b",
+	}, diff)
+
+	mockComparer.AssertExpectations(t)
+}
+
+func TestSnapshotValidatorWhenIgnorePaths(t *testing.T) {
+	data := makeManifest(`
+metadata:
+  annotations:
+    checksum/config: 1234abcd
+spec:
+  replicas: 2
+`)
+	validator := MatchSnapshotValidator{IgnorePaths: []string{"metadata.annotations['checksum/config']"}}
+
+	mockComparer := new(mockSnapshotComparer)
+	mockComparer.On("CompareToSnapshot", common.K8sManifest{
+		"metadata": common.K8sManifest{
+			"annotations": common.K8sManifest{"checksum/config": "<ignored>"},
+		},
+		"spec": common.K8sManifest{"replicas": 2},
+	}).Return(&snapshot.CompareResult{
+		Passed: true,
+	})
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:             []common.K8sManifest{data},
+		SnapshotComparer: mockComparer,
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+
+	mockComparer.AssertExpectations(t)
+}
+
+func TestSnapshotValidatorWhenInvalidIgnorePaths(t *testing.T) {
+	data := common.K8sManifest{"a": "b"}
+	validator := MatchSnapshotValidator{IgnorePaths: []string{"a[b"}}
+
+	mockComparer := new(mockSnapshotComparer)
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:             []common.K8sManifest{data},
+		SnapshotComparer: mockComparer,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, "DocumentIndex:	0", diff[0])
+	assert.Equal(t, "Error:", diff[1])
+	mockComparer.AssertNotCalled(t, "CompareToSnapshot")
+}
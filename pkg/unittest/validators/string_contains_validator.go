@@ -11,7 +11,6 @@ import (
 	"github.com/yosuke-furukawa/json5/encoding/json5"
 
 	"github.com/helm-unittest/helm-unittest/internal/common"
-	"github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
 	"gopkg.in/yaml.v3"
 )
 
@@ -22,6 +21,8 @@ type StringContainsValidator struct {
 	IgnoreFormatting bool // When true, ignores spaces, tabs and line breaks in comparison
 	FromJson         bool // When true, treats the string as JSON and checks if it contains the YAML content
 	FromYaml         bool // When true, treats the string as YAML and checks if it contains the YAML content
+	Decode           interface{}
+	SubPath          string
 }
 
 // normalizeWhitespace removes all whitespace characters and replaces them with a single space
@@ -223,7 +224,7 @@ func (v StringContainsValidator) validateSingle(singleActual string, manifestInd
 }
 
 func (v StringContainsValidator) validateManifest(manifest common.K8sManifest, manifestIndex int, context *ValidateContext) (bool, []string) {
-	actual, err := valueutils.GetValueOfSetPath(manifest, v.Path)
+	actual, err := valuesOfPath(manifest, v.Path, v.Decode, v.SubPath)
 	if err != nil {
 		return false, splitInfof(errorFormat, manifestIndex, -1, err.Error())
 	}
@@ -0,0 +1,168 @@
+package valueutils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	"github.com/magiconair/properties"
+	"gopkg.in/ini.v1"
+)
+
+// decoders decode a string of their format, the structured formats into plain maps and lists like yaml documents
+var decoders = map[string]func(content string) (interface{}, error){
+	"base64":     decodeBase64,
+	"json":       decodeJSON,
+	"yaml":       decodeYAML,
+	"toml":       decodeTOML,
+	"properties": decodeProperties,
+	"ini":        decodeINI,
+}
+
+// DecodeFormats returns the formats of the decode option, which is a format or a list of formats to decode in order
+func DecodeFormats(decode interface{}) ([]string, error) {
+	var formats []string
+	switch d := decode.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		formats = []string{d}
+	case []string:
+		formats = d
+	case []interface{}:
+		for _, format := range d {
+			formats = append(formats, fmt.Sprintf("%v", format))
+		}
+	default:
+		return nil, fmt.Errorf("decode must be a format or a list of formats, got %v", decode)
+	}
+
+	for _, format := range formats {
+		if _, ok := decoders[format]; !ok {
+			return nil, fmt.Errorf("unknown decode format '%s', expected one of base64, json, yaml, toml, properties, ini", format)
+		}
+	}
+	return formats, nil
+}
+
+// DecodeValue decodes the value by each of the formats in order, like base64 and then json
+func DecodeValue(value interface{}, formats []string) (interface{}, error) {
+	for _, format := range formats {
+		content, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("unable to decode %s, the value is no string: %v", format, value)
+		}
+
+		decoded, err := decoders[format](content)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode %s: %s", format, err)
+		}
+		value = decoded
+	}
+	return value, nil
+}
+
+// GetValueOfDecodedPath get the values of the `--set` format path from a manifest, decoded by the formats,
+// and the values of the sub path in the decoded content
+func GetValueOfDecodedPath(manifest common.K8sManifest, path string, formats []string, subPath string) ([]interface{}, error) {
+	actuals, err := GetValueOfSetPath(manifest, path)
+	if err != nil || (len(formats) == 0 && subPath == "") {
+		return actuals, err
+	}
+
+	values := make([]interface{}, 0, len(actuals))
+	for _, actual := range actuals {
+		decoded, err := DecodeValue(actual, formats)
+		if err != nil {
+			return nil, err
+		}
+		if subPath == "" {
+			values = append(values, decoded)
+			continue
+		}
+
+		subValues, err := getValueOfPath(decoded, subPath)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, subValues...)
+	}
+	return values, nil
+}
+
+func decodeBase64(content string) (interface{}, error) {
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(content))
+	if err != nil {
+		return nil, err
+	}
+	return string(decoded), nil
+}
+
+func decodeJSON(content string) (interface{}, error) {
+	var value interface{}
+	if err := json.Unmarshal([]byte(content), &value); err != nil {
+		return nil, err
+	}
+	// JSON is decoded as yaml to compare the numbers like the values of the manifests
+	return decodeYAML(content)
+}
+
+func decodeYAML(content string) (interface{}, error) {
+	var value interface{}
+	if err := common.YmlUnmarshal(content, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func decodeTOML(content string) (interface{}, error) {
+	value := map[string]interface{}{}
+	if _, err := toml.Decode(content, &value); err != nil {
+		return nil, err
+	}
+	return plainValue(value)
+}
+
+func decodeProperties(content string) (interface{}, error) {
+	loaded, err := properties.LoadString(content)
+	if err != nil {
+		return nil, err
+	}
+	value := map[string]interface{}{}
+	for key, property := range loaded.Map() {
+		value[key] = property
+	}
+	return value, nil
+}
+
+// decodeINI decodes the keys without section at the top level, and the keys of each section by the section name
+func decodeINI(content string) (interface{}, error) {
+	loaded, err := ini.Load([]byte(content))
+	if err != nil {
+		return nil, err
+	}
+	value := map[string]interface{}{}
+	for _, section := range loaded.Sections() {
+		keys := value
+		if section.Name() != ini.DefaultSection {
+			keys = map[string]interface{}{}
+			value[section.Name()] = keys
+		}
+		for _, key := range section.Keys() {
+			keys[key.Name()] = key.Value()
+		}
+	}
+	return value, nil
+}
+
+// plainValue returns the value with the plain maps, lists and numbers of a yaml document
+func plainValue(value interface{}) (interface{}, error) {
+	content, err := common.YmlMarshall(value)
+	if err != nil {
+		return nil, err
+	}
+	return decodeYAML(content)
+}
@@ -0,0 +1,127 @@
+package valueutils_test
+
+import (
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeFormats(t *testing.T) {
+	a := assert.New(t)
+
+	formats, err := DecodeFormats("base64")
+	a.NoError(err)
+	a.Equal([]string{"base64"}, formats)
+
+	formats, err = DecodeFormats([]interface{}{"base64", "json"})
+	a.NoError(err)
+	a.Equal([]string{"base64", "json"}, formats)
+
+	formats, err = DecodeFormats(nil)
+	a.NoError(err)
+	a.Nil(formats)
+
+	_, err = DecodeFormats("xml")
+	a.EqualError(err, "unknown decode format 'xml', expected one of base64, json, yaml, toml, properties, ini")
+
+	_, err = DecodeFormats(map[string]interface{}{"format": "json"})
+	a.EqualError(err, "decode must be a format or a list of formats, got map[format:json]")
+}
+
+func TestDecodeValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		formats  []string
+		expected interface{}
+	}{
+		{
+			name:     "base64",
+			value:    "c2VjcmV0",
+			formats:  []string{"base64"},
+			expected: "secret",
+		},
+		{
+			name:     "base64 then json",
+			value:    "eyJwb3J0IjogNTQzMn0=",
+			formats:  []string{"base64", "json"},
+			expected: map[string]interface{}{"port": 5432},
+		},
+		{
+			name:     "yaml",
+			value:    "hosts:\n  - a\n  - b\n",
+			formats:  []string{"yaml"},
+			expected: map[string]interface{}{"hosts": []interface{}{"a", "b"}},
+		},
+		{
+			name:     "toml",
+			value:    "[database]\nport = 5432\nhost = \"db\"\n",
+			formats:  []string{"toml"},
+			expected: map[string]interface{}{"database": map[string]interface{}{"port": 5432, "host": "db"}},
+		},
+		{
+			name:     "properties",
+			value:    "database.host=db\ndatabase.port = 5432\n",
+			formats:  []string{"properties"},
+			expected: map[string]interface{}{"database.host": "db", "database.port": "5432"},
+		},
+		{
+			name:     "ini",
+			value:    "mode = production\n[database]\nhost = db\n",
+			formats:  []string{"ini"},
+			expected: map[string]interface{}{"mode": "production", "database": map[string]interface{}{"host": "db"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := DecodeValue(tt.value, tt.formats)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, decoded)
+		})
+	}
+}
+
+func TestDecodeValueWhenInvalid(t *testing.T) {
+	a := assert.New(t)
+
+	_, err := DecodeValue("not base64!", []string{"base64"})
+	a.EqualError(err, "unable to decode base64: illegal base64 data at input byte 3")
+
+	_, err = DecodeValue("{invalid", []string{"json"})
+	a.EqualError(err, "unable to decode json: invalid character 'i' looking for beginning of object key string")
+
+	_, err = DecodeValue("c2VjcmV0", []string{"base64", "json", "yaml"})
+	a.EqualError(err, "unable to decode json: invalid character 's' looking for beginning of value")
+
+	_, err = DecodeValue(map[string]interface{}{"port": 5432}, []string{"json"})
+	a.EqualError(err, "unable to decode json, the value is no string: map[port:5432]")
+}
+
+func TestGetValueOfDecodedPath(t *testing.T) {
+	a := assert.New(t)
+	manifest := common.K8sManifest{
+		"data": map[string]interface{}{
+			"config.json":    "eyJkYXRhYmFzZSI6IHsiaG9zdHMiOiBbImEiLCAiYiJdfX0=",
+			"app.properties": "database.host=db\n",
+		},
+	}
+
+	values, err := GetValueOfDecodedPath(manifest, "data['config.json']", []string{"base64", "json"}, "database.hosts[1]")
+	a.NoError(err)
+	a.Equal([]interface{}{"b"}, values)
+
+	values, err = GetValueOfDecodedPath(manifest, "data['app.properties']", []string{"properties"}, "['database.host']")
+	a.NoError(err)
+	a.Equal([]interface{}{"db"}, values)
+
+	values, err = GetValueOfDecodedPath(manifest, "data['config.json']", []string{"base64", "json"}, "database.port")
+	a.NoError(err)
+	a.Empty(values)
+
+	values, err = GetValueOfDecodedPath(manifest, "data.missing", []string{"base64"}, "")
+	a.NoError(err)
+	a.Empty(values)
+}
@@ -8,33 +8,26 @@ import (
 
 	"github.com/helm-unittest/helm-unittest/internal/common"
 	"github.com/vmware-labs/yaml-jsonpath/pkg/yamlpath"
+	yamlv3 "gopkg.in/yaml.v3"
 )
 
 // GetValueOfSetPath get the value of the `--set` format path from a manifest
 func GetValueOfSetPath(manifest common.K8sManifest, path string) ([]interface{}, error) {
+	return getValueOfPath(manifest, path)
+}
+
+// getValueOfPath get the value of the `--set` format path from any value, like a decoded document
+func getValueOfPath(value interface{}, path string) ([]interface{}, error) {
 	manifestResult := make([]interface{}, 0)
 	if path == "" {
-		return append(manifestResult, manifest), nil
+		return append(manifestResult, value), nil
 	}
 
-	byteBuffer := new(bytes.Buffer)
-
-	// Convert K8Manifest to yaml.Node
-	node := common.NewYamlNode()
-	yamlEncoder := common.YamlNewEncoder(byteBuffer)
-	yamlEncoder.SetIndent(common.YAMLINDENTION)
-
-	err := yamlEncoder.Encode(manifest)
+	node, err := manifestToNode(value)
 	if err != nil {
 		return nil, err
 	}
 
-	yamlDecoder := common.YamlNewDecoder(byteBuffer)
-
-	if err := yamlDecoder.Decode(&node.Node); err != nil {
-		return nil, err
-	}
-
 	// Set Path
 	yamlPath, err := yamlpath.NewPath(path)
 	if err != nil {
@@ -58,6 +51,59 @@ func GetValueOfSetPath(manifest common.K8sManifest, path string) ([]interface{},
 	return manifestResult, nil
 }
 
+// MaskValuesOfSetPaths returns a copy of the manifest with the values of the `--set` format paths replaced by the mask,
+// paths without values are ignored
+func MaskValuesOfSetPaths(manifest common.K8sManifest, paths []string, mask string) (common.K8sManifest, error) {
+	node, err := manifestToNode(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range paths {
+		yamlPath, err := yamlpath.NewPath(path)
+		if err != nil {
+			return nil, err
+		}
+
+		maskedParts, err := yamlPath.Find(&node.Node)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, maskedPart := range maskedParts {
+			*maskedPart = yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: mask}
+		}
+	}
+
+	masked := common.K8sManifest{}
+	if err := node.Node.Decode(&masked); err != nil {
+		return nil, err
+	}
+	return masked, nil
+}
+
+// manifestToNode converts the manifest, or any value, to a yaml node
+func manifestToNode(manifest interface{}) (*common.YamlNode, error) {
+	byteBuffer := new(bytes.Buffer)
+
+	// Convert K8Manifest to yaml.Node
+	node := common.NewYamlNode()
+	yamlEncoder := common.YamlNewEncoder(byteBuffer)
+	yamlEncoder.SetIndent(common.YAMLINDENTION)
+
+	err := yamlEncoder.Encode(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	yamlDecoder := common.YamlNewDecoder(byteBuffer)
+
+	if err := yamlDecoder.Decode(&node.Node); err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
+
 // BuildValueOfSetPath build the complete form the `--set` format path and its value
 func BuildValueOfSetPath(val interface{}, path string) (map[string]interface{}, error) {
 	if path == "" {
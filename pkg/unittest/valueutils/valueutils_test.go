@@ -590,3 +590,49 @@ func TestMergeValues_OverwriteWithNil(t *testing.T) {
 	actual := v3util.MergeTables(dest, src)
 	assert.Equal(t, expected, actual)
 }
+
+func TestMaskValuesOfSetPaths(t *testing.T) {
+	a := assert.New(t)
+	data := common.TrustedUnmarshalYAML(`
+metadata:
+  annotations:
+    checksum/config: 1234abcd
+    team: a
+spec:
+  containers:
+    - name: app
+      env:
+        - name: SECRET
+          value: random
+`)
+
+	masked, err := MaskValuesOfSetPaths(data, []string{
+		"metadata.annotations['checksum/config']",
+		"spec.containers[*].env[?(@.name == 'SECRET')].value",
+		"spec.notExisting",
+	}, "<ignored>")
+
+	a.Nil(err)
+	a.Equal(`metadata:
+  annotations:
+    checksum/config: <ignored>
+    team: a
+spec:
+  containers:
+    - env:
+        - name: SECRET
+          value: <ignored>
+      name: app
+`, common.TrustedMarshalYAML(masked))
+	a.Equal("1234abcd", data["metadata"].(common.K8sManifest)["annotations"].(common.K8sManifest)["checksum/config"])
+}
+
+func TestMaskValuesOfSetPathsWhenInvalidPath(t *testing.T) {
+	a := assert.New(t)
+	data := common.K8sManifest{"a": "b"}
+
+	masked, err := MaskValuesOfSetPaths(data, []string{"a[b"}, "<ignored>")
+
+	a.Nil(masked)
+	a.NotNil(err)
+}
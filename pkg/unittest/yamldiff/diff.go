@@ -0,0 +1,298 @@
+package yamldiff
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// Op is the operation of a Change
+type Op string
+
+const (
+	// Changed the value at the path differs
+	Changed Op = "~"
+	// Added the value at the path only exists in the actual value
+	Added Op = "+"
+	// Removed the value at the path only exists in the expected value
+	Removed Op = "-"
+	// Moved the list item at the path is found at another index of the actual list
+	Moved Op = "^"
+)
+
+// maxListPairs limits the size of the lists compared by their longest common subsequence,
+// larger lists are compared by index
+const maxListPairs = 250000
+
+var plainKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// Change is a difference between the expected and the actual value at a path
+type Change struct {
+	Op Op
+	// Path is the `--set` format path of the value, empty for the root value
+	Path     string
+	Expected interface{}
+	Actual   interface{}
+	// To is the index of a Moved list item in the actual list
+	To int
+}
+
+// String returns the change as a single line
+func (c Change) String() string {
+	path := c.Path
+	if path == "" {
+		path = "(root)"
+	}
+
+	switch c.Op {
+	case Added:
+		return fmt.Sprintf("%s %s: added %s", c.Op, path, formatValue(c.Actual))
+	case Removed:
+		return fmt.Sprintf("%s %s: removed %s", c.Op, path, formatValue(c.Expected))
+	case Moved:
+		return fmt.Sprintf("%s %s: moved to [%d]", c.Op, path, c.To)
+	default:
+		return fmt.Sprintf("%s %s: %s → %s", c.Op, path, formatValue(c.Expected), formatValue(c.Actual))
+	}
+}
+
+// Format returns the changes, one per line
+func Format(changes []Change) string {
+	lines := make([]string, len(changes))
+	for i, change := range changes {
+		lines[i] = change.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Compare returns the changes from the expected to the actual value, which are compared as yaml.
+// Maps are compared by key, lists by their longest common subsequence of equal items,
+// equal items at other indexes are Moved and the remaining items are compared by order.
+func Compare(expected, actual interface{}) ([]Change, error) {
+	normalizedExpected, err := normalize(expected)
+	if err != nil {
+		return nil, err
+	}
+	normalizedActual, err := normalize(actual)
+	if err != nil {
+		return nil, err
+	}
+	return compare("", normalizedExpected, normalizedActual), nil
+}
+
+// IsStructured check if the value is a map or a list
+func IsStructured(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+	kind := reflect.TypeOf(value).Kind()
+	return kind == reflect.Map || kind == reflect.Slice || kind == reflect.Array
+}
+
+// normalize converts the value to the generic yaml types, so values of other go types compare equal
+func normalize(value interface{}) (interface{}, error) {
+	content, err := yamlv3.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized interface{}
+	if err := yamlv3.Unmarshal(content, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+func compare(path string, expected, actual interface{}) []Change {
+	expectedMap, expectedIsMap := expected.(map[string]interface{})
+	actualMap, actualIsMap := actual.(map[string]interface{})
+	if expectedIsMap && actualIsMap {
+		return compareMaps(path, expectedMap, actualMap)
+	}
+
+	expectedList, expectedIsList := expected.([]interface{})
+	actualList, actualIsList := actual.([]interface{})
+	if expectedIsList && actualIsList {
+		return compareLists(path, expectedList, actualList)
+	}
+
+	if reflect.DeepEqual(expected, actual) {
+		return nil
+	}
+	return []Change{{Op: Changed, Path: path, Expected: expected, Actual: actual}}
+}
+
+func compareMaps(path string, expected, actual map[string]interface{}) []Change {
+	keys := make([]string, 0, len(expected)+len(actual))
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	for key := range actual {
+		if _, ok := expected[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []Change
+	for _, key := range keys {
+		keyPath := JoinKey(path, key)
+		expectedValue, inExpected := expected[key]
+		actualValue, inActual := actual[key]
+		switch {
+		case !inActual:
+			changes = append(changes, Change{Op: Removed, Path: keyPath, Expected: expectedValue})
+		case !inExpected:
+			changes = append(changes, Change{Op: Added, Path: keyPath, Actual: actualValue})
+		default:
+			changes = append(changes, compare(keyPath, expectedValue, actualValue)...)
+		}
+	}
+	return changes
+}
+
+func compareLists(path string, expected, actual []interface{}) []Change {
+	if len(expected)*len(actual) > maxListPairs {
+		return compareListsByIndex(path, expected, actual)
+	}
+
+	// The items of the longest common subsequence are unchanged
+	matchedExpected := make([]bool, len(expected))
+	matchedActual := make([]bool, len(actual))
+	for _, pair := range longestCommonSubsequence(expected, actual) {
+		matchedExpected[pair[0]] = true
+		matchedActual[pair[1]] = true
+	}
+
+	var changes []Change
+	// Equal items at another index are moved
+	for i, expectedItem := range expected {
+		if matchedExpected[i] {
+			continue
+		}
+		for j, actualItem := range actual {
+			if !matchedActual[j] && reflect.DeepEqual(expectedItem, actualItem) {
+				matchedExpected[i] = true
+				matchedActual[j] = true
+				changes = append(changes, Change{Op: Moved, Path: JoinIndex(path, i), Expected: expectedItem, Actual: actualItem, To: j})
+				break
+			}
+		}
+	}
+
+	// The remaining items are compared by order
+	j := 0
+	for i, expectedItem := range expected {
+		if matchedExpected[i] {
+			continue
+		}
+		for j < len(actual) && matchedActual[j] {
+			j++
+		}
+		if j == len(actual) {
+			changes = append(changes, Change{Op: Removed, Path: JoinIndex(path, i), Expected: expectedItem})
+			continue
+		}
+		matchedActual[j] = true
+		changes = append(changes, compare(JoinIndex(path, i), expectedItem, actual[j])...)
+	}
+	for j, actualItem := range actual {
+		if !matchedActual[j] {
+			changes = append(changes, Change{Op: Added, Path: JoinIndex(path, j), Actual: actualItem})
+		}
+	}
+	return changes
+}
+
+func compareListsByIndex(path string, expected, actual []interface{}) []Change {
+	var changes []Change
+	for i := 0; i < len(expected) || i < len(actual); i++ {
+		switch {
+		case i >= len(actual):
+			changes = append(changes, Change{Op: Removed, Path: JoinIndex(path, i), Expected: expected[i]})
+		case i >= len(expected):
+			changes = append(changes, Change{Op: Added, Path: JoinIndex(path, i), Actual: actual[i]})
+		default:
+			changes = append(changes, compare(JoinIndex(path, i), expected[i], actual[i])...)
+		}
+	}
+	return changes
+}
+
+// longestCommonSubsequence returns the index pairs of the longest common subsequence of equal items
+func longestCommonSubsequence(expected, actual []interface{}) [][2]int {
+	lengths := make([][]int, len(expected)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(actual)+1)
+	}
+	for i := len(expected) - 1; i >= 0; i-- {
+		for j := len(actual) - 1; j >= 0; j-- {
+			if reflect.DeepEqual(expected[i], actual[j]) {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	var pairs [][2]int
+	for i, j := 0, 0; i < len(expected) && j < len(actual); {
+		switch {
+		case reflect.DeepEqual(expected[i], actual[j]):
+			pairs = append(pairs, [2]int{i, j})
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return pairs
+}
+
+// JoinKey returns the path of the key of the map at path, quoting keys which are no plain path segment
+func JoinKey(path, key string) string {
+	if !plainKey.MatchString(key) {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// JoinIndex returns the path of the item at idx of the list at path
+func JoinIndex(path string, idx int) string {
+	return fmt.Sprintf("%s[%d]", path, idx)
+}
+
+// formatValue returns the value as single line yaml
+func formatValue(value interface{}) string {
+	var node yamlv3.Node
+	if err := node.Encode(value); err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	setFlowStyle(&node)
+
+	content, err := yamlv3.Marshal(&node)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return strings.TrimSpace(string(content))
+}
+
+func setFlowStyle(node *yamlv3.Node) {
+	if node.Kind == yamlv3.MappingNode || node.Kind == yamlv3.SequenceNode {
+		node.Style = yamlv3.FlowStyle
+	}
+	if node.Kind == yamlv3.ScalarNode && strings.Contains(node.Value, "\n") {
+		node.Style = yamlv3.DoubleQuotedStyle
+	}
+	for _, child := range node.Content {
+		setFlowStyle(child)
+	}
+}
@@ -0,0 +1,144 @@
+package yamldiff_test
+
+import (
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/yamldiff"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareWhenEqual(t *testing.T) {
+	a := assert.New(t)
+	expected := map[string]interface{}{"a": []interface{}{1, "b"}}
+	actual := common.K8sManifest{"a": []interface{}{1, "b"}}
+
+	changes, err := Compare(expected, actual)
+
+	a.Nil(err)
+	a.Empty(changes)
+}
+
+func TestCompareWhenValueChanged(t *testing.T) {
+	a := assert.New(t)
+	expected := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "app", "image": "nginx:1.0"},
+			},
+		},
+	}
+	actual := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "app", "image": "nginx:1.1"},
+			},
+		},
+	}
+
+	changes, err := Compare(expected, actual)
+
+	a.Nil(err)
+	a.Equal([]Change{
+		{Op: Changed, Path: "spec.containers[0].image", Expected: "nginx:1.0", Actual: "nginx:1.1"},
+	}, changes)
+	a.Equal("~ spec.containers[0].image: nginx:1.0 → nginx:1.1", Format(changes))
+}
+
+func TestCompareWhenKeysAddedAndRemoved(t *testing.T) {
+	a := assert.New(t)
+	expected := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{"app": "web", "team": "a"},
+		},
+	}
+	actual := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels":      map[string]interface{}{"app": "web"},
+			"annotations": map[string]interface{}{"checksum/config": "abc"},
+		},
+	}
+
+	changes, err := Compare(expected, actual)
+
+	a.Nil(err)
+	a.Equal(
+		"+ metadata.annotations: added {checksum/config: abc}\n"+
+			"- metadata.labels.team: removed a",
+		Format(changes),
+	)
+}
+
+func TestCompareWhenKeyNeedsQuoting(t *testing.T) {
+	a := assert.New(t)
+	expected := map[string]interface{}{"annotations": map[string]interface{}{"checksum/config": "abc"}}
+	actual := map[string]interface{}{"annotations": map[string]interface{}{"checksum/config": "def"}}
+
+	changes, err := Compare(expected, actual)
+
+	a.Nil(err)
+	a.Equal(`~ annotations["checksum/config"]: abc → def`, Format(changes))
+}
+
+func TestCompareWhenListItemMoved(t *testing.T) {
+	a := assert.New(t)
+	expected := []interface{}{"a", "b", "c"}
+	actual := []interface{}{"c", "a", "b"}
+
+	changes, err := Compare(expected, actual)
+
+	a.Nil(err)
+	a.Equal("^ [2]: moved to [0]", Format(changes))
+}
+
+func TestCompareWhenListItemsInsertedAndRemoved(t *testing.T) {
+	a := assert.New(t)
+	expected := map[string]interface{}{"env": []interface{}{
+		map[string]interface{}{"name": "A", "value": "1"},
+		map[string]interface{}{"name": "B", "value": "2"},
+	}}
+	actual := map[string]interface{}{"env": []interface{}{
+		map[string]interface{}{"name": "C", "value": "3"},
+		map[string]interface{}{"name": "A", "value": "1"},
+		map[string]interface{}{"name": "B", "value": "2"},
+		map[string]interface{}{"name": "D", "value": "4"},
+	}}
+
+	changes, err := Compare(expected, actual)
+
+	a.Nil(err)
+	a.Equal(
+		"+ env[0]: added {name: C, value: \"3\"}\n"+
+			"+ env[3]: added {name: D, value: \"4\"}",
+		Format(changes),
+	)
+}
+
+func TestCompareWhenTypeChanged(t *testing.T) {
+	a := assert.New(t)
+
+	changes, err := Compare(map[string]interface{}{"a": "1"}, map[string]interface{}{"a": 1})
+
+	a.Nil(err)
+	a.Equal("~ a: \"1\" → 1", Format(changes))
+}
+
+func TestCompareWhenRootChanged(t *testing.T) {
+	a := assert.New(t)
+
+	changes, err := Compare(map[string]interface{}{"a": 1}, []interface{}{1})
+
+	a.Nil(err)
+	a.Equal("~ (root): {a: 1} → [1]", Format(changes))
+}
+
+func TestIsStructured(t *testing.T) {
+	a := assert.New(t)
+
+	a.True(IsStructured(map[string]interface{}{}))
+	a.True(IsStructured(common.K8sManifest{}))
+	a.True(IsStructured([]interface{}{}))
+	a.False(IsStructured("a"))
+	a.False(IsStructured(1))
+	a.False(IsStructured(nil))
+}